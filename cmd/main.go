@@ -60,7 +60,9 @@ func main() {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	if err := e.Shutdown(ctx); err != nil {
+	err = e.Shutdown(ctx)
+	server.Stop()
+	if err != nil {
 		log.Error("Server forced to shutdown:", "error", err)
 		os.Exit(1)
 	}
@@ -4,6 +4,7 @@ import (
 	"log"
 	"log/slog"
 	"os"
+	"strconv"
 )
 
 type Config struct {
@@ -17,6 +18,35 @@ type Config struct {
 	Banner      string
 	SentryUrl   string
 	AworkApiKey string
+	// CarryOverExpiry is the month and day (MM-DD) in the following year until
+	// which carried over vacation can be taken.
+	CarryOverExpiry string
+	// CarryOverCap limits the carried over days, a negative value disables it.
+	CarryOverCap float64
+	// StandardWorkdaysWeek is the full time week of the organisation, vacation
+	// of part time users is scaled relative to it.
+	StandardWorkdaysWeek float64
+	// HolidayRegion is the German state whose public holidays are created.
+	HolidayRegion string
+	// ReminderDays is the interval in days in which the approvers of a
+	// pending request are reminded, zero disables reminders.
+	ReminderDays float64
+	// EscalationDays is the number of days after which a pending request is
+	// escalated to the fallback approver, zero disables escalation.
+	EscalationDays float64
+	// EscalationApprover is the username of the fallback approver, the
+	// admins are used if it is empty.
+	EscalationApprover string
+	// AutoBreakDeduction takes the break the working time act requires but
+	// was not recorded off the worked time of a day.
+	AutoBreakDeduction bool
+	// TimerClosePolicy decides when timers that were left running are
+	// stopped: end_of_day, fixed_time at TimerCloseAt (HH:MM, server local
+	// time), max_hours after TimerMaxHours or schedule at the scheduled hours
+	// of the day, at the end of the day if none are scheduled.
+	TimerClosePolicy string
+	TimerCloseAt     string
+	TimerMaxHours    float64
 }
 
 var config *Config
@@ -38,7 +68,18 @@ func NewConfigFromEnv() *Config {
 			"SENTRY_URL",
 			func() bool { return loadDefault("DEBUG", "0") == "0" },
 		),
-		AworkApiKey: loadDefault("AWORK_API_KEY", ""),
+		AworkApiKey:          loadDefault("AWORK_API_KEY", ""),
+		CarryOverExpiry:      loadDefault("CARRY_OVER_EXPIRY", "03-31"),
+		CarryOverCap:         loadFloat("CARRY_OVER_CAP", -1),
+		StandardWorkdaysWeek: loadFloat("STANDARD_WORKDAYS_WEEK", 5),
+		HolidayRegion:        loadDefault("HOLIDAY_REGION", "BW"),
+		ReminderDays:         loadFloat("REMINDER_DAYS", 3),
+		EscalationDays:       loadFloat("ESCALATION_DAYS", 7),
+		EscalationApprover:   loadDefault("ESCALATION_APPROVER", ""),
+		AutoBreakDeduction:   loadDefault("AUTO_BREAK_DEDUCTION", "0") == "1",
+		TimerClosePolicy:     loadDefault("TIMER_CLOSE_POLICY", "end_of_day"),
+		TimerCloseAt:         loadDefault("TIMER_CLOSE_AT", "18:00"),
+		TimerMaxHours:        loadFloat("TIMER_MAX_HOURS", 10),
 	}
 
 	slog.Info("Config loaded")
@@ -71,3 +112,17 @@ func loadDefault(envVar, defaultVal string) string {
 
 	return valEnv
 }
+
+func loadFloat(envVar string, defaultVal float64) float64 {
+	valEnv := os.Getenv(envVar)
+	if valEnv == "" {
+		return defaultVal
+	}
+
+	val, err := strconv.ParseFloat(valEnv, 64)
+	if err != nil {
+		log.Fatalf("Environment variable \"%v\" is not a number", envVar)
+	}
+
+	return val
+}
@@ -19,13 +19,14 @@ func NewDB(name string) *sql.DB {
 
 	dbPath := filepath.Join("db", name)
 
-	db, err := sql.Open("sqlite3", dbPath)
+	// foreign_keys is a per connection setting, so it goes into the DSN to
+	// apply to every pooled connection and not just the first one
+	db, err := sql.Open("sqlite3", dbPath+"?_foreign_keys=on")
 	if err != nil {
 		slog.Error("Failed to open database", "error", err)
 	}
 
 	pragmas := []string{
-		"PRAGMA foreign_keys=ON;",
 		"PRAGMA journal_mode=WAL;",
 		"PRAGMA synchronous=NORMAL;",
 		"PRAGMA busy_timeout=5000;",
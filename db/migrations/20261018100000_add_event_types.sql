@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS event_types (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE,
+    label TEXT NOT NULL,
+    weight FLOAT NOT NULL DEFAULT 1.0,
+    consumes_vacation BOOLEAN NOT NULL DEFAULT 0,
+    needs_approval BOOLEAN NOT NULL DEFAULT 0,
+    counts_as_worked BOOLEAN NOT NULL DEFAULT 0,
+    color TEXT NOT NULL DEFAULT '#000000',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    edited_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO event_types (name, label, weight, consumes_vacation, needs_approval, counts_as_worked, color)
+VALUES
+    ('urlaub', 'Urlaub', 1.0, 1, 1, 0, '#22C55E'),
+    ('urlaub halbtags', 'Urlaub Halbtags', 0.5, 1, 1, 0, '#86EFAC'),
+    ('krank', 'Krank', 1.0, 0, 0, 1, '#EF4444'),
+    ('home office', 'Home Office', 1.0, 0, 0, 0, '#3B82F6');
+
+-- +goose Down
+DROP TABLE IF EXISTS event_types;
@@ -0,0 +1,80 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS absences (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    start_date DATETIME NOT NULL,
+    end_date DATETIME NOT NULL,
+    half_day_start BOOLEAN NOT NULL DEFAULT 0,
+    half_day_end BOOLEAN NOT NULL DEFAULT 0,
+    state TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    edited_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    user_id INTEGER NOT NULL,
+    FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+ALTER TABLE events ADD COLUMN half_day BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE events ADD COLUMN absence_id INTEGER REFERENCES absences(id) ON DELETE CASCADE;
+ALTER TABLE requests ADD COLUMN absence_id INTEGER REFERENCES absences(id) ON DELETE CASCADE;
+
+-- every existing request becomes a single day absence that reuses the request id
+INSERT INTO absences (id, name, start_date, end_date, state, created_at, edited_at, user_id)
+SELECT r.id, e.name, e.scheduled_at, e.scheduled_at, r.state, r.created_at, r.edited_at, r.user_id
+FROM requests r
+JOIN events e ON r.event_id = e.id;
+
+UPDATE requests SET absence_id = id;
+
+UPDATE events
+SET absence_id = (SELECT r.id FROM requests r WHERE r.event_id = events.id)
+WHERE id IN (SELECT event_id FROM requests);
+
+CREATE INDEX IF NOT EXISTS idx_events_absence_id ON events(absence_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_events_absence_id;
+
+-- SQLite can not drop columns with a reference, so both tables are rebuilt.
+-- requests_old references events_old, dropping events then cascades nowhere
+-- and the rename moves the reference back to events.
+CREATE TABLE IF NOT EXISTS events_old (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    scheduled_at DATETIME NOT NULL,
+    name TEXT NOT NULL,
+    state TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    edited_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    user_id INTEGER NOT NULL,
+    FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+INSERT INTO events_old (id, scheduled_at, name, state, created_at, edited_at, user_id)
+SELECT id, scheduled_at, name, state, created_at, edited_at, user_id FROM events;
+
+CREATE TABLE IF NOT EXISTS requests_old (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  message TEXT,
+  state TEXT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  edited_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  user_id INTEGER NOT NULL,
+  edited_by INTEGER ,
+  event_id INTEGER NOT NULL,
+
+  FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY(edited_by) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY(event_id) REFERENCES events_old(id) ON DELETE CASCADE
+);
+
+INSERT INTO requests_old (id, message, state, created_at, edited_at, user_id, edited_by, event_id)
+SELECT id, message, state, created_at, edited_at, user_id, edited_by, event_id FROM requests;
+
+DROP TABLE requests;
+DROP TABLE events;
+ALTER TABLE events_old RENAME TO events;
+ALTER TABLE requests_old RENAME TO requests;
+
+DROP TABLE IF EXISTS absences;
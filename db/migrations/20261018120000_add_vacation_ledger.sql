@@ -0,0 +1,59 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS vacation_tokens_new (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  start_date DATETIME NOT NULL,
+  end_date DATETIME NOT NULL,
+  value FLOAT NOT NULL,
+
+  user_id INTEGER NOT NULL,
+
+  reason TEXT NOT NULL DEFAULT 'admin_adjustment',
+  -- request and event ids are kept without foreign keys so the ledger
+  -- still explains a balance after the request or event was deleted
+  request_id INTEGER,
+  event_id INTEGER,
+  created_by INTEGER,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY(created_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- positive tokens matching the users yearly vacation days are the yearly grants,
+-- negative tokens were deducted for accepted vacation days, everything else
+-- can't be told apart anymore and counts as an adjustment
+INSERT INTO vacation_tokens_new (id, start_date, end_date, value, user_id, reason, created_at)
+SELECT t.id, t.start_date, t.end_date, t.value, t.user_id,
+  CASE
+    WHEN t.value = u.vacation_days THEN 'yearly_grant'
+    WHEN t.value < 0 THEN 'request_accepted'
+    ELSE 'admin_adjustment'
+  END,
+  t.start_date
+FROM vacation_tokens t
+JOIN users u ON t.user_id = u.id;
+
+DROP TABLE vacation_tokens;
+ALTER TABLE vacation_tokens_new RENAME TO vacation_tokens;
+
+CREATE INDEX IF NOT EXISTS idx_vacation_tokens_user_id ON vacation_tokens(user_id, start_date);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_vacation_tokens_user_id;
+
+CREATE TABLE IF NOT EXISTS vacation_tokens_old (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  start_date DATETIME NOT NULL,
+  end_date DATETIME NOT NULL,
+  value FLOAT NOT NULL,
+
+  user_id INTEGER NOT NULL,
+
+  FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+INSERT INTO vacation_tokens_old (id, start_date, end_date, value, user_id)
+SELECT id, start_date, end_date, value, user_id FROM vacation_tokens;
+
+DROP TABLE vacation_tokens;
+ALTER TABLE vacation_tokens_old RENAME TO vacation_tokens;
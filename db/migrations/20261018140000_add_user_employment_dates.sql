@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN entry_date DATETIME;
+ALTER TABLE users ADD COLUMN exit_date DATETIME;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN exit_date;
+ALTER TABLE users DROP COLUMN entry_date;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS user_contracts (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  valid_from DATETIME NOT NULL,
+  valid_to DATETIME,
+  workday_hours FLOAT NOT NULL,
+  workdays_week FLOAT NOT NULL,
+  vacation_days INTEGER NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  user_id INTEGER NOT NULL,
+  created_by INTEGER,
+  FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY(created_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_contracts_user_id ON user_contracts(user_id);
+
+-- the current terms of every user become an open ended contract so past
+-- years keep their numbers once the terms change
+INSERT INTO user_contracts (valid_from, workday_hours, workdays_week, vacation_days, user_id)
+SELECT '1970-01-01 00:00:00+00:00', workday_hours, workdays_week, vacation_days, id
+FROM users;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_user_contracts_user_id;
+DROP TABLE IF EXISTS user_contracts;
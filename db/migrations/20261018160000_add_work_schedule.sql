@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN schedule TEXT;
+ALTER TABLE user_contracts ADD COLUMN schedule TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE user_contracts DROP COLUMN schedule;
+ALTER TABLE users DROP COLUMN schedule;
+-- +goose StatementEnd
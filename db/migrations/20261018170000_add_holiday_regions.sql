@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN region TEXT;
+ALTER TABLE events ADD COLUMN region TEXT;
+ALTER TABLE api_cache ADD COLUMN region TEXT NOT NULL DEFAULT 'BW';
+
+-- holidays have been fetched for Baden-Württemberg so far, they are tagged
+-- on startup once the bot user is known by name
+
+CREATE INDEX IF NOT EXISTS idx_events_region ON events(region, scheduled_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_events_region;
+ALTER TABLE api_cache DROP COLUMN region;
+ALTER TABLE events DROP COLUMN region;
+ALTER TABLE users DROP COLUMN region;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS company_holidays (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  date DATETIME NOT NULL,
+  name TEXT NOT NULL,
+  weight FLOAT NOT NULL DEFAULT 1.0,
+  -- NULL applies the closure to every region
+  region TEXT,
+  consumes_vacation BOOLEAN NOT NULL DEFAULT 0,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  -- holiday event of the bot user that shows the closure in the calendar
+  event_id INTEGER,
+  created_by INTEGER,
+
+  FOREIGN KEY(event_id) REFERENCES events(id) ON DELETE SET NULL,
+  FOREIGN KEY(created_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_company_holidays_date ON company_holidays(date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_company_holidays_date;
+DROP TABLE IF EXISTS company_holidays;
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS teams (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  name TEXT NOT NULL UNIQUE,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- every user belongs to at most one team
+CREATE TABLE IF NOT EXISTS team_members (
+  user_id INTEGER PRIMARY KEY,
+  team_id INTEGER NOT NULL,
+
+  FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY(team_id) REFERENCES teams(id) ON DELETE CASCADE
+);
+
+-- approvers decide the requests of a single user or of every member of a
+-- team, stage 1 is the team lead and the optional stage 2 e.g. HR
+CREATE TABLE IF NOT EXISTS approvers (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  stage INTEGER NOT NULL DEFAULT 1 CHECK (stage IN (1, 2)),
+  approver_id INTEGER NOT NULL,
+  user_id INTEGER,
+  team_id INTEGER,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  CHECK ((user_id IS NULL) != (team_id IS NULL)),
+  FOREIGN KEY(approver_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY(team_id) REFERENCES teams(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_members_team ON team_members(team_id);
+CREATE INDEX IF NOT EXISTS idx_approvers_user ON approvers(user_id);
+CREATE INDEX IF NOT EXISTS idx_approvers_team ON approvers(team_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_approvers_team;
+DROP INDEX IF EXISTS idx_approvers_user;
+DROP INDEX IF EXISTS idx_team_members_team;
+DROP TABLE IF EXISTS approvers;
+DROP TABLE IF EXISTS team_members;
+DROP TABLE IF EXISTS teams;
+-- +goose StatementEnd
@@ -0,0 +1,47 @@
+-- +goose Up
+-- +goose StatementBegin
+-- cancellations ask to withdraw a range of an accepted absence, the absence
+-- id is cleared once a withdrawal removed the whole absence
+CREATE TABLE IF NOT EXISTS cancellations (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  absence_id INTEGER,
+  user_id INTEGER NOT NULL,
+  start_date DATETIME NOT NULL,
+  end_date DATETIME NOT NULL,
+  reason TEXT,
+  state TEXT NOT NULL DEFAULT 'pending',
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  decided_by INTEGER,
+  decided_at DATETIME,
+
+  FOREIGN KEY(absence_id) REFERENCES absences(id) ON DELETE SET NULL,
+  FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY(decided_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_cancellations_absence ON cancellations(absence_id);
+CREATE INDEX IF NOT EXISTS idx_cancellations_state ON cancellations(state);
+
+-- tokens remember their absence so partial refunds still find the booking
+-- after some of its events were removed
+ALTER TABLE vacation_tokens ADD COLUMN absence_id INTEGER;
+
+UPDATE vacation_tokens
+SET absence_id = (SELECT r.absence_id FROM requests r WHERE r.id = vacation_tokens.request_id)
+WHERE request_id IS NOT NULL;
+
+UPDATE vacation_tokens
+SET absence_id = (SELECT e.absence_id FROM events e WHERE e.id = vacation_tokens.event_id)
+WHERE absence_id IS NULL AND event_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_vacation_tokens_absence ON vacation_tokens(absence_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_vacation_tokens_absence;
+ALTER TABLE vacation_tokens DROP COLUMN absence_id;
+DROP INDEX IF EXISTS idx_cancellations_state;
+DROP INDEX IF EXISTS idx_cancellations_absence;
+DROP TABLE IF EXISTS cancellations;
+-- +goose StatementEnd
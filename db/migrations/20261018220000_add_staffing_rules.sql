@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+-- staffing rules require a minimum number of people present on every working
+-- day, either per team or, without a team, across the company
+CREATE TABLE IF NOT EXISTS staffing_rules (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  team_id INTEGER,
+  min_present INTEGER NOT NULL,
+  severity TEXT NOT NULL DEFAULT 'warning' CHECK (severity IN ('warning', 'error')),
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  FOREIGN KEY(team_id) REFERENCES teams(id) ON DELETE CASCADE
+);
+
+-- blackout periods disallow vacation (error) or make it need approval
+-- (warning), for a single team or everyone
+CREATE TABLE IF NOT EXISTS blackout_periods (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  name TEXT NOT NULL,
+  start_date DATETIME NOT NULL,
+  end_date DATETIME NOT NULL,
+  team_id INTEGER,
+  severity TEXT NOT NULL DEFAULT 'error' CHECK (severity IN ('warning', 'error')),
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  FOREIGN KEY(team_id) REFERENCES teams(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_blackout_periods_range ON blackout_periods(start_date, end_date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_blackout_periods_range;
+DROP TABLE IF EXISTS blackout_periods;
+DROP TABLE IF EXISTS staffing_rules;
+-- +goose StatementEnd
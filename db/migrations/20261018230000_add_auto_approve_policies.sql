@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- auto approve policies accept absence requests on behalf of the bot user,
+-- for a single event type or any type without event_name and up to max_days
+-- working days, or any length without max_days
+CREATE TABLE IF NOT EXISTS auto_approve_policies (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  name TEXT NOT NULL,
+  event_name TEXT,
+  max_days REAL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS auto_approve_policies;
+-- +goose StatementEnd
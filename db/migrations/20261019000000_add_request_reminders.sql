@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- pending requests remind their approvers regularly and escalate to the
+-- fallback approver once, both start over with the next approval stage
+ALTER TABLE requests ADD COLUMN reminder_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE requests ADD COLUMN last_reminded_at DATETIME;
+ALTER TABLE requests ADD COLUMN escalated_at DATETIME;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE requests DROP COLUMN escalated_at;
+ALTER TABLE requests DROP COLUMN last_reminded_at;
+ALTER TABLE requests DROP COLUMN reminder_count;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- comments let the requester and the approvers discuss a request, the reason
+-- of a rejection is stored as a comment of kind rejection
+CREATE TABLE IF NOT EXISTS request_comments (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  request_id INTEGER NOT NULL,
+  user_id INTEGER NOT NULL,
+  kind TEXT NOT NULL DEFAULT 'comment' CHECK (kind IN ('comment', 'rejection')),
+  body TEXT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  FOREIGN KEY(request_id) REFERENCES requests(id) ON DELETE CASCADE,
+  FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_request_comments_request ON request_comments(request_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_request_comments_request;
+DROP TABLE IF EXISTS request_comments;
+-- +goose StatementEnd
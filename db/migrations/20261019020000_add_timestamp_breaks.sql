@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- breaks interrupt a timestamp, an open break is a paused timer
+CREATE TABLE IF NOT EXISTS timestamp_breaks (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  timestamp_id INTEGER NOT NULL,
+  start_time DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  end_time DATETIME,
+
+  FOREIGN KEY(timestamp_id) REFERENCES timestamps(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_timestamp_breaks_timestamp ON timestamp_breaks(timestamp_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_timestamp_breaks_timestamp;
+DROP TABLE IF EXISTS timestamp_breaks;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- timers that were left running are stopped by the timers job and flagged so
+-- the user is asked to correct them, editing the timestamp clears the flag
+ALTER TABLE timestamps ADD COLUMN auto_closed BOOLEAN NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE timestamps DROP COLUMN auto_closed;
+-- +goose StatementEnd
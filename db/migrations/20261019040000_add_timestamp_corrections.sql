@@ -0,0 +1,60 @@
+-- +goose Up
+-- +goose StatementBegin
+-- corrections ask to change, add or delete a timestamp, the timestamp id of
+-- an added entry is set once it is accepted. They are decided by the admins
+-- and reminded like requests, but kept apart from requests, whose rows belong
+-- to an absence and its events.
+CREATE TABLE IF NOT EXISTS timestamp_corrections (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL,
+  timestamp_id INTEGER,
+  kind TEXT NOT NULL,
+  start_time DATETIME,
+  end_time DATETIME,
+  reason TEXT,
+  state TEXT NOT NULL DEFAULT 'pending',
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  decided_by INTEGER,
+  decided_at DATETIME,
+  reminder_count INTEGER NOT NULL DEFAULT 0,
+  last_reminded_at DATETIME,
+
+  FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY(timestamp_id) REFERENCES timestamps(id) ON DELETE SET NULL,
+  FOREIGN KEY(decided_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_timestamp_corrections_user ON timestamp_corrections(user_id);
+CREATE INDEX IF NOT EXISTS idx_timestamp_corrections_state ON timestamp_corrections(state);
+
+-- the history keeps the values of a timestamp before each correction, it
+-- outlives deleted timestamps
+CREATE TABLE IF NOT EXISTS timestamp_history (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  timestamp_id INTEGER NOT NULL,
+  user_id INTEGER NOT NULL,
+  correction_id INTEGER,
+  kind TEXT NOT NULL,
+  old_start_time DATETIME,
+  old_end_time DATETIME,
+  new_start_time DATETIME,
+  new_end_time DATETIME,
+  changed_by INTEGER,
+  changed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY(correction_id) REFERENCES timestamp_corrections(id) ON DELETE SET NULL,
+  FOREIGN KEY(changed_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_timestamp_history_timestamp ON timestamp_history(timestamp_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_timestamp_history_timestamp;
+DROP TABLE IF EXISTS timestamp_history;
+DROP INDEX IF EXISTS idx_timestamp_corrections_state;
+DROP INDEX IF EXISTS idx_timestamp_corrections_user;
+DROP TABLE IF EXISTS timestamp_corrections;
+-- +goose StatementEnd
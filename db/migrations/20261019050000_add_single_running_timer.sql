@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+-- stop the older of several running timers of a user when the next one
+-- started, they are flagged like the timers the auto close job stops
+UPDATE timestamps
+SET end_time = (
+  SELECT MIN(t.start_time) FROM timestamps t
+  WHERE t.user_id = timestamps.user_id
+  AND t.id > timestamps.id
+),
+auto_closed = 1
+WHERE end_time IS NULL
+AND id < (
+  SELECT MAX(t.id) FROM timestamps t
+  WHERE t.user_id = timestamps.user_id
+  AND t.end_time IS NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_timestamps_running ON timestamps(user_id)
+WHERE end_time IS NULL;
+
+-- idempotency keys remember the timestamp a clock in or out was applied to,
+-- so retried requests return it instead of applying it again
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL,
+  key TEXT NOT NULL,
+  action TEXT NOT NULL,
+  timestamp_id INTEGER,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY(timestamp_id) REFERENCES timestamps(id) ON DELETE SET NULL,
+  UNIQUE(user_id, key)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS idempotency_keys;
+DROP INDEX IF EXISTS idx_timestamps_running;
+-- +goose StatementEnd
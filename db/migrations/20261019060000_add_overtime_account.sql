@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+-- bookings change the overtime account of a user besides the worked hours,
+-- payouts and time off in lieu are stored as negative hours
+CREATE TABLE IF NOT EXISTS overtime_bookings (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  user_id INTEGER NOT NULL,
+  date DATETIME NOT NULL,
+  kind TEXT NOT NULL,
+  hours REAL NOT NULL,
+  reason TEXT,
+  created_by INTEGER,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  FOREIGN KEY(created_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_overtime_bookings_user ON overtime_bookings(user_id, date);
+
+-- snapshots close the overtime account of a user for a month, month is the
+-- first day of the month and balance the balance at its end
+CREATE TABLE IF NOT EXISTS overtime_snapshots (
+  user_id INTEGER NOT NULL,
+  month DATETIME NOT NULL,
+  worked REAL NOT NULL,
+  expected REAL NOT NULL,
+  booked REAL NOT NULL,
+  balance REAL NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  PRIMARY KEY(user_id, month),
+  FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS overtime_snapshots;
+DROP INDEX IF EXISTS idx_overtime_bookings_user;
+DROP TABLE IF EXISTS overtime_bookings;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- accepted days of event types that consume overtime take their scheduled
+-- hours off the overtime account instead of the vacation tokens
+ALTER TABLE event_types ADD COLUMN consumes_overtime BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE overtime_snapshots ADD COLUMN time_off REAL NOT NULL DEFAULT 0;
+
+INSERT INTO event_types (name, label, weight, consumes_vacation, needs_approval, counts_as_worked, color, consumes_overtime)
+VALUES ('ueberstundenabbau', 'Überstundenabbau', 1.0, 0, 1, 0, '#F59E0B', 1)
+ON CONFLICT (name) DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM event_types WHERE name = 'ueberstundenabbau';
+ALTER TABLE overtime_snapshots DROP COLUMN time_off;
+ALTER TABLE event_types DROP COLUMN consumes_overtime;
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- name: CreateAbsence :one
+INSERT INTO absences (name, user_id, start_date, end_date, half_day_start, half_day_end, state)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetAbsenceById :one
+SELECT * FROM absences
+WHERE id = ?;
+
+-- name: GetAbsenceByRequestId :one
+SELECT a.* FROM absences a
+JOIN requests r ON r.absence_id = a.id
+WHERE r.id = ?;
+
+-- name: GetAbsencesForUser :many
+SELECT * FROM absences
+WHERE user_id = ?
+AND end_date >= ?
+AND start_date < ?
+ORDER BY start_date;
+
+-- name: UpdateAbsenceState :one
+UPDATE absences
+SET state = ?,
+edited_at = CURRENT_TIMESTAMP
+WHERE id = ?
+RETURNING *;
+
+-- name: UpdateAbsenceRange :one
+UPDATE absences
+SET start_date = ?,
+end_date = ?,
+half_day_start = ?,
+half_day_end = ?,
+edited_at = CURRENT_TIMESTAMP
+WHERE id = ?
+RETURNING *;
+
+-- name: DeleteAbsence :exec
+DELETE FROM absences
+WHERE id = ?;
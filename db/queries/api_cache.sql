@@ -1,12 +1,12 @@
 -- name: CacheExists :one
 SELECT EXISTS(
     SELECT 1 FROM api_cache
-    WHERE year = ?
+    WHERE year = ? AND region = ?
 );
 
 -- name: CreateCache :exec
-INSERT INTO api_cache (year)
-VALUES (?);
+INSERT INTO api_cache (year, region)
+VALUES (?, ?);
 
 -- name: GetApiCacheYears :many
 SELECT year FROM api_cache
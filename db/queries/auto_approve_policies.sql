@@ -0,0 +1,12 @@
+-- name: CreateAutoApprovePolicy :one
+INSERT INTO auto_approve_policies (name, event_name, max_days)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: DeleteAutoApprovePolicy :exec
+DELETE FROM auto_approve_policies
+WHERE id = ?;
+
+-- name: GetAllAutoApprovePolicies :many
+SELECT * FROM auto_approve_policies
+ORDER BY id;
@@ -0,0 +1,31 @@
+-- name: CreateCancellation :one
+INSERT INTO cancellations (absence_id, user_id, start_date, end_date, reason)
+VALUES (?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetCancellationById :one
+SELECT * FROM cancellations
+WHERE id = ?;
+
+-- name: GetCancellationsForAbsence :many
+SELECT * FROM cancellations
+WHERE absence_id = ?
+ORDER BY start_date;
+
+-- name: GetPendingCancellations :many
+SELECT * FROM cancellations
+WHERE state = 'pending'
+ORDER BY created_at;
+
+-- name: ClearCancellationsAbsence :exec
+UPDATE cancellations
+SET absence_id = NULL
+WHERE absence_id = ?;
+
+-- name: UpdateCancellationState :one
+UPDATE cancellations
+SET state = ?,
+decided_by = ?,
+decided_at = CURRENT_TIMESTAMP
+WHERE id = ?
+RETURNING *;
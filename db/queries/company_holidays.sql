@@ -0,0 +1,28 @@
+-- name: CreateCompanyHoliday :one
+INSERT INTO company_holidays (date, name, weight, region, consumes_vacation, event_id, created_by)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetCompanyHolidayById :one
+SELECT * FROM company_holidays
+WHERE id = ?;
+
+-- name: GetCompanyHolidaysInRange :many
+SELECT * FROM company_holidays
+WHERE date >= ?
+  AND date <= ?
+ORDER BY date;
+
+-- name: UpdateCompanyHoliday :one
+UPDATE company_holidays
+SET date = ?,
+name = ?,
+weight = ?,
+region = ?,
+consumes_vacation = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: DeleteCompanyHoliday :exec
+DELETE FROM company_holidays
+WHERE id = ?;
@@ -0,0 +1,33 @@
+-- name: CreateEventType :one
+INSERT INTO event_types (name, label, weight, consumes_vacation, needs_approval, counts_as_worked, color, consumes_overtime)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetEventTypeById :one
+SELECT * FROM event_types
+WHERE id = ?;
+
+-- name: GetEventTypeByName :one
+SELECT * FROM event_types
+WHERE name = ?;
+
+-- name: GetAllEventTypes :many
+SELECT * FROM event_types
+ORDER BY id;
+
+-- name: UpdateEventType :one
+UPDATE event_types
+SET label = ?,
+weight = ?,
+consumes_vacation = ?,
+needs_approval = ?,
+counts_as_worked = ?,
+color = ?,
+consumes_overtime = ?,
+edited_at = CURRENT_TIMESTAMP
+WHERE id = ?
+RETURNING *;
+
+-- name: DeleteEventType :exec
+DELETE FROM event_types
+WHERE id = ?;
@@ -12,12 +12,13 @@ SELECT * FROM events
 WHERE Date(scheduled_at) = ?;
 
 -- name: GetEventsForYear :many
-SELECT * FROM events e
+SELECT e.*, u.* FROM events e
 JOIN users u ON e.user_id = u.id
+LEFT JOIN event_types t ON e.name = t.name
 WHERE e.scheduled_at >= ? 
   AND e.scheduled_at < ?
   AND e.state = "accepted"
-  AND (e.name IN ('urlaub', 'urlaub halbtags') OR e.user_id = 1)
+  AND (t.consumes_vacation = true OR u.username = ?)
 
 ORDER BY scheduled_at;
 
@@ -41,20 +42,14 @@ AND scheduled_at < ?
 AND user_id = ?;
 
 -- name: GetVacationCountForUser :one 
-SELECT 
-  SUM(
-    CASE
-      WHEN name = 'urlaub'          THEN 1
-      WHEN name = 'urlaub halbtags' THEN 0.5
-      ELSE 0
-    END
-  ) 
-FROM events
-WHERE user_id = ?
-  AND scheduled_at >= ?
-  AND scheduled_at < ?
-  AND name IN ('urlaub', 'urlaub halbtags')
-  AND state = 'accepted';
+SELECT SUM(t.weight * CASE WHEN e.half_day THEN 0.5 ELSE 1 END)
+FROM events e
+JOIN event_types t ON e.name = t.name
+WHERE e.user_id = ?
+  AND e.scheduled_at >= ?
+  AND e.scheduled_at < ?
+  AND t.consumes_vacation = true
+  AND e.state = 'accepted';
 
 -- name: UpdateEventState :one
 UPDATE events
@@ -63,13 +58,39 @@ edited_at = CURRENT_TIMESTAMP
 WHERE id = ?
 RETURNING *;
 
--- name: UpdateEventsRange :exec
+-- name: CreateAbsenceEvent :one
+INSERT INTO events (name, user_id, scheduled_at, state, half_day, absence_id)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetEventsByAbsenceId :many
+SELECT * FROM events
+WHERE absence_id = ?
+ORDER BY scheduled_at;
+
+-- name: DeleteAbsenceEvents :exec
+DELETE FROM events
+WHERE absence_id = ?;
+
+-- name: DeleteAbsenceEventsInRange :exec
+DELETE FROM events
+WHERE absence_id = ?
+AND scheduled_at >= ?
+AND scheduled_at <= ?;
+
+-- name: UpdateAbsenceEventsState :exec
 UPDATE events
-SET state = ?, 
+SET state = ?,
 edited_at = CURRENT_TIMESTAMP
-WHERE user_id = ? 
+WHERE absence_id = ?;
+
+-- name: GetAbsenceEventsInRange :many
+SELECT * FROM events
+WHERE absence_id IS NOT NULL
+AND state != 'declined'
 AND scheduled_at >= ?
-AND scheduled_at <= ?;
+AND scheduled_at <= ?
+ORDER BY scheduled_at;
 
 -- name: GetConflictingEventUsers :many
 SELECT DISTINCT u.* FROM events e
@@ -78,6 +99,46 @@ WHERE u.id != ?
 AND e.scheduled_at >= ?
 AND e.scheduled_at <= ?;
 
+-- name: GetEventsInRange :many
+SELECT * FROM events
+WHERE scheduled_at >= ?
+AND scheduled_at <= ?
+ORDER BY scheduled_at;
+
 -- name: GetEventsByUserId :many
 SELECT * from events
 WHERE user_id = ?;
+
+-- name: CreateHolidayEvent :one
+INSERT INTO events (name, user_id, scheduled_at, state, half_day, region)
+VALUES (?, ?, ?, 'accepted', ?, ?)
+RETURNING *;
+
+-- name: UpdateHolidayEvent :one
+UPDATE events
+SET name = ?,
+scheduled_at = ?,
+half_day = ?,
+region = ?,
+edited_at = CURRENT_TIMESTAMP
+WHERE id = ?
+RETURNING *;
+
+-- name: GetHolidaysForRegion :many
+SELECT e.id, e.scheduled_at, e.name, e.half_day, e.region, c.consumes_vacation
+FROM events e
+JOIN users u ON e.user_id = u.id
+LEFT JOIN company_holidays c ON c.event_id = e.id
+WHERE u.username = ?
+  AND (e.region IS NULL OR e.region = ?)
+  AND e.scheduled_at >= ?
+  AND e.scheduled_at <= ?
+ORDER BY e.scheduled_at;
+
+-- name: UpdateLegacyHolidayRegion :exec
+UPDATE events
+SET region = ?
+WHERE region IS NULL
+  AND absence_id IS NULL
+  AND user_id = (SELECT id FROM users WHERE username = ?)
+  AND id NOT IN (SELECT event_id FROM company_holidays WHERE event_id IS NOT NULL);
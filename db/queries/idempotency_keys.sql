@@ -0,0 +1,14 @@
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys
+WHERE user_id = ?
+AND key = ?
+AND created_at > ?;
+
+-- name: SaveIdempotencyKey :one
+INSERT INTO idempotency_keys (user_id, key, action, timestamp_id)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (user_id, key) DO UPDATE
+SET action = excluded.action,
+timestamp_id = excluded.timestamp_id,
+created_at = CURRENT_TIMESTAMP
+RETURNING *;
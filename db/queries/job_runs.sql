@@ -0,0 +1,22 @@
+-- name: CreateJobRun :one
+INSERT INTO job_runs (job)
+VALUES (?)
+RETURNING *;
+
+-- name: FinishJobRun :one
+UPDATE job_runs
+SET finished_at = CURRENT_TIMESTAMP,
+error = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: GetLastJobRun :one
+SELECT * FROM job_runs
+WHERE job = ?
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: GetLastJobRuns :many
+SELECT * FROM job_runs
+WHERE id IN (SELECT MAX(id) FROM job_runs GROUP BY job)
+ORDER BY job;
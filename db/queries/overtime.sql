@@ -0,0 +1,38 @@
+-- name: CreateOvertimeBooking :one
+INSERT INTO overtime_bookings (user_id, date, kind, hours, reason, created_by)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetOvertimeBookingsForUser :many
+SELECT * FROM overtime_bookings
+WHERE user_id = ?
+ORDER BY date, id;
+
+-- name: GetOvertimeBookingsInRange :many
+SELECT * FROM overtime_bookings
+WHERE user_id = ?
+AND date >= @start_date
+AND date <= @end_date
+ORDER BY date, id;
+
+-- name: GetOpeningOvertimeBooking :one
+SELECT * FROM overtime_bookings
+WHERE user_id = ?
+AND kind = 'opening';
+
+-- name: CreateOvertimeSnapshot :one
+INSERT INTO overtime_snapshots (user_id, month, worked, expected, booked, balance, time_off)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetOvertimeSnapshotsForUser :many
+SELECT * FROM overtime_snapshots
+WHERE user_id = ?
+ORDER BY month;
+
+-- name: GetLastOvertimeSnapshotBefore :one
+SELECT * FROM overtime_snapshots
+WHERE user_id = ?
+AND month < ?
+ORDER BY month DESC
+LIMIT 1;
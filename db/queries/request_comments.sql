@@ -0,0 +1,10 @@
+-- name: CreateRequestComment :one
+INSERT INTO request_comments (request_id, user_id, kind, body)
+VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetCommentsForRequest :many
+SELECT c.*, u.username FROM request_comments c
+JOIN users u ON u.id = c.user_id
+WHERE c.request_id = ?
+ORDER BY c.created_at, c.id;
@@ -1,6 +1,6 @@
 -- name: CreateRequest :one 
-INSERT INTO requests (message, state, user_id, event_id)
-VALUES (?, ?, ?, ?)
+INSERT INTO requests (message, state, user_id, event_id, absence_id)
+VALUES (?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: UpdateRequest :one
@@ -14,26 +14,47 @@ WHERE id = ?
 RETURNING *;
 
 -- name: GetPendingRequests :many
-SELECT * FROM requests r
+SELECT r.*, u.*, a.*,
+  (SELECT COUNT(*) FROM events e WHERE e.absence_id = a.id) AS event_count
+FROM requests r
 JOIN users u ON r.user_id = u.id
-JOIN events e ON r.event_id = e.id
-WHERE r.state = "pending"
-ORDER BY r.user_id, e.scheduled_at;
+JOIN absences a ON r.absence_id = a.id
+WHERE r.state IN ("pending", "approved_by_lead")
+ORDER BY r.user_id, a.start_date;
 
 
--- name: UpdateRequestStateRange :one
+-- name: UpdateAbsenceRequestState :exec
 UPDATE requests
 SET state = ?,
     edited_by = ?,
-    edited_at = CURRENT_TIMESTAMP
-WHERE requests.user_id = ?
-AND event_id IN (
-    SELECT e.id
-    FROM events e
-    WHERE e.scheduled_at >= ?
-      AND e.scheduled_at <= ?
-  )
-RETURNING requests.id;
+    edited_at = CURRENT_TIMESTAMP,
+    reminder_count = 0,
+    last_reminded_at = NULL,
+    escalated_at = NULL
+WHERE absence_id = ?;
+
+-- name: UpdateRequestReminder :exec
+UPDATE requests
+SET reminder_count = reminder_count + 1,
+    last_reminded_at = ?
+WHERE id = ?;
+
+-- name: UpdateRequestEscalation :exec
+UPDATE requests
+SET escalated_at = ?
+WHERE id = ?;
+
+-- name: DeleteAbsenceRequest :exec
+DELETE FROM requests
+WHERE absence_id = ?;
+
+-- name: GetRequestByAbsenceId :one
+SELECT * FROM requests
+WHERE absence_id = ?;
+
+-- name: GetRequestById :one
+SELECT * FROM requests
+WHERE id = ?;
 
 -- name: GetRequestRange :many
 SELECT r.* FROM requests r
@@ -18,3 +18,7 @@ WHERE id = ?;
 
 -- name: DeleteAllSessions :exec
 DELETE from sessions;
+
+-- name: DeleteExpiredSessions :execrows
+DELETE FROM sessions
+WHERE valid_until < ?;
@@ -0,0 +1,31 @@
+-- name: CreateStaffingRule :one
+INSERT INTO staffing_rules (team_id, min_present, severity)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: DeleteStaffingRule :exec
+DELETE FROM staffing_rules
+WHERE id = ?;
+
+-- name: GetAllStaffingRules :many
+SELECT * FROM staffing_rules
+ORDER BY id;
+
+-- name: CreateBlackoutPeriod :one
+INSERT INTO blackout_periods (name, start_date, end_date, team_id, severity)
+VALUES (?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: DeleteBlackoutPeriod :exec
+DELETE FROM blackout_periods
+WHERE id = ?;
+
+-- name: GetAllBlackoutPeriods :many
+SELECT * FROM blackout_periods
+ORDER BY start_date;
+
+-- name: GetBlackoutPeriodsInRange :many
+SELECT * FROM blackout_periods
+WHERE end_date >= ?
+AND start_date <= ?
+ORDER BY start_date;
@@ -0,0 +1,47 @@
+-- name: CreateTeam :one
+INSERT INTO teams (name)
+VALUES (?)
+RETURNING *;
+
+-- name: UpdateTeam :one
+UPDATE teams
+SET name = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: DeleteTeam :exec
+DELETE FROM teams
+WHERE id = ?;
+
+-- name: GetTeamById :one
+SELECT * FROM teams
+WHERE id = ?;
+
+-- name: GetAllTeams :many
+SELECT * FROM teams
+ORDER BY name;
+
+-- name: SetTeamMember :exec
+INSERT INTO team_members (user_id, team_id)
+VALUES (?, ?)
+ON CONFLICT(user_id) DO UPDATE SET team_id = excluded.team_id;
+
+-- name: DeleteTeamMember :exec
+DELETE FROM team_members
+WHERE user_id = ?;
+
+-- name: GetAllTeamMembers :many
+SELECT * FROM team_members;
+
+-- name: CreateApprover :one
+INSERT INTO approvers (stage, approver_id, user_id, team_id)
+VALUES (?, ?, ?, ?)
+RETURNING *;
+
+-- name: DeleteApprover :exec
+DELETE FROM approvers
+WHERE id = ?;
+
+-- name: GetAllApprovers :many
+SELECT * FROM approvers
+ORDER BY stage, id;
@@ -0,0 +1,74 @@
+-- name: CreateTimestampBreak :one
+INSERT INTO timestamp_breaks (timestamp_id, start_time, end_time)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: StartTimestampBreak :one
+INSERT INTO timestamp_breaks (timestamp_id)
+VALUES (?)
+RETURNING *;
+
+-- name: StopTimestampBreak :one
+UPDATE timestamp_breaks
+SET end_time = CURRENT_TIMESTAMP
+WHERE timestamp_id = ?
+AND end_time IS NULL
+RETURNING *;
+
+-- name: EndOpenTimestampBreaks :exec
+UPDATE timestamp_breaks
+SET end_time = @end_time
+WHERE timestamp_id = @timestamp_id
+AND end_time IS NULL
+AND start_time < @end_time;
+
+-- name: DeleteTimestampBreak :exec
+DELETE FROM timestamp_breaks
+WHERE id = ?;
+
+-- name: DeleteTimestampBreaksFrom :exec
+DELETE FROM timestamp_breaks
+WHERE timestamp_id = ?
+AND start_time >= ?;
+
+-- name: GetTimestampBreakById :one
+SELECT * FROM timestamp_breaks
+WHERE id = ?;
+
+-- name: GetBreaksForTimestamp :many
+SELECT * FROM timestamp_breaks
+WHERE timestamp_id = ?
+ORDER BY start_time;
+
+-- name: GetTimestampBreaksInRange :many
+SELECT timestamp_breaks.* FROM timestamp_breaks
+JOIN timestamps ON timestamps.id = timestamp_breaks.timestamp_id
+WHERE timestamps.user_id = ?
+AND timestamps.start_time < @end_time
+AND timestamps.end_time IS NOT NULL
+AND timestamps.end_time > @start_time
+ORDER BY timestamp_breaks.start_time;
+
+-- name: GetAllTimestampBreaksInRange :many
+SELECT timestamp_breaks.* FROM timestamp_breaks
+JOIN timestamps ON timestamps.id = timestamp_breaks.timestamp_id
+WHERE timestamps.start_time < @end_time
+AND timestamps.end_time IS NOT NULL
+AND timestamps.end_time > @start_time
+ORDER BY timestamp_breaks.start_time;
+
+-- name: GetTotalBreakSecondsInRange :one
+SELECT
+  SUM(
+    MAX(
+      0,
+      strftime('%s', MIN(timestamp_breaks.end_time, @range_end))
+      - strftime('%s', MAX(timestamp_breaks.start_time, @range_start))
+    )
+  ) AS total_seconds
+FROM timestamp_breaks
+JOIN timestamps ON timestamps.id = timestamp_breaks.timestamp_id
+WHERE timestamps.user_id = @user_id
+  AND timestamp_breaks.end_time IS NOT NULL
+  AND timestamp_breaks.start_time < @range_end
+  AND timestamp_breaks.end_time > @range_start;
@@ -0,0 +1,33 @@
+-- name: CreateTimestampCorrection :one
+INSERT INTO timestamp_corrections (user_id, timestamp_id, kind, start_time, end_time, reason)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetTimestampCorrectionById :one
+SELECT * FROM timestamp_corrections
+WHERE id = ?;
+
+-- name: GetTimestampCorrectionsForUser :many
+SELECT * FROM timestamp_corrections
+WHERE user_id = ?
+ORDER BY created_at DESC;
+
+-- name: GetPendingTimestampCorrections :many
+SELECT * FROM timestamp_corrections
+WHERE state = 'pending'
+ORDER BY created_at;
+
+-- name: DecideTimestampCorrection :one
+UPDATE timestamp_corrections
+SET state = ?,
+timestamp_id = ?,
+decided_by = ?,
+decided_at = CURRENT_TIMESTAMP
+WHERE id = ?
+RETURNING *;
+
+-- name: UpdateTimestampCorrectionReminder :exec
+UPDATE timestamp_corrections
+SET reminder_count = reminder_count + 1,
+    last_reminded_at = ?
+WHERE id = ?;
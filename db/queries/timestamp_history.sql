@@ -0,0 +1,19 @@
+-- name: CreateTimestampHistory :one
+INSERT INTO timestamp_history (
+  timestamp_id,
+  user_id,
+  correction_id,
+  kind,
+  old_start_time,
+  old_end_time,
+  new_start_time,
+  new_end_time,
+  changed_by
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: GetTimestampHistory :many
+SELECT * FROM timestamp_history
+WHERE timestamp_id = ?
+ORDER BY changed_at, id;
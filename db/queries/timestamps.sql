@@ -1,12 +1,19 @@
 -- name: StartTimestamp :one
 INSERT INTO timestamps (user_id)
 VALUES (?)
+ON CONFLICT (user_id) WHERE end_time IS NULL DO NOTHING
+RETURNING *;
+
+-- name: CreateTimestamp :one
+INSERT INTO timestamps (user_id, start_time, end_time)
+VALUES (?, ?, ?)
 RETURNING *;
 
 -- name: UpdateTimestamp :one
 UPDATE timestamps
 SET start_time = ?,
-end_time = ?
+end_time = ?,
+auto_closed = 0
 WHERE id = ?
 RETURNING *;
 
@@ -16,6 +23,14 @@ SET end_time = CURRENT_TIMESTAMP
 WHERE id = ?
 RETURNING *;
 
+-- name: AutoCloseTimestamp :one
+UPDATE timestamps
+SET end_time = ?,
+auto_closed = 1
+WHERE id = ?
+AND end_time IS NULL
+RETURNING *;
+
 -- name: DeleteTimestamp :exec
 DELETE FROM timestamps
 WHERE id = ?;
@@ -41,6 +56,12 @@ WHERE start_time < @end_time
 AND end_time IS NOT NULL
 AND end_time > @start_time;
 
+-- name: GetFirstTimestamp :one
+SELECT * FROM timestamps
+WHERE user_id = ?
+ORDER BY start_time
+LIMIT 1;
+
 -- name: GetLatestTimestamp :one
 SELECT * FROM timestamps
 WHERE user_id = ?
@@ -61,3 +82,13 @@ WHERE user_id = @user_id
   AND start_time < @range_end
   AND end_time > @range_start;
 
+
+-- name: GetRunningTimestamp :one
+SELECT * FROM timestamps
+WHERE user_id = ?
+AND end_time IS NULL;
+
+-- name: GetOpenTimestampsBefore :many
+SELECT * FROM timestamps
+WHERE end_time IS NULL
+AND start_time < ?;
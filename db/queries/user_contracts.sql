@@ -0,0 +1,24 @@
+-- name: CreateUserContract :one
+INSERT INTO user_contracts (valid_from, valid_to, workday_hours, workdays_week, vacation_days, user_id, created_by, schedule)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING *;
+
+-- name: UpdateUserContract :one
+UPDATE user_contracts
+SET valid_from = ?,
+valid_to = ?,
+workday_hours = ?,
+workdays_week = ?,
+vacation_days = ?,
+schedule = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: GetAllUserContracts :many
+SELECT * FROM user_contracts
+ORDER BY user_id, valid_from;
+
+-- name: GetUserContractsForUser :many
+SELECT * FROM user_contracts
+WHERE user_id = ?
+ORDER BY valid_from;
@@ -1,6 +1,6 @@
 -- name: CreateUser :one
-INSERT INTO users (username, color, vacation_days, email, password, is_superuser, awork_id, workday_hours, workdays_week)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+INSERT INTO users (username, color, vacation_days, email, password, is_superuser, awork_id, workday_hours, workdays_week, entry_date, exit_date, schedule, region)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: GetUserByID :one
@@ -31,6 +31,10 @@ vacation_days = ?,
 is_superuser = ?,
 workday_hours = ?,
 workdays_week = ?,
+entry_date = ?,
+exit_date = ?,
+schedule = ?,
+region = ?,
 edited_at = CURRENT_TIMESTAMP
 WHERE id = ?
 RETURNING *;
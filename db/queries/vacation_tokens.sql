@@ -1,6 +1,6 @@
 -- name: CreateVacationToken :one 
-INSERT INTO vacation_tokens (user_id, start_date, end_date, value)
-VALUES (?,?,?,?)
+INSERT INTO vacation_tokens (user_id, start_date, end_date, value, reason, request_id, event_id, created_by, absence_id)
+VALUES (?,?,?,?,?,?,?,?,?)
 RETURNING *;
 
 -- name: DeleteVacationToken :exec
@@ -15,3 +15,29 @@ AND end_date >= ?;
 
 -- name: DeleteAllVacationTokens :exec
 DELETE FROM vacation_tokens;
+
+-- name: GetVacationTokensForUser :many
+SELECT * FROM vacation_tokens
+WHERE user_id = ?
+AND start_date >= ?
+AND start_date < ?
+ORDER BY created_at, id;
+
+-- name: GetVacationPotsForUser :many
+SELECT start_date, end_date, CAST(SUM(value) AS FLOAT) AS value
+FROM vacation_tokens
+WHERE user_id = ?
+AND start_date <= ?
+AND end_date >= ?
+GROUP BY start_date, end_date
+ORDER BY end_date, start_date;
+
+-- name: GetVacationTokensForAbsence :many
+SELECT * FROM vacation_tokens
+WHERE absence_id = ?
+ORDER BY id;
+
+-- name: GetVacationTokensForEvent :many
+SELECT * FROM vacation_tokens
+WHERE event_id = ?
+ORDER BY id;
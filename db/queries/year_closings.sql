@@ -0,0 +1,8 @@
+-- name: CreateYearClosing :one
+INSERT INTO year_closings (year, closed_by)
+VALUES (?, ?)
+RETURNING *;
+
+-- name: GetYearClosing :one
+SELECT * FROM year_closings
+WHERE year = ?;
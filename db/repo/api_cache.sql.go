@@ -12,24 +12,34 @@ import (
 const CacheExists = `-- name: CacheExists :one
 SELECT EXISTS(
     SELECT 1 FROM api_cache
-    WHERE year = ?
+    WHERE year = ? AND region = ?
 )
 `
 
-func (q *Queries) CacheExists(ctx context.Context, year int64) (int64, error) {
-	row := q.db.QueryRowContext(ctx, CacheExists, year)
+type CacheExistsParams struct {
+	Year   int64  `json:"year"`
+	Region string `json:"region"`
+}
+
+func (q *Queries) CacheExists(ctx context.Context, arg CacheExistsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, CacheExists, arg.Year, arg.Region)
 	var column_1 int64
 	err := row.Scan(&column_1)
 	return column_1, err
 }
 
 const CreateCache = `-- name: CreateCache :exec
-INSERT INTO api_cache (year)
-VALUES (?)
+INSERT INTO api_cache (year, region)
+VALUES (?, ?)
 `
 
-func (q *Queries) CreateCache(ctx context.Context, year int64) error {
-	_, err := q.db.ExecContext(ctx, CreateCache, year)
+type CreateCacheParams struct {
+	Year   int64  `json:"year"`
+	Region string `json:"region"`
+}
+
+func (q *Queries) CreateCache(ctx context.Context, arg CreateCacheParams) error {
+	_, err := q.db.ExecContext(ctx, CreateCache, arg.Year, arg.Region)
 	return err
 }
 
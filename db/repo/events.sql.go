@@ -10,10 +10,50 @@ import (
 	"time"
 )
 
+const CreateAbsenceEvent = `-- name: CreateAbsenceEvent :one
+INSERT INTO events (name, user_id, scheduled_at, state, half_day, absence_id)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING id, scheduled_at, name, state, created_at, edited_at, user_id, half_day, absence_id, region
+`
+
+type CreateAbsenceEventParams struct {
+	Name        string    `json:"name"`
+	UserID      int64     `json:"user_id"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+	State       string    `json:"state"`
+	HalfDay     bool      `json:"half_day"`
+	AbsenceID   *int64    `json:"absence_id"`
+}
+
+func (q *Queries) CreateAbsenceEvent(ctx context.Context, arg CreateAbsenceEventParams) (Event, error) {
+	row := q.db.QueryRowContext(ctx, CreateAbsenceEvent,
+		arg.Name,
+		arg.UserID,
+		arg.ScheduledAt,
+		arg.State,
+		arg.HalfDay,
+		arg.AbsenceID,
+	)
+	var i Event
+	err := row.Scan(
+		&i.ID,
+		&i.ScheduledAt,
+		&i.Name,
+		&i.State,
+		&i.CreatedAt,
+		&i.EditedAt,
+		&i.UserID,
+		&i.HalfDay,
+		&i.AbsenceID,
+		&i.Region,
+	)
+	return i, err
+}
+
 const CreateEvent = `-- name: CreateEvent :one
 INSERT INTO events (name, user_id, scheduled_at, state)
 VALUES (?, ?, ?, ?)
-RETURNING id, scheduled_at, name, state, created_at, edited_at, user_id
+RETURNING id, scheduled_at, name, state, created_at, edited_at, user_id, half_day, absence_id, region
 `
 
 type CreateEventParams struct {
@@ -39,10 +79,79 @@ func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) (Event
 		&i.CreatedAt,
 		&i.EditedAt,
 		&i.UserID,
+		&i.HalfDay,
+		&i.AbsenceID,
+		&i.Region,
+	)
+	return i, err
+}
+
+const CreateHolidayEvent = `-- name: CreateHolidayEvent :one
+INSERT INTO events (name, user_id, scheduled_at, state, half_day, region)
+VALUES (?, ?, ?, 'accepted', ?, ?)
+RETURNING id, scheduled_at, name, state, created_at, edited_at, user_id, half_day, absence_id, region
+`
+
+type CreateHolidayEventParams struct {
+	Name        string    `json:"name"`
+	UserID      int64     `json:"user_id"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+	HalfDay     bool      `json:"half_day"`
+	Region      *string   `json:"region"`
+}
+
+func (q *Queries) CreateHolidayEvent(ctx context.Context, arg CreateHolidayEventParams) (Event, error) {
+	row := q.db.QueryRowContext(ctx, CreateHolidayEvent,
+		arg.Name,
+		arg.UserID,
+		arg.ScheduledAt,
+		arg.HalfDay,
+		arg.Region,
+	)
+	var i Event
+	err := row.Scan(
+		&i.ID,
+		&i.ScheduledAt,
+		&i.Name,
+		&i.State,
+		&i.CreatedAt,
+		&i.EditedAt,
+		&i.UserID,
+		&i.HalfDay,
+		&i.AbsenceID,
+		&i.Region,
 	)
 	return i, err
 }
 
+const DeleteAbsenceEvents = `-- name: DeleteAbsenceEvents :exec
+DELETE FROM events
+WHERE absence_id = ?
+`
+
+func (q *Queries) DeleteAbsenceEvents(ctx context.Context, absenceID *int64) error {
+	_, err := q.db.ExecContext(ctx, DeleteAbsenceEvents, absenceID)
+	return err
+}
+
+const DeleteAbsenceEventsInRange = `-- name: DeleteAbsenceEventsInRange :exec
+DELETE FROM events
+WHERE absence_id = ?
+AND scheduled_at >= ?
+AND scheduled_at <= ?
+`
+
+type DeleteAbsenceEventsInRangeParams struct {
+	AbsenceID     *int64    `json:"absence_id"`
+	ScheduledAt   time.Time `json:"scheduled_at"`
+	ScheduledAt_2 time.Time `json:"scheduled_at_2"`
+}
+
+func (q *Queries) DeleteAbsenceEventsInRange(ctx context.Context, arg DeleteAbsenceEventsInRangeParams) error {
+	_, err := q.db.ExecContext(ctx, DeleteAbsenceEventsInRange, arg.AbsenceID, arg.ScheduledAt, arg.ScheduledAt_2)
+	return err
+}
+
 const DeleteEvent = `-- name: DeleteEvent :exec
 DELETE FROM events
 WHERE id = ?
@@ -53,8 +162,56 @@ func (q *Queries) DeleteEvent(ctx context.Context, id int64) error {
 	return err
 }
 
+const GetAbsenceEventsInRange = `-- name: GetAbsenceEventsInRange :many
+SELECT id, scheduled_at, name, state, created_at, edited_at, user_id, half_day, absence_id, region FROM events
+WHERE absence_id IS NOT NULL
+AND state != 'declined'
+AND scheduled_at >= ?
+AND scheduled_at <= ?
+ORDER BY scheduled_at
+`
+
+type GetAbsenceEventsInRangeParams struct {
+	ScheduledAt   time.Time `json:"scheduled_at"`
+	ScheduledAt_2 time.Time `json:"scheduled_at_2"`
+}
+
+func (q *Queries) GetAbsenceEventsInRange(ctx context.Context, arg GetAbsenceEventsInRangeParams) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, GetAbsenceEventsInRange, arg.ScheduledAt, arg.ScheduledAt_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.ID,
+			&i.ScheduledAt,
+			&i.Name,
+			&i.State,
+			&i.CreatedAt,
+			&i.EditedAt,
+			&i.UserID,
+			&i.HalfDay,
+			&i.AbsenceID,
+			&i.Region,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const GetConflictingEventUsers = `-- name: GetConflictingEventUsers :many
-SELECT DISTINCT u.id, u.username, u.email, u.password, u.vacation_days, u.is_superuser, u.created_at, u.edited_at, u.color, u.role, u.enabled, u.awork_id, u.workday_hours, u.workdays_week FROM events e
+SELECT DISTINCT u.id, u.username, u.email, u.password, u.vacation_days, u.is_superuser, u.created_at, u.edited_at, u.color, u.role, u.enabled, u.awork_id, u.workday_hours, u.workdays_week, u.entry_date, u.exit_date, u.schedule, u.region FROM events e
 JOIN users u on e.user_id = u.id
 WHERE u.id != ? 
 AND e.scheduled_at >= ?
@@ -91,6 +248,10 @@ func (q *Queries) GetConflictingEventUsers(ctx context.Context, arg GetConflicti
 			&i.AworkID,
 			&i.WorkdayHours,
 			&i.WorkdaysWeek,
+			&i.EntryDate,
+			&i.ExitDate,
+			&i.Schedule,
+			&i.Region,
 		); err != nil {
 			return nil, err
 		}
@@ -106,7 +267,7 @@ func (q *Queries) GetConflictingEventUsers(ctx context.Context, arg GetConflicti
 }
 
 const GetEventById = `-- name: GetEventById :one
-SELECT id, scheduled_at, name, state, created_at, edited_at, user_id FROM events
+SELECT id, scheduled_at, name, state, created_at, edited_at, user_id, half_day, absence_id, region FROM events
 WHERE id = ?
 `
 
@@ -121,12 +282,55 @@ func (q *Queries) GetEventById(ctx context.Context, id int64) (Event, error) {
 		&i.CreatedAt,
 		&i.EditedAt,
 		&i.UserID,
+		&i.HalfDay,
+		&i.AbsenceID,
+		&i.Region,
 	)
 	return i, err
 }
 
+const GetEventsByAbsenceId = `-- name: GetEventsByAbsenceId :many
+SELECT id, scheduled_at, name, state, created_at, edited_at, user_id, half_day, absence_id, region FROM events
+WHERE absence_id = ?
+ORDER BY scheduled_at
+`
+
+func (q *Queries) GetEventsByAbsenceId(ctx context.Context, absenceID *int64) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, GetEventsByAbsenceId, absenceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.ID,
+			&i.ScheduledAt,
+			&i.Name,
+			&i.State,
+			&i.CreatedAt,
+			&i.EditedAt,
+			&i.UserID,
+			&i.HalfDay,
+			&i.AbsenceID,
+			&i.Region,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const GetEventsByUserId = `-- name: GetEventsByUserId :many
-SELECT id, scheduled_at, name, state, created_at, edited_at, user_id from events
+SELECT id, scheduled_at, name, state, created_at, edited_at, user_id, half_day, absence_id, region from events
 WHERE user_id = ?
 `
 
@@ -147,6 +351,9 @@ func (q *Queries) GetEventsByUserId(ctx context.Context, userID int64) ([]Event,
 			&i.CreatedAt,
 			&i.EditedAt,
 			&i.UserID,
+			&i.HalfDay,
+			&i.AbsenceID,
+			&i.Region,
 		); err != nil {
 			return nil, err
 		}
@@ -162,7 +369,7 @@ func (q *Queries) GetEventsByUserId(ctx context.Context, userID int64) ([]Event,
 }
 
 const GetEventsForDay = `-- name: GetEventsForDay :many
-SELECT id, scheduled_at, name, state, created_at, edited_at, user_id FROM events 
+SELECT id, scheduled_at, name, state, created_at, edited_at, user_id, half_day, absence_id, region FROM events 
 WHERE Date(scheduled_at) = ?
 `
 
@@ -183,6 +390,9 @@ func (q *Queries) GetEventsForDay(ctx context.Context, scheduledAt time.Time) ([
 			&i.CreatedAt,
 			&i.EditedAt,
 			&i.UserID,
+			&i.HalfDay,
+			&i.AbsenceID,
+			&i.Region,
 		); err != nil {
 			return nil, err
 		}
@@ -198,7 +408,7 @@ func (q *Queries) GetEventsForDay(ctx context.Context, scheduledAt time.Time) ([
 }
 
 const GetEventsForMonth = `-- name: GetEventsForMonth :many
-SELECT e.id, scheduled_at, name, state, e.created_at, e.edited_at, user_id, u.id, username, email, password, vacation_days, is_superuser, u.created_at, u.edited_at, color, role, enabled, awork_id, workday_hours, workdays_week
+SELECT e.id, scheduled_at, name, state, e.created_at, e.edited_at, user_id, half_day, absence_id, e.region, u.id, username, email, password, vacation_days, is_superuser, u.created_at, u.edited_at, color, role, enabled, awork_id, workday_hours, workdays_week, entry_date, exit_date, schedule, u.region
 FROM events e
 JOIN users u ON e.user_id = u.id
 WHERE scheduled_at >= ? AND scheduled_at < ?
@@ -211,27 +421,34 @@ type GetEventsForMonthParams struct {
 }
 
 type GetEventsForMonthRow struct {
-	ID           int64     `json:"id"`
-	ScheduledAt  time.Time `json:"scheduled_at"`
-	Name         string    `json:"name"`
-	State        string    `json:"state"`
-	CreatedAt    time.Time `json:"created_at"`
-	EditedAt     time.Time `json:"edited_at"`
-	UserID       int64     `json:"user_id"`
-	ID_2         int64     `json:"id_2"`
-	Username     string    `json:"username"`
-	Email        string    `json:"email"`
-	Password     string    `json:"password"`
-	VacationDays int64     `json:"vacation_days"`
-	IsSuperuser  bool      `json:"is_superuser"`
-	CreatedAt_2  time.Time `json:"created_at_2"`
-	EditedAt_2   time.Time `json:"edited_at_2"`
-	Color        string    `json:"color"`
-	Role         string    `json:"role"`
-	Enabled      bool      `json:"enabled"`
-	AworkID      *string   `json:"awork_id"`
-	WorkdayHours float64   `json:"workday_hours"`
-	WorkdaysWeek float64   `json:"workdays_week"`
+	ID           int64      `json:"id"`
+	ScheduledAt  time.Time  `json:"scheduled_at"`
+	Name         string     `json:"name"`
+	State        string     `json:"state"`
+	CreatedAt    time.Time  `json:"created_at"`
+	EditedAt     time.Time  `json:"edited_at"`
+	UserID       int64      `json:"user_id"`
+	HalfDay      bool       `json:"half_day"`
+	AbsenceID    *int64     `json:"absence_id"`
+	Region       *string    `json:"region"`
+	ID_2         int64      `json:"id_2"`
+	Username     string     `json:"username"`
+	Email        string     `json:"email"`
+	Password     string     `json:"password"`
+	VacationDays int64      `json:"vacation_days"`
+	IsSuperuser  bool       `json:"is_superuser"`
+	CreatedAt_2  time.Time  `json:"created_at_2"`
+	EditedAt_2   time.Time  `json:"edited_at_2"`
+	Color        string     `json:"color"`
+	Role         string     `json:"role"`
+	Enabled      bool       `json:"enabled"`
+	AworkID      *string    `json:"awork_id"`
+	WorkdayHours float64    `json:"workday_hours"`
+	WorkdaysWeek float64    `json:"workdays_week"`
+	EntryDate    *time.Time `json:"entry_date"`
+	ExitDate     *time.Time `json:"exit_date"`
+	Schedule     *string    `json:"schedule"`
+	Region_2     *string    `json:"region_2"`
 }
 
 func (q *Queries) GetEventsForMonth(ctx context.Context, arg GetEventsForMonthParams) ([]GetEventsForMonthRow, error) {
@@ -251,6 +468,9 @@ func (q *Queries) GetEventsForMonth(ctx context.Context, arg GetEventsForMonthPa
 			&i.CreatedAt,
 			&i.EditedAt,
 			&i.UserID,
+			&i.HalfDay,
+			&i.AbsenceID,
+			&i.Region,
 			&i.ID_2,
 			&i.Username,
 			&i.Email,
@@ -265,6 +485,10 @@ func (q *Queries) GetEventsForMonth(ctx context.Context, arg GetEventsForMonthPa
 			&i.AworkID,
 			&i.WorkdayHours,
 			&i.WorkdaysWeek,
+			&i.EntryDate,
+			&i.ExitDate,
+			&i.Schedule,
+			&i.Region_2,
 		); err != nil {
 			return nil, err
 		}
@@ -280,12 +504,13 @@ func (q *Queries) GetEventsForMonth(ctx context.Context, arg GetEventsForMonthPa
 }
 
 const GetEventsForYear = `-- name: GetEventsForYear :many
-SELECT e.id, scheduled_at, name, state, e.created_at, e.edited_at, user_id, u.id, username, email, password, vacation_days, is_superuser, u.created_at, u.edited_at, color, role, enabled, awork_id, workday_hours, workdays_week FROM events e
+SELECT e.id, e.scheduled_at, e.name, e.state, e.created_at, e.edited_at, e.user_id, e.half_day, e.absence_id, e.region, u.id, u.username, u.email, u.password, u.vacation_days, u.is_superuser, u.created_at, u.edited_at, u.color, u.role, u.enabled, u.awork_id, u.workday_hours, u.workdays_week, u.entry_date, u.exit_date, u.schedule, u.region FROM events e
 JOIN users u ON e.user_id = u.id
+LEFT JOIN event_types t ON e.name = t.name
 WHERE e.scheduled_at >= ? 
   AND e.scheduled_at < ?
   AND e.state = "accepted"
-  AND (e.name IN ('urlaub', 'urlaub halbtags') OR e.user_id = 1)
+  AND (t.consumes_vacation = true OR u.username = ?)
 
 ORDER BY scheduled_at
 `
@@ -293,34 +518,42 @@ ORDER BY scheduled_at
 type GetEventsForYearParams struct {
 	ScheduledAt   time.Time `json:"scheduled_at"`
 	ScheduledAt_2 time.Time `json:"scheduled_at_2"`
+	Username      string    `json:"username"`
 }
 
 type GetEventsForYearRow struct {
-	ID           int64     `json:"id"`
-	ScheduledAt  time.Time `json:"scheduled_at"`
-	Name         string    `json:"name"`
-	State        string    `json:"state"`
-	CreatedAt    time.Time `json:"created_at"`
-	EditedAt     time.Time `json:"edited_at"`
-	UserID       int64     `json:"user_id"`
-	ID_2         int64     `json:"id_2"`
-	Username     string    `json:"username"`
-	Email        string    `json:"email"`
-	Password     string    `json:"password"`
-	VacationDays int64     `json:"vacation_days"`
-	IsSuperuser  bool      `json:"is_superuser"`
-	CreatedAt_2  time.Time `json:"created_at_2"`
-	EditedAt_2   time.Time `json:"edited_at_2"`
-	Color        string    `json:"color"`
-	Role         string    `json:"role"`
-	Enabled      bool      `json:"enabled"`
-	AworkID      *string   `json:"awork_id"`
-	WorkdayHours float64   `json:"workday_hours"`
-	WorkdaysWeek float64   `json:"workdays_week"`
+	ID           int64      `json:"id"`
+	ScheduledAt  time.Time  `json:"scheduled_at"`
+	Name         string     `json:"name"`
+	State        string     `json:"state"`
+	CreatedAt    time.Time  `json:"created_at"`
+	EditedAt     time.Time  `json:"edited_at"`
+	UserID       int64      `json:"user_id"`
+	HalfDay      bool       `json:"half_day"`
+	AbsenceID    *int64     `json:"absence_id"`
+	Region       *string    `json:"region"`
+	ID_2         int64      `json:"id_2"`
+	Username     string     `json:"username"`
+	Email        string     `json:"email"`
+	Password     string     `json:"password"`
+	VacationDays int64      `json:"vacation_days"`
+	IsSuperuser  bool       `json:"is_superuser"`
+	CreatedAt_2  time.Time  `json:"created_at_2"`
+	EditedAt_2   time.Time  `json:"edited_at_2"`
+	Color        string     `json:"color"`
+	Role         string     `json:"role"`
+	Enabled      bool       `json:"enabled"`
+	AworkID      *string    `json:"awork_id"`
+	WorkdayHours float64    `json:"workday_hours"`
+	WorkdaysWeek float64    `json:"workdays_week"`
+	EntryDate    *time.Time `json:"entry_date"`
+	ExitDate     *time.Time `json:"exit_date"`
+	Schedule     *string    `json:"schedule"`
+	Region_2     *string    `json:"region_2"`
 }
 
 func (q *Queries) GetEventsForYear(ctx context.Context, arg GetEventsForYearParams) ([]GetEventsForYearRow, error) {
-	rows, err := q.db.QueryContext(ctx, GetEventsForYear, arg.ScheduledAt, arg.ScheduledAt_2)
+	rows, err := q.db.QueryContext(ctx, GetEventsForYear, arg.ScheduledAt, arg.ScheduledAt_2, arg.Username)
 	if err != nil {
 		return nil, err
 	}
@@ -336,6 +569,9 @@ func (q *Queries) GetEventsForYear(ctx context.Context, arg GetEventsForYearPara
 			&i.CreatedAt,
 			&i.EditedAt,
 			&i.UserID,
+			&i.HalfDay,
+			&i.AbsenceID,
+			&i.Region,
 			&i.ID_2,
 			&i.Username,
 			&i.Email,
@@ -350,6 +586,119 @@ func (q *Queries) GetEventsForYear(ctx context.Context, arg GetEventsForYearPara
 			&i.AworkID,
 			&i.WorkdayHours,
 			&i.WorkdaysWeek,
+			&i.EntryDate,
+			&i.ExitDate,
+			&i.Schedule,
+			&i.Region_2,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetEventsInRange = `-- name: GetEventsInRange :many
+SELECT id, scheduled_at, name, state, created_at, edited_at, user_id, half_day, absence_id, region FROM events
+WHERE scheduled_at >= ?
+AND scheduled_at <= ?
+ORDER BY scheduled_at
+`
+
+type GetEventsInRangeParams struct {
+	ScheduledAt   time.Time `json:"scheduled_at"`
+	ScheduledAt_2 time.Time `json:"scheduled_at_2"`
+}
+
+func (q *Queries) GetEventsInRange(ctx context.Context, arg GetEventsInRangeParams) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, GetEventsInRange, arg.ScheduledAt, arg.ScheduledAt_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.ID,
+			&i.ScheduledAt,
+			&i.Name,
+			&i.State,
+			&i.CreatedAt,
+			&i.EditedAt,
+			&i.UserID,
+			&i.HalfDay,
+			&i.AbsenceID,
+			&i.Region,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetHolidaysForRegion = `-- name: GetHolidaysForRegion :many
+SELECT e.id, e.scheduled_at, e.name, e.half_day, e.region, c.consumes_vacation
+FROM events e
+JOIN users u ON e.user_id = u.id
+LEFT JOIN company_holidays c ON c.event_id = e.id
+WHERE u.username = ?
+  AND (e.region IS NULL OR e.region = ?)
+  AND e.scheduled_at >= ?
+  AND e.scheduled_at <= ?
+ORDER BY e.scheduled_at
+`
+
+type GetHolidaysForRegionParams struct {
+	Username      string    `json:"username"`
+	Region        *string   `json:"region"`
+	ScheduledAt   time.Time `json:"scheduled_at"`
+	ScheduledAt_2 time.Time `json:"scheduled_at_2"`
+}
+
+type GetHolidaysForRegionRow struct {
+	ID               int64     `json:"id"`
+	ScheduledAt      time.Time `json:"scheduled_at"`
+	Name             string    `json:"name"`
+	HalfDay          bool      `json:"half_day"`
+	Region           *string   `json:"region"`
+	ConsumesVacation *bool     `json:"consumes_vacation"`
+}
+
+func (q *Queries) GetHolidaysForRegion(ctx context.Context, arg GetHolidaysForRegionParams) ([]GetHolidaysForRegionRow, error) {
+	rows, err := q.db.QueryContext(ctx, GetHolidaysForRegion,
+		arg.Username,
+		arg.Region,
+		arg.ScheduledAt,
+		arg.ScheduledAt_2,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetHolidaysForRegionRow
+	for rows.Next() {
+		var i GetHolidaysForRegionRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ScheduledAt,
+			&i.Name,
+			&i.HalfDay,
+			&i.Region,
+			&i.ConsumesVacation,
 		); err != nil {
 			return nil, err
 		}
@@ -386,20 +735,14 @@ func (q *Queries) GetPendingEventsForYear(ctx context.Context, arg GetPendingEve
 }
 
 const GetVacationCountForUser = `-- name: GetVacationCountForUser :one
-SELECT 
-  SUM(
-    CASE
-      WHEN name = 'urlaub'          THEN 1
-      WHEN name = 'urlaub halbtags' THEN 0.5
-      ELSE 0
-    END
-  ) 
-FROM events
-WHERE user_id = ?
-  AND scheduled_at >= ?
-  AND scheduled_at < ?
-  AND name IN ('urlaub', 'urlaub halbtags')
-  AND state = 'accepted'
+SELECT SUM(t.weight * CASE WHEN e.half_day THEN 0.5 ELSE 1 END)
+FROM events e
+JOIN event_types t ON e.name = t.name
+WHERE e.user_id = ?
+  AND e.scheduled_at >= ?
+  AND e.scheduled_at < ?
+  AND t.consumes_vacation = true
+  AND e.state = 'accepted'
 `
 
 type GetVacationCountForUserParams struct {
@@ -415,12 +758,29 @@ func (q *Queries) GetVacationCountForUser(ctx context.Context, arg GetVacationCo
 	return sum, err
 }
 
+const UpdateAbsenceEventsState = `-- name: UpdateAbsenceEventsState :exec
+UPDATE events
+SET state = ?,
+edited_at = CURRENT_TIMESTAMP
+WHERE absence_id = ?
+`
+
+type UpdateAbsenceEventsStateParams struct {
+	State     string `json:"state"`
+	AbsenceID *int64 `json:"absence_id"`
+}
+
+func (q *Queries) UpdateAbsenceEventsState(ctx context.Context, arg UpdateAbsenceEventsStateParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateAbsenceEventsState, arg.State, arg.AbsenceID)
+	return err
+}
+
 const UpdateEventState = `-- name: UpdateEventState :one
 UPDATE events
 SET state = ?,
 edited_at = CURRENT_TIMESTAMP
 WHERE id = ?
-RETURNING id, scheduled_at, name, state, created_at, edited_at, user_id
+RETURNING id, scheduled_at, name, state, created_at, edited_at, user_id, half_day, absence_id, region
 `
 
 type UpdateEventStateParams struct {
@@ -439,32 +799,71 @@ func (q *Queries) UpdateEventState(ctx context.Context, arg UpdateEventStatePara
 		&i.CreatedAt,
 		&i.EditedAt,
 		&i.UserID,
+		&i.HalfDay,
+		&i.AbsenceID,
+		&i.Region,
 	)
 	return i, err
 }
 
-const UpdateEventsRange = `-- name: UpdateEventsRange :exec
+const UpdateHolidayEvent = `-- name: UpdateHolidayEvent :one
 UPDATE events
-SET state = ?, 
+SET name = ?,
+scheduled_at = ?,
+half_day = ?,
+region = ?,
 edited_at = CURRENT_TIMESTAMP
-WHERE user_id = ? 
-AND scheduled_at >= ?
-AND scheduled_at <= ?
+WHERE id = ?
+RETURNING id, scheduled_at, name, state, created_at, edited_at, user_id, half_day, absence_id, region
 `
 
-type UpdateEventsRangeParams struct {
-	State         string    `json:"state"`
-	UserID        int64     `json:"user_id"`
-	ScheduledAt   time.Time `json:"scheduled_at"`
-	ScheduledAt_2 time.Time `json:"scheduled_at_2"`
+type UpdateHolidayEventParams struct {
+	Name        string    `json:"name"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+	HalfDay     bool      `json:"half_day"`
+	Region      *string   `json:"region"`
+	ID          int64     `json:"id"`
 }
 
-func (q *Queries) UpdateEventsRange(ctx context.Context, arg UpdateEventsRangeParams) error {
-	_, err := q.db.ExecContext(ctx, UpdateEventsRange,
-		arg.State,
-		arg.UserID,
+func (q *Queries) UpdateHolidayEvent(ctx context.Context, arg UpdateHolidayEventParams) (Event, error) {
+	row := q.db.QueryRowContext(ctx, UpdateHolidayEvent,
+		arg.Name,
 		arg.ScheduledAt,
-		arg.ScheduledAt_2,
+		arg.HalfDay,
+		arg.Region,
+		arg.ID,
 	)
+	var i Event
+	err := row.Scan(
+		&i.ID,
+		&i.ScheduledAt,
+		&i.Name,
+		&i.State,
+		&i.CreatedAt,
+		&i.EditedAt,
+		&i.UserID,
+		&i.HalfDay,
+		&i.AbsenceID,
+		&i.Region,
+	)
+	return i, err
+}
+
+const UpdateLegacyHolidayRegion = `-- name: UpdateLegacyHolidayRegion :exec
+UPDATE events
+SET region = ?
+WHERE region IS NULL
+  AND absence_id IS NULL
+  AND user_id = (SELECT id FROM users WHERE username = ?)
+  AND id NOT IN (SELECT event_id FROM company_holidays WHERE event_id IS NOT NULL)
+`
+
+type UpdateLegacyHolidayRegionParams struct {
+	Region   *string `json:"region"`
+	Username string  `json:"username"`
+}
+
+func (q *Queries) UpdateLegacyHolidayRegion(ctx context.Context, arg UpdateLegacyHolidayRegionParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateLegacyHolidayRegion, arg.Region, arg.Username)
 	return err
 }
@@ -8,10 +8,76 @@ import (
 	"time"
 )
 
+type Absence struct {
+	ID           int64     `json:"id"`
+	Name         string    `json:"name"`
+	StartDate    time.Time `json:"start_date"`
+	EndDate      time.Time `json:"end_date"`
+	HalfDayStart bool      `json:"half_day_start"`
+	HalfDayEnd   bool      `json:"half_day_end"`
+	State        string    `json:"state"`
+	CreatedAt    time.Time `json:"created_at"`
+	EditedAt     time.Time `json:"edited_at"`
+	UserID       int64     `json:"user_id"`
+}
+
 type ApiCache struct {
 	ID        int64     `json:"id"`
 	Year      int64     `json:"year"`
 	CreatedAt time.Time `json:"created_at"`
+	Region    string    `json:"region"`
+}
+
+type Approver struct {
+	ID         int64     `json:"id"`
+	Stage      int64     `json:"stage"`
+	ApproverID int64     `json:"approver_id"`
+	UserID     *int64    `json:"user_id"`
+	TeamID     *int64    `json:"team_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type AutoApprovePolicy struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	EventName *string   `json:"event_name"`
+	MaxDays   *float64  `json:"max_days"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type BlackoutPeriod struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	TeamID    *int64    `json:"team_id"`
+	Severity  string    `json:"severity"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Cancellation struct {
+	ID        int64      `json:"id"`
+	AbsenceID *int64     `json:"absence_id"`
+	UserID    int64      `json:"user_id"`
+	StartDate time.Time  `json:"start_date"`
+	EndDate   time.Time  `json:"end_date"`
+	Reason    *string    `json:"reason"`
+	State     string     `json:"state"`
+	CreatedAt time.Time  `json:"created_at"`
+	DecidedBy *int64     `json:"decided_by"`
+	DecidedAt *time.Time `json:"decided_at"`
+}
+
+type CompanyHoliday struct {
+	ID               int64     `json:"id"`
+	Date             time.Time `json:"date"`
+	Name             string    `json:"name"`
+	Weight           float64   `json:"weight"`
+	Region           *string   `json:"region"`
+	ConsumesVacation bool      `json:"consumes_vacation"`
+	CreatedAt        time.Time `json:"created_at"`
+	EventID          *int64    `json:"event_id"`
+	CreatedBy        *int64    `json:"created_by"`
 }
 
 type Event struct {
@@ -22,6 +88,40 @@ type Event struct {
 	CreatedAt   time.Time `json:"created_at"`
 	EditedAt    time.Time `json:"edited_at"`
 	UserID      int64     `json:"user_id"`
+	HalfDay     bool      `json:"half_day"`
+	AbsenceID   *int64    `json:"absence_id"`
+	Region      *string   `json:"region"`
+}
+
+type EventType struct {
+	ID               int64     `json:"id"`
+	Name             string    `json:"name"`
+	Label            string    `json:"label"`
+	Weight           float64   `json:"weight"`
+	ConsumesVacation bool      `json:"consumes_vacation"`
+	NeedsApproval    bool      `json:"needs_approval"`
+	CountsAsWorked   bool      `json:"counts_as_worked"`
+	Color            string    `json:"color"`
+	CreatedAt        time.Time `json:"created_at"`
+	EditedAt         time.Time `json:"edited_at"`
+	ConsumesOvertime bool      `json:"consumes_overtime"`
+}
+
+type IdempotencyKey struct {
+	ID          int64     `json:"id"`
+	UserID      int64     `json:"user_id"`
+	Key         string    `json:"key"`
+	Action      string    `json:"action"`
+	TimestampID *int64    `json:"timestamp_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type JobRun struct {
+	ID         int64      `json:"id"`
+	Job        string     `json:"job"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+	Error      *string    `json:"error"`
 }
 
 type Notification struct {
@@ -36,15 +136,50 @@ type NotificationUser struct {
 	UserID         int64 `json:"user_id"`
 }
 
-type Request struct {
+type OvertimeBooking struct {
 	ID        int64     `json:"id"`
-	Message   *string   `json:"message"`
-	State     string    `json:"state"`
+	UserID    int64     `json:"user_id"`
+	Date      time.Time `json:"date"`
+	Kind      string    `json:"kind"`
+	Hours     float64   `json:"hours"`
+	Reason    *string   `json:"reason"`
+	CreatedBy *int64    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type OvertimeSnapshot struct {
+	UserID    int64     `json:"user_id"`
+	Month     time.Time `json:"month"`
+	Worked    float64   `json:"worked"`
+	Expected  float64   `json:"expected"`
+	Booked    float64   `json:"booked"`
+	Balance   float64   `json:"balance"`
 	CreatedAt time.Time `json:"created_at"`
-	EditedAt  time.Time `json:"edited_at"`
+	TimeOff   float64   `json:"time_off"`
+}
+
+type Request struct {
+	ID             int64      `json:"id"`
+	Message        *string    `json:"message"`
+	State          string     `json:"state"`
+	CreatedAt      time.Time  `json:"created_at"`
+	EditedAt       time.Time  `json:"edited_at"`
+	UserID         int64      `json:"user_id"`
+	EditedBy       *int64     `json:"edited_by"`
+	EventID        int64      `json:"event_id"`
+	AbsenceID      *int64     `json:"absence_id"`
+	ReminderCount  int64      `json:"reminder_count"`
+	LastRemindedAt *time.Time `json:"last_reminded_at"`
+	EscalatedAt    *time.Time `json:"escalated_at"`
+}
+
+type RequestComment struct {
+	ID        int64     `json:"id"`
+	RequestID int64     `json:"request_id"`
 	UserID    int64     `json:"user_id"`
-	EditedBy  *int64    `json:"edited_by"`
-	EventID   int64     `json:"event_id"`
+	Kind      string    `json:"kind"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Session struct {
@@ -60,11 +195,68 @@ type Setting struct {
 	SignupEnabled bool  `json:"signup_enabled"`
 }
 
+type StaffingRule struct {
+	ID         int64     `json:"id"`
+	TeamID     *int64    `json:"team_id"`
+	MinPresent int64     `json:"min_present"`
+	Severity   string    `json:"severity"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type Team struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TeamMember struct {
+	UserID int64 `json:"user_id"`
+	TeamID int64 `json:"team_id"`
+}
+
 type Timestamp struct {
-	ID        int64      `json:"id"`
-	StartTime time.Time  `json:"start_time"`
-	EndTime   *time.Time `json:"end_time"`
-	UserID    int64      `json:"user_id"`
+	ID         int64      `json:"id"`
+	StartTime  time.Time  `json:"start_time"`
+	EndTime    *time.Time `json:"end_time"`
+	UserID     int64      `json:"user_id"`
+	AutoClosed bool       `json:"auto_closed"`
+}
+
+type TimestampBreak struct {
+	ID          int64      `json:"id"`
+	TimestampID int64      `json:"timestamp_id"`
+	StartTime   time.Time  `json:"start_time"`
+	EndTime     *time.Time `json:"end_time"`
+}
+
+type TimestampCorrection struct {
+	ID             int64      `json:"id"`
+	UserID         int64      `json:"user_id"`
+	TimestampID    *int64     `json:"timestamp_id"`
+	Kind           string     `json:"kind"`
+	StartTime      *time.Time `json:"start_time"`
+	EndTime        *time.Time `json:"end_time"`
+	Reason         *string    `json:"reason"`
+	State          string     `json:"state"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DecidedBy      *int64     `json:"decided_by"`
+	DecidedAt      *time.Time `json:"decided_at"`
+	ReminderCount  int64      `json:"reminder_count"`
+	LastRemindedAt *time.Time `json:"last_reminded_at"`
+}
+
+type TimestampHistory struct {
+	ID           int64      `json:"id"`
+	TimestampID  int64      `json:"timestamp_id"`
+	UserID       int64      `json:"user_id"`
+	CorrectionID *int64     `json:"correction_id"`
+	Kind         string     `json:"kind"`
+	OldStartTime *time.Time `json:"old_start_time"`
+	OldEndTime   *time.Time `json:"old_end_time"`
+	NewStartTime *time.Time `json:"new_start_time"`
+	NewEndTime   *time.Time `json:"new_end_time"`
+	ChangedBy    *int64     `json:"changed_by"`
+	ChangedAt    time.Time  `json:"changed_at"`
 }
 
 type TokenRefresh struct {
@@ -75,20 +267,37 @@ type TokenRefresh struct {
 }
 
 type User struct {
-	ID           int64     `json:"id"`
-	Username     string    `json:"username"`
-	Email        string    `json:"email"`
-	Password     string    `json:"password"`
-	VacationDays int64     `json:"vacation_days"`
-	IsSuperuser  bool      `json:"is_superuser"`
-	CreatedAt    time.Time `json:"created_at"`
-	EditedAt     time.Time `json:"edited_at"`
-	Color        string    `json:"color"`
-	Role         string    `json:"role"`
-	Enabled      bool      `json:"enabled"`
-	AworkID      *string   `json:"awork_id"`
-	WorkdayHours float64   `json:"workday_hours"`
-	WorkdaysWeek float64   `json:"workdays_week"`
+	ID           int64      `json:"id"`
+	Username     string     `json:"username"`
+	Email        string     `json:"email"`
+	Password     string     `json:"password"`
+	VacationDays int64      `json:"vacation_days"`
+	IsSuperuser  bool       `json:"is_superuser"`
+	CreatedAt    time.Time  `json:"created_at"`
+	EditedAt     time.Time  `json:"edited_at"`
+	Color        string     `json:"color"`
+	Role         string     `json:"role"`
+	Enabled      bool       `json:"enabled"`
+	AworkID      *string    `json:"awork_id"`
+	WorkdayHours float64    `json:"workday_hours"`
+	WorkdaysWeek float64    `json:"workdays_week"`
+	EntryDate    *time.Time `json:"entry_date"`
+	ExitDate     *time.Time `json:"exit_date"`
+	Schedule     *string    `json:"schedule"`
+	Region       *string    `json:"region"`
+}
+
+type UserContract struct {
+	ID           int64      `json:"id"`
+	ValidFrom    time.Time  `json:"valid_from"`
+	ValidTo      *time.Time `json:"valid_to"`
+	WorkdayHours float64    `json:"workday_hours"`
+	WorkdaysWeek float64    `json:"workdays_week"`
+	VacationDays int64      `json:"vacation_days"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UserID       int64      `json:"user_id"`
+	CreatedBy    *int64     `json:"created_by"`
+	Schedule     *string    `json:"schedule"`
 }
 
 type VacationToken struct {
@@ -97,4 +306,16 @@ type VacationToken struct {
 	EndDate   time.Time `json:"end_date"`
 	Value     float64   `json:"value"`
 	UserID    int64     `json:"user_id"`
+	Reason    string    `json:"reason"`
+	RequestID *int64    `json:"request_id"`
+	EventID   *int64    `json:"event_id"`
+	CreatedBy *int64    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	AbsenceID *int64    `json:"absence_id"`
+}
+
+type YearClosing struct {
+	Year      int64     `json:"year"`
+	CreatedAt time.Time `json:"created_at"`
+	ClosedBy  *int64    `json:"closed_by"`
 }
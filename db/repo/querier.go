@@ -10,67 +10,177 @@ import (
 )
 
 type Querier interface {
-	CacheExists(ctx context.Context, year int64) (int64, error)
+	AutoCloseTimestamp(ctx context.Context, arg AutoCloseTimestampParams) (Timestamp, error)
+	CacheExists(ctx context.Context, arg CacheExistsParams) (int64, error)
 	ClearAllUserNotifications(ctx context.Context, userID int64) error
+	ClearCancellationsAbsence(ctx context.Context, absenceID *int64) error
 	ClearNotification(ctx context.Context, id int64) (Notification, error)
-	CreateCache(ctx context.Context, year int64) error
+	CreateAbsence(ctx context.Context, arg CreateAbsenceParams) (Absence, error)
+	CreateAbsenceEvent(ctx context.Context, arg CreateAbsenceEventParams) (Event, error)
+	CreateApprover(ctx context.Context, arg CreateApproverParams) (Approver, error)
+	CreateAutoApprovePolicy(ctx context.Context, arg CreateAutoApprovePolicyParams) (AutoApprovePolicy, error)
+	CreateBlackoutPeriod(ctx context.Context, arg CreateBlackoutPeriodParams) (BlackoutPeriod, error)
+	CreateCache(ctx context.Context, arg CreateCacheParams) error
+	CreateCancellation(ctx context.Context, arg CreateCancellationParams) (Cancellation, error)
+	CreateCompanyHoliday(ctx context.Context, arg CreateCompanyHolidayParams) (CompanyHoliday, error)
 	CreateEvent(ctx context.Context, arg CreateEventParams) (Event, error)
+	CreateEventType(ctx context.Context, arg CreateEventTypeParams) (EventType, error)
+	CreateHolidayEvent(ctx context.Context, arg CreateHolidayEventParams) (Event, error)
+	CreateJobRun(ctx context.Context, job string) (JobRun, error)
 	CreateNotification(ctx context.Context, message string) (Notification, error)
 	CreateNotificationUser(ctx context.Context, arg CreateNotificationUserParams) error
+	CreateOvertimeBooking(ctx context.Context, arg CreateOvertimeBookingParams) (OvertimeBooking, error)
+	CreateOvertimeSnapshot(ctx context.Context, arg CreateOvertimeSnapshotParams) (OvertimeSnapshot, error)
 	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) (TokenRefresh, error)
 	CreateRequest(ctx context.Context, arg CreateRequestParams) (Request, error)
+	CreateRequestComment(ctx context.Context, arg CreateRequestCommentParams) (RequestComment, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateSettings(ctx context.Context, signupEnabled bool) (Setting, error)
+	CreateStaffingRule(ctx context.Context, arg CreateStaffingRuleParams) (StaffingRule, error)
+	CreateTeam(ctx context.Context, name string) (Team, error)
+	CreateTimestamp(ctx context.Context, arg CreateTimestampParams) (Timestamp, error)
+	CreateTimestampBreak(ctx context.Context, arg CreateTimestampBreakParams) (TimestampBreak, error)
+	CreateTimestampCorrection(ctx context.Context, arg CreateTimestampCorrectionParams) (TimestampCorrection, error)
+	CreateTimestampHistory(ctx context.Context, arg CreateTimestampHistoryParams) (TimestampHistory, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateUserContract(ctx context.Context, arg CreateUserContractParams) (UserContract, error)
 	CreateVacationToken(ctx context.Context, arg CreateVacationTokenParams) (VacationToken, error)
+	CreateYearClosing(ctx context.Context, arg CreateYearClosingParams) (YearClosing, error)
+	DecideTimestampCorrection(ctx context.Context, arg DecideTimestampCorrectionParams) (TimestampCorrection, error)
+	DeleteAbsence(ctx context.Context, id int64) error
+	DeleteAbsenceEvents(ctx context.Context, absenceID *int64) error
+	DeleteAbsenceEventsInRange(ctx context.Context, arg DeleteAbsenceEventsInRangeParams) error
+	DeleteAbsenceRequest(ctx context.Context, absenceID *int64) error
 	DeleteAllRefreshTokens(ctx context.Context) error
 	DeleteAllSessions(ctx context.Context) error
 	DeleteAllVacationTokens(ctx context.Context) error
+	DeleteApprover(ctx context.Context, id int64) error
+	DeleteAutoApprovePolicy(ctx context.Context, id int64) error
+	DeleteBlackoutPeriod(ctx context.Context, id int64) error
+	DeleteCompanyHoliday(ctx context.Context, id int64) error
 	DeleteEvent(ctx context.Context, id int64) error
+	DeleteEventType(ctx context.Context, id int64) error
+	DeleteExpiredSessions(ctx context.Context, validUntil time.Time) (int64, error)
 	DeleteSession(ctx context.Context, id string) error
 	DeleteSettings(ctx context.Context, id int64) error
+	DeleteStaffingRule(ctx context.Context, id int64) error
+	DeleteTeam(ctx context.Context, id int64) error
+	DeleteTeamMember(ctx context.Context, userID int64) error
 	DeleteTimestamp(ctx context.Context, id int64) error
+	DeleteTimestampBreak(ctx context.Context, id int64) error
+	DeleteTimestampBreaksFrom(ctx context.Context, arg DeleteTimestampBreaksFromParams) error
 	DeleteUser(ctx context.Context, id int64) error
 	DeleteVacationToken(ctx context.Context, id int64) error
+	EndOpenTimestampBreaks(ctx context.Context, arg EndOpenTimestampBreaksParams) error
+	FinishJobRun(ctx context.Context, arg FinishJobRunParams) (JobRun, error)
+	GetAbsenceById(ctx context.Context, id int64) (Absence, error)
+	GetAbsenceByRequestId(ctx context.Context, id int64) (Absence, error)
+	GetAbsenceEventsInRange(ctx context.Context, arg GetAbsenceEventsInRangeParams) ([]Event, error)
+	GetAbsencesForUser(ctx context.Context, arg GetAbsencesForUserParams) ([]Absence, error)
 	GetAdmins(ctx context.Context) ([]User, error)
+	GetAllApprovers(ctx context.Context) ([]Approver, error)
+	GetAllAutoApprovePolicies(ctx context.Context) ([]AutoApprovePolicy, error)
+	GetAllBlackoutPeriods(ctx context.Context) ([]BlackoutPeriod, error)
+	GetAllEventTypes(ctx context.Context) ([]EventType, error)
+	GetAllStaffingRules(ctx context.Context) ([]StaffingRule, error)
+	GetAllTeamMembers(ctx context.Context) ([]TeamMember, error)
+	GetAllTeams(ctx context.Context) ([]Team, error)
+	GetAllTimestampBreaksInRange(ctx context.Context, arg GetAllTimestampBreaksInRangeParams) ([]TimestampBreak, error)
 	GetAllTimestampsForUser(ctx context.Context, userID int64) ([]Timestamp, error)
 	GetAllTimestampsInRange(ctx context.Context, arg GetAllTimestampsInRangeParams) ([]Timestamp, error)
+	GetAllUserContracts(ctx context.Context) ([]UserContract, error)
 	GetAllUsers(ctx context.Context) ([]User, error)
 	GetApiCacheYears(ctx context.Context) ([]int64, error)
+	GetBlackoutPeriodsInRange(ctx context.Context, arg GetBlackoutPeriodsInRangeParams) ([]BlackoutPeriod, error)
+	GetBreaksForTimestamp(ctx context.Context, timestampID int64) ([]TimestampBreak, error)
+	GetCancellationById(ctx context.Context, id int64) (Cancellation, error)
+	GetCancellationsForAbsence(ctx context.Context, absenceID *int64) ([]Cancellation, error)
+	GetCommentsForRequest(ctx context.Context, requestID int64) ([]GetCommentsForRequestRow, error)
+	GetCompanyHolidayById(ctx context.Context, id int64) (CompanyHoliday, error)
+	GetCompanyHolidaysInRange(ctx context.Context, arg GetCompanyHolidaysInRangeParams) ([]CompanyHoliday, error)
 	GetConflictingEventUsers(ctx context.Context, arg GetConflictingEventUsersParams) ([]User, error)
 	GetEventById(ctx context.Context, id int64) (Event, error)
 	GetEventNameFromRequest(ctx context.Context, id int64) (string, error)
+	GetEventTypeById(ctx context.Context, id int64) (EventType, error)
+	GetEventTypeByName(ctx context.Context, name string) (EventType, error)
+	GetEventsByAbsenceId(ctx context.Context, absenceID *int64) ([]Event, error)
 	GetEventsByUserId(ctx context.Context, userID int64) ([]Event, error)
 	GetEventsForDay(ctx context.Context, scheduledAt time.Time) ([]Event, error)
 	GetEventsForMonth(ctx context.Context, arg GetEventsForMonthParams) ([]GetEventsForMonthRow, error)
 	GetEventsForYear(ctx context.Context, arg GetEventsForYearParams) ([]GetEventsForYearRow, error)
+	GetEventsInRange(ctx context.Context, arg GetEventsInRangeParams) ([]Event, error)
+	GetFirstTimestamp(ctx context.Context, userID int64) (Timestamp, error)
+	GetHolidaysForRegion(ctx context.Context, arg GetHolidaysForRegionParams) ([]GetHolidaysForRegionRow, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetLastJobRun(ctx context.Context, job string) (JobRun, error)
+	GetLastJobRuns(ctx context.Context) ([]JobRun, error)
+	GetLastOvertimeSnapshotBefore(ctx context.Context, arg GetLastOvertimeSnapshotBeforeParams) (OvertimeSnapshot, error)
 	GetLatestTimestamp(ctx context.Context, userID int64) (Timestamp, error)
+	GetOpenTimestampsBefore(ctx context.Context, startTime time.Time) ([]Timestamp, error)
+	GetOpeningOvertimeBooking(ctx context.Context, userID int64) (OvertimeBooking, error)
+	GetOvertimeBookingsForUser(ctx context.Context, userID int64) ([]OvertimeBooking, error)
+	GetOvertimeBookingsInRange(ctx context.Context, arg GetOvertimeBookingsInRangeParams) ([]OvertimeBooking, error)
+	GetOvertimeSnapshotsForUser(ctx context.Context, userID int64) ([]OvertimeSnapshot, error)
+	GetPendingCancellations(ctx context.Context) ([]Cancellation, error)
 	GetPendingEventsForYear(ctx context.Context, arg GetPendingEventsForYearParams) (int64, error)
 	GetPendingRequests(ctx context.Context) ([]GetPendingRequestsRow, error)
+	GetPendingTimestampCorrections(ctx context.Context) ([]TimestampCorrection, error)
 	GetRefreshToken(ctx context.Context, arg GetRefreshTokenParams) (int64, error)
 	GetRemainingVacationForUser(ctx context.Context, arg GetRemainingVacationForUserParams) (*float64, error)
+	GetRequestByAbsenceId(ctx context.Context, absenceID *int64) (Request, error)
+	GetRequestById(ctx context.Context, id int64) (Request, error)
 	GetRequestRange(ctx context.Context, arg GetRequestRangeParams) ([]Request, error)
+	GetRunningTimestamp(ctx context.Context, userID int64) (Timestamp, error)
 	GetSessionById(ctx context.Context, id string) (Session, error)
 	GetSettingsById(ctx context.Context, id int64) (Setting, error)
+	GetTeamById(ctx context.Context, id int64) (Team, error)
+	GetTimestampBreakById(ctx context.Context, id int64) (TimestampBreak, error)
+	GetTimestampBreaksInRange(ctx context.Context, arg GetTimestampBreaksInRangeParams) ([]TimestampBreak, error)
 	GetTimestampById(ctx context.Context, id int64) (Timestamp, error)
+	GetTimestampCorrectionById(ctx context.Context, id int64) (TimestampCorrection, error)
+	GetTimestampCorrectionsForUser(ctx context.Context, userID int64) ([]TimestampCorrection, error)
+	GetTimestampHistory(ctx context.Context, timestampID int64) ([]TimestampHistory, error)
 	GetTimestampsInRange(ctx context.Context, arg GetTimestampsInRangeParams) ([]Timestamp, error)
+	GetTotalBreakSecondsInRange(ctx context.Context, arg GetTotalBreakSecondsInRangeParams) (*float64, error)
 	GetTotalSecondsInRange(ctx context.Context, arg GetTotalSecondsInRangeParams) (*float64, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id int64) (User, error)
 	GetUserByName(ctx context.Context, username string) (User, error)
+	GetUserContractsForUser(ctx context.Context, userID int64) ([]UserContract, error)
 	GetUserFromSession(ctx context.Context, id string) (User, error)
 	GetUserNotifications(ctx context.Context, userID int64) ([]Notification, error)
 	GetVacationCountForUser(ctx context.Context, arg GetVacationCountForUserParams) (*float64, error)
+	GetVacationPotsForUser(ctx context.Context, arg GetVacationPotsForUserParams) ([]GetVacationPotsForUserRow, error)
+	GetVacationTokensForAbsence(ctx context.Context, absenceID *int64) ([]VacationToken, error)
+	GetVacationTokensForEvent(ctx context.Context, eventID *int64) ([]VacationToken, error)
+	GetVacationTokensForUser(ctx context.Context, arg GetVacationTokensForUserParams) ([]VacationToken, error)
+	GetYearClosing(ctx context.Context, year int64) (YearClosing, error)
+	SaveIdempotencyKey(ctx context.Context, arg SaveIdempotencyKeyParams) (IdempotencyKey, error)
+	SetTeamMember(ctx context.Context, arg SetTeamMemberParams) error
 	StartTimestamp(ctx context.Context, userID int64) (Timestamp, error)
+	StartTimestampBreak(ctx context.Context, timestampID int64) (TimestampBreak, error)
 	StopTimestamp(ctx context.Context, id int64) (Timestamp, error)
+	StopTimestampBreak(ctx context.Context, timestampID int64) (TimestampBreak, error)
+	UpdateAbsenceEventsState(ctx context.Context, arg UpdateAbsenceEventsStateParams) error
+	UpdateAbsenceRange(ctx context.Context, arg UpdateAbsenceRangeParams) (Absence, error)
+	UpdateAbsenceRequestState(ctx context.Context, arg UpdateAbsenceRequestStateParams) error
+	UpdateAbsenceState(ctx context.Context, arg UpdateAbsenceStateParams) (Absence, error)
+	UpdateCancellationState(ctx context.Context, arg UpdateCancellationStateParams) (Cancellation, error)
+	UpdateCompanyHoliday(ctx context.Context, arg UpdateCompanyHolidayParams) (CompanyHoliday, error)
 	UpdateEventState(ctx context.Context, arg UpdateEventStateParams) (Event, error)
-	UpdateEventsRange(ctx context.Context, arg UpdateEventsRangeParams) error
+	UpdateEventType(ctx context.Context, arg UpdateEventTypeParams) (EventType, error)
+	UpdateHolidayEvent(ctx context.Context, arg UpdateHolidayEventParams) (Event, error)
+	UpdateLegacyHolidayRegion(ctx context.Context, arg UpdateLegacyHolidayRegionParams) error
 	UpdateNotification(ctx context.Context, message string) (Notification, error)
 	UpdateRequest(ctx context.Context, arg UpdateRequestParams) (Request, error)
-	UpdateRequestStateRange(ctx context.Context, arg UpdateRequestStateRangeParams) (int64, error)
+	UpdateRequestEscalation(ctx context.Context, arg UpdateRequestEscalationParams) error
+	UpdateRequestReminder(ctx context.Context, arg UpdateRequestReminderParams) error
 	UpdateSettings(ctx context.Context, arg UpdateSettingsParams) (Setting, error)
+	UpdateTeam(ctx context.Context, arg UpdateTeamParams) (Team, error)
 	UpdateTimestamp(ctx context.Context, arg UpdateTimestampParams) (Timestamp, error)
+	UpdateTimestampCorrectionReminder(ctx context.Context, arg UpdateTimestampCorrectionReminderParams) error
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserContract(ctx context.Context, arg UpdateUserContractParams) (UserContract, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -11,16 +11,17 @@ import (
 )
 
 const CreateRequest = `-- name: CreateRequest :one
-INSERT INTO requests (message, state, user_id, event_id)
-VALUES (?, ?, ?, ?)
-RETURNING id, message, state, created_at, edited_at, user_id, edited_by, event_id
+INSERT INTO requests (message, state, user_id, event_id, absence_id)
+VALUES (?, ?, ?, ?, ?)
+RETURNING id, message, state, created_at, edited_at, user_id, edited_by, event_id, absence_id, reminder_count, last_reminded_at, escalated_at
 `
 
 type CreateRequestParams struct {
-	Message *string `json:"message"`
-	State   string  `json:"state"`
-	UserID  int64   `json:"user_id"`
-	EventID int64   `json:"event_id"`
+	Message   *string `json:"message"`
+	State     string  `json:"state"`
+	UserID    int64   `json:"user_id"`
+	EventID   int64   `json:"event_id"`
+	AbsenceID *int64  `json:"absence_id"`
 }
 
 func (q *Queries) CreateRequest(ctx context.Context, arg CreateRequestParams) (Request, error) {
@@ -29,6 +30,7 @@ func (q *Queries) CreateRequest(ctx context.Context, arg CreateRequestParams) (R
 		arg.State,
 		arg.UserID,
 		arg.EventID,
+		arg.AbsenceID,
 	)
 	var i Request
 	err := row.Scan(
@@ -40,10 +42,24 @@ func (q *Queries) CreateRequest(ctx context.Context, arg CreateRequestParams) (R
 		&i.UserID,
 		&i.EditedBy,
 		&i.EventID,
+		&i.AbsenceID,
+		&i.ReminderCount,
+		&i.LastRemindedAt,
+		&i.EscalatedAt,
 	)
 	return i, err
 }
 
+const DeleteAbsenceRequest = `-- name: DeleteAbsenceRequest :exec
+DELETE FROM requests
+WHERE absence_id = ?
+`
+
+func (q *Queries) DeleteAbsenceRequest(ctx context.Context, absenceID *int64) error {
+	_, err := q.db.ExecContext(ctx, DeleteAbsenceRequest, absenceID)
+	return err
+}
+
 const GetEventNameFromRequest = `-- name: GetEventNameFromRequest :one
 SELECT e.name FROM requests r
 JOIN events e on r.event_id = e.id
@@ -58,43 +74,57 @@ func (q *Queries) GetEventNameFromRequest(ctx context.Context, id int64) (string
 }
 
 const GetPendingRequests = `-- name: GetPendingRequests :many
-SELECT r.id, message, r.state, r.created_at, r.edited_at, r.user_id, edited_by, event_id, u.id, username, email, password, vacation_days, is_superuser, u.created_at, u.edited_at, color, role, enabled, awork_id, workday_hours, workdays_week, e.id, scheduled_at, name, e.state, e.created_at, e.edited_at, e.user_id FROM requests r
+SELECT r.id, r.message, r.state, r.created_at, r.edited_at, r.user_id, r.edited_by, r.event_id, r.absence_id, r.reminder_count, r.last_reminded_at, r.escalated_at, u.id, u.username, u.email, u.password, u.vacation_days, u.is_superuser, u.created_at, u.edited_at, u.color, u.role, u.enabled, u.awork_id, u.workday_hours, u.workdays_week, u.entry_date, u.exit_date, u.schedule, u.region, a.id, a.name, a.start_date, a.end_date, a.half_day_start, a.half_day_end, a.state, a.created_at, a.edited_at, a.user_id,
+  (SELECT COUNT(*) FROM events e WHERE e.absence_id = a.id) AS event_count
+FROM requests r
 JOIN users u ON r.user_id = u.id
-JOIN events e ON r.event_id = e.id
-WHERE r.state = "pending"
-ORDER BY r.user_id, e.scheduled_at
+JOIN absences a ON r.absence_id = a.id
+WHERE r.state IN ("pending", "approved_by_lead")
+ORDER BY r.user_id, a.start_date
 `
 
 type GetPendingRequestsRow struct {
-	ID           int64     `json:"id"`
-	Message      *string   `json:"message"`
-	State        string    `json:"state"`
-	CreatedAt    time.Time `json:"created_at"`
-	EditedAt     time.Time `json:"edited_at"`
-	UserID       int64     `json:"user_id"`
-	EditedBy     *int64    `json:"edited_by"`
-	EventID      int64     `json:"event_id"`
-	ID_2         int64     `json:"id_2"`
-	Username     string    `json:"username"`
-	Email        string    `json:"email"`
-	Password     string    `json:"password"`
-	VacationDays int64     `json:"vacation_days"`
-	IsSuperuser  bool      `json:"is_superuser"`
-	CreatedAt_2  time.Time `json:"created_at_2"`
-	EditedAt_2   time.Time `json:"edited_at_2"`
-	Color        string    `json:"color"`
-	Role         string    `json:"role"`
-	Enabled      bool      `json:"enabled"`
-	AworkID      *string   `json:"awork_id"`
-	WorkdayHours float64   `json:"workday_hours"`
-	WorkdaysWeek float64   `json:"workdays_week"`
-	ID_3         int64     `json:"id_3"`
-	ScheduledAt  time.Time `json:"scheduled_at"`
-	Name         string    `json:"name"`
-	State_2      string    `json:"state_2"`
-	CreatedAt_3  time.Time `json:"created_at_3"`
-	EditedAt_3   time.Time `json:"edited_at_3"`
-	UserID_2     int64     `json:"user_id_2"`
+	ID             int64      `json:"id"`
+	Message        *string    `json:"message"`
+	State          string     `json:"state"`
+	CreatedAt      time.Time  `json:"created_at"`
+	EditedAt       time.Time  `json:"edited_at"`
+	UserID         int64      `json:"user_id"`
+	EditedBy       *int64     `json:"edited_by"`
+	EventID        int64      `json:"event_id"`
+	AbsenceID      *int64     `json:"absence_id"`
+	ReminderCount  int64      `json:"reminder_count"`
+	LastRemindedAt *time.Time `json:"last_reminded_at"`
+	EscalatedAt    *time.Time `json:"escalated_at"`
+	ID_2           int64      `json:"id_2"`
+	Username       string     `json:"username"`
+	Email          string     `json:"email"`
+	Password       string     `json:"password"`
+	VacationDays   int64      `json:"vacation_days"`
+	IsSuperuser    bool       `json:"is_superuser"`
+	CreatedAt_2    time.Time  `json:"created_at_2"`
+	EditedAt_2     time.Time  `json:"edited_at_2"`
+	Color          string     `json:"color"`
+	Role           string     `json:"role"`
+	Enabled        bool       `json:"enabled"`
+	AworkID        *string    `json:"awork_id"`
+	WorkdayHours   float64    `json:"workday_hours"`
+	WorkdaysWeek   float64    `json:"workdays_week"`
+	EntryDate      *time.Time `json:"entry_date"`
+	ExitDate       *time.Time `json:"exit_date"`
+	Schedule       *string    `json:"schedule"`
+	Region         *string    `json:"region"`
+	ID_3           int64      `json:"id_3"`
+	Name           string     `json:"name"`
+	StartDate      time.Time  `json:"start_date"`
+	EndDate        time.Time  `json:"end_date"`
+	HalfDayStart   bool       `json:"half_day_start"`
+	HalfDayEnd     bool       `json:"half_day_end"`
+	State_2        string     `json:"state_2"`
+	CreatedAt_3    time.Time  `json:"created_at_3"`
+	EditedAt_3     time.Time  `json:"edited_at_3"`
+	UserID_2       int64      `json:"user_id_2"`
+	EventCount     int64      `json:"event_count"`
 }
 
 func (q *Queries) GetPendingRequests(ctx context.Context) ([]GetPendingRequestsRow, error) {
@@ -115,6 +145,10 @@ func (q *Queries) GetPendingRequests(ctx context.Context) ([]GetPendingRequestsR
 			&i.UserID,
 			&i.EditedBy,
 			&i.EventID,
+			&i.AbsenceID,
+			&i.ReminderCount,
+			&i.LastRemindedAt,
+			&i.EscalatedAt,
 			&i.ID_2,
 			&i.Username,
 			&i.Email,
@@ -129,13 +163,21 @@ func (q *Queries) GetPendingRequests(ctx context.Context) ([]GetPendingRequestsR
 			&i.AworkID,
 			&i.WorkdayHours,
 			&i.WorkdaysWeek,
+			&i.EntryDate,
+			&i.ExitDate,
+			&i.Schedule,
+			&i.Region,
 			&i.ID_3,
-			&i.ScheduledAt,
 			&i.Name,
+			&i.StartDate,
+			&i.EndDate,
+			&i.HalfDayStart,
+			&i.HalfDayEnd,
 			&i.State_2,
 			&i.CreatedAt_3,
 			&i.EditedAt_3,
 			&i.UserID_2,
+			&i.EventCount,
 		); err != nil {
 			return nil, err
 		}
@@ -150,8 +192,58 @@ func (q *Queries) GetPendingRequests(ctx context.Context) ([]GetPendingRequestsR
 	return items, nil
 }
 
+const GetRequestByAbsenceId = `-- name: GetRequestByAbsenceId :one
+SELECT id, message, state, created_at, edited_at, user_id, edited_by, event_id, absence_id, reminder_count, last_reminded_at, escalated_at FROM requests
+WHERE absence_id = ?
+`
+
+func (q *Queries) GetRequestByAbsenceId(ctx context.Context, absenceID *int64) (Request, error) {
+	row := q.db.QueryRowContext(ctx, GetRequestByAbsenceId, absenceID)
+	var i Request
+	err := row.Scan(
+		&i.ID,
+		&i.Message,
+		&i.State,
+		&i.CreatedAt,
+		&i.EditedAt,
+		&i.UserID,
+		&i.EditedBy,
+		&i.EventID,
+		&i.AbsenceID,
+		&i.ReminderCount,
+		&i.LastRemindedAt,
+		&i.EscalatedAt,
+	)
+	return i, err
+}
+
+const GetRequestById = `-- name: GetRequestById :one
+SELECT id, message, state, created_at, edited_at, user_id, edited_by, event_id, absence_id, reminder_count, last_reminded_at, escalated_at FROM requests
+WHERE id = ?
+`
+
+func (q *Queries) GetRequestById(ctx context.Context, id int64) (Request, error) {
+	row := q.db.QueryRowContext(ctx, GetRequestById, id)
+	var i Request
+	err := row.Scan(
+		&i.ID,
+		&i.Message,
+		&i.State,
+		&i.CreatedAt,
+		&i.EditedAt,
+		&i.UserID,
+		&i.EditedBy,
+		&i.EventID,
+		&i.AbsenceID,
+		&i.ReminderCount,
+		&i.LastRemindedAt,
+		&i.EscalatedAt,
+	)
+	return i, err
+}
+
 const GetRequestRange = `-- name: GetRequestRange :many
-SELECT r.id, r.message, r.state, r.created_at, r.edited_at, r.user_id, r.edited_by, r.event_id FROM requests r
+SELECT r.id, r.message, r.state, r.created_at, r.edited_at, r.user_id, r.edited_by, r.event_id, r.absence_id, r.reminder_count, r.last_reminded_at, r.escalated_at FROM requests r
 JOIN users u ON r.user_id = u.id
 JOIN events e ON r.event_id = e.id
 WHERE r.user_id = ?
@@ -184,6 +276,10 @@ func (q *Queries) GetRequestRange(ctx context.Context, arg GetRequestRangeParams
 			&i.UserID,
 			&i.EditedBy,
 			&i.EventID,
+			&i.AbsenceID,
+			&i.ReminderCount,
+			&i.LastRemindedAt,
+			&i.EscalatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -198,6 +294,28 @@ func (q *Queries) GetRequestRange(ctx context.Context, arg GetRequestRangeParams
 	return items, nil
 }
 
+const UpdateAbsenceRequestState = `-- name: UpdateAbsenceRequestState :exec
+UPDATE requests
+SET state = ?,
+    edited_by = ?,
+    edited_at = CURRENT_TIMESTAMP,
+    reminder_count = 0,
+    last_reminded_at = NULL,
+    escalated_at = NULL
+WHERE absence_id = ?
+`
+
+type UpdateAbsenceRequestStateParams struct {
+	State     string `json:"state"`
+	EditedBy  *int64 `json:"edited_by"`
+	AbsenceID *int64 `json:"absence_id"`
+}
+
+func (q *Queries) UpdateAbsenceRequestState(ctx context.Context, arg UpdateAbsenceRequestStateParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateAbsenceRequestState, arg.State, arg.EditedBy, arg.AbsenceID)
+	return err
+}
+
 const UpdateRequest = `-- name: UpdateRequest :one
 UPDATE requests
 SET message = ?,
@@ -206,7 +324,7 @@ edited_by = ?,
 event_id = ?,
 edited_at = CURRENT_TIMESTAMP
 WHERE id = ?
-RETURNING id, message, state, created_at, edited_at, user_id, edited_by, event_id
+RETURNING id, message, state, created_at, edited_at, user_id, edited_by, event_id, absence_id, reminder_count, last_reminded_at, escalated_at
 `
 
 type UpdateRequestParams struct {
@@ -235,42 +353,43 @@ func (q *Queries) UpdateRequest(ctx context.Context, arg UpdateRequestParams) (R
 		&i.UserID,
 		&i.EditedBy,
 		&i.EventID,
+		&i.AbsenceID,
+		&i.ReminderCount,
+		&i.LastRemindedAt,
+		&i.EscalatedAt,
 	)
 	return i, err
 }
 
-const UpdateRequestStateRange = `-- name: UpdateRequestStateRange :one
+const UpdateRequestEscalation = `-- name: UpdateRequestEscalation :exec
 UPDATE requests
-SET state = ?,
-    edited_by = ?,
-    edited_at = CURRENT_TIMESTAMP
-WHERE requests.user_id = ?
-AND event_id IN (
-    SELECT e.id
-    FROM events e
-    WHERE e.scheduled_at >= ?
-      AND e.scheduled_at <= ?
-  )
-RETURNING requests.id
+SET escalated_at = ?
+WHERE id = ?
 `
 
-type UpdateRequestStateRangeParams struct {
-	State         string    `json:"state"`
-	EditedBy      *int64    `json:"edited_by"`
-	UserID        int64     `json:"user_id"`
-	ScheduledAt   time.Time `json:"scheduled_at"`
-	ScheduledAt_2 time.Time `json:"scheduled_at_2"`
+type UpdateRequestEscalationParams struct {
+	EscalatedAt *time.Time `json:"escalated_at"`
+	ID          int64      `json:"id"`
 }
 
-func (q *Queries) UpdateRequestStateRange(ctx context.Context, arg UpdateRequestStateRangeParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, UpdateRequestStateRange,
-		arg.State,
-		arg.EditedBy,
-		arg.UserID,
-		arg.ScheduledAt,
-		arg.ScheduledAt_2,
-	)
-	var id int64
-	err := row.Scan(&id)
-	return id, err
+func (q *Queries) UpdateRequestEscalation(ctx context.Context, arg UpdateRequestEscalationParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateRequestEscalation, arg.EscalatedAt, arg.ID)
+	return err
+}
+
+const UpdateRequestReminder = `-- name: UpdateRequestReminder :exec
+UPDATE requests
+SET reminder_count = reminder_count + 1,
+    last_reminded_at = ?
+WHERE id = ?
+`
+
+type UpdateRequestReminderParams struct {
+	LastRemindedAt *time.Time `json:"last_reminded_at"`
+	ID             int64      `json:"id"`
+}
+
+func (q *Queries) UpdateRequestReminder(ctx context.Context, arg UpdateRequestReminderParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateRequestReminder, arg.LastRemindedAt, arg.ID)
+	return err
 }
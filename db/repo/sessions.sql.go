@@ -44,6 +44,19 @@ func (q *Queries) DeleteAllSessions(ctx context.Context) error {
 	return err
 }
 
+const DeleteExpiredSessions = `-- name: DeleteExpiredSessions :execrows
+DELETE FROM sessions
+WHERE valid_until < ?
+`
+
+func (q *Queries) DeleteExpiredSessions(ctx context.Context, validUntil time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, DeleteExpiredSessions, validUntil)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const DeleteSession = `-- name: DeleteSession :exec
 DELETE from sessions 
 WHERE id = ?
@@ -73,7 +86,7 @@ func (q *Queries) GetSessionById(ctx context.Context, id string) (Session, error
 }
 
 const GetUserFromSession = `-- name: GetUserFromSession :one
-SELECT u.id, u.username, u.email, u.password, u.vacation_days, u.is_superuser, u.created_at, u.edited_at, u.color, u.role, u.enabled, u.awork_id, u.workday_hours, u.workdays_week FROM sessions s
+SELECT u.id, u.username, u.email, u.password, u.vacation_days, u.is_superuser, u.created_at, u.edited_at, u.color, u.role, u.enabled, u.awork_id, u.workday_hours, u.workdays_week, u.entry_date, u.exit_date, u.schedule, u.region FROM sessions s
 JOIN users u ON s.user_id = u.id
 WHERE s.id = ?
 `
@@ -96,6 +109,10 @@ func (q *Queries) GetUserFromSession(ctx context.Context, id string) (User, erro
 		&i.AworkID,
 		&i.WorkdayHours,
 		&i.WorkdaysWeek,
+		&i.EntryDate,
+		&i.ExitDate,
+		&i.Schedule,
+		&i.Region,
 	)
 	return i, err
 }
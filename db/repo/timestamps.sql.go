@@ -10,6 +10,58 @@ import (
 	"time"
 )
 
+const AutoCloseTimestamp = `-- name: AutoCloseTimestamp :one
+UPDATE timestamps
+SET end_time = ?,
+auto_closed = 1
+WHERE id = ?
+AND end_time IS NULL
+RETURNING id, start_time, end_time, user_id, auto_closed
+`
+
+type AutoCloseTimestampParams struct {
+	EndTime *time.Time `json:"end_time"`
+	ID      int64      `json:"id"`
+}
+
+func (q *Queries) AutoCloseTimestamp(ctx context.Context, arg AutoCloseTimestampParams) (Timestamp, error) {
+	row := q.db.QueryRowContext(ctx, AutoCloseTimestamp, arg.EndTime, arg.ID)
+	var i Timestamp
+	err := row.Scan(
+		&i.ID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.UserID,
+		&i.AutoClosed,
+	)
+	return i, err
+}
+
+const CreateTimestamp = `-- name: CreateTimestamp :one
+INSERT INTO timestamps (user_id, start_time, end_time)
+VALUES (?, ?, ?)
+RETURNING id, start_time, end_time, user_id, auto_closed
+`
+
+type CreateTimestampParams struct {
+	UserID    int64      `json:"user_id"`
+	StartTime time.Time  `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+}
+
+func (q *Queries) CreateTimestamp(ctx context.Context, arg CreateTimestampParams) (Timestamp, error) {
+	row := q.db.QueryRowContext(ctx, CreateTimestamp, arg.UserID, arg.StartTime, arg.EndTime)
+	var i Timestamp
+	err := row.Scan(
+		&i.ID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.UserID,
+		&i.AutoClosed,
+	)
+	return i, err
+}
+
 const DeleteTimestamp = `-- name: DeleteTimestamp :exec
 DELETE FROM timestamps
 WHERE id = ?
@@ -21,7 +73,7 @@ func (q *Queries) DeleteTimestamp(ctx context.Context, id int64) error {
 }
 
 const GetAllTimestampsForUser = `-- name: GetAllTimestampsForUser :many
-SELECT id, start_time, end_time, user_id FROM timestamps
+SELECT id, start_time, end_time, user_id, auto_closed FROM timestamps
 WHERE user_id = ?
 `
 
@@ -39,6 +91,7 @@ func (q *Queries) GetAllTimestampsForUser(ctx context.Context, userID int64) ([]
 			&i.StartTime,
 			&i.EndTime,
 			&i.UserID,
+			&i.AutoClosed,
 		); err != nil {
 			return nil, err
 		}
@@ -54,7 +107,7 @@ func (q *Queries) GetAllTimestampsForUser(ctx context.Context, userID int64) ([]
 }
 
 const GetAllTimestampsInRange = `-- name: GetAllTimestampsInRange :many
-SELECT id, start_time, end_time, user_id FROM timestamps
+SELECT id, start_time, end_time, user_id, auto_closed FROM timestamps
 WHERE start_time < ?1
 AND end_time IS NOT NULL
 AND end_time > ?2
@@ -79,6 +132,7 @@ func (q *Queries) GetAllTimestampsInRange(ctx context.Context, arg GetAllTimesta
 			&i.StartTime,
 			&i.EndTime,
 			&i.UserID,
+			&i.AutoClosed,
 		); err != nil {
 			return nil, err
 		}
@@ -93,8 +147,28 @@ func (q *Queries) GetAllTimestampsInRange(ctx context.Context, arg GetAllTimesta
 	return items, nil
 }
 
+const GetFirstTimestamp = `-- name: GetFirstTimestamp :one
+SELECT id, start_time, end_time, user_id, auto_closed FROM timestamps
+WHERE user_id = ?
+ORDER BY start_time
+LIMIT 1
+`
+
+func (q *Queries) GetFirstTimestamp(ctx context.Context, userID int64) (Timestamp, error) {
+	row := q.db.QueryRowContext(ctx, GetFirstTimestamp, userID)
+	var i Timestamp
+	err := row.Scan(
+		&i.ID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.UserID,
+		&i.AutoClosed,
+	)
+	return i, err
+}
+
 const GetLatestTimestamp = `-- name: GetLatestTimestamp :one
-SELECT id, start_time, end_time, user_id FROM timestamps
+SELECT id, start_time, end_time, user_id, auto_closed FROM timestamps
 WHERE user_id = ?
 ORDER BY id DESC
 `
@@ -107,12 +181,67 @@ func (q *Queries) GetLatestTimestamp(ctx context.Context, userID int64) (Timesta
 		&i.StartTime,
 		&i.EndTime,
 		&i.UserID,
+		&i.AutoClosed,
+	)
+	return i, err
+}
+
+const GetOpenTimestampsBefore = `-- name: GetOpenTimestampsBefore :many
+SELECT id, start_time, end_time, user_id, auto_closed FROM timestamps
+WHERE end_time IS NULL
+AND start_time < ?
+`
+
+func (q *Queries) GetOpenTimestampsBefore(ctx context.Context, startTime time.Time) ([]Timestamp, error) {
+	rows, err := q.db.QueryContext(ctx, GetOpenTimestampsBefore, startTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Timestamp
+	for rows.Next() {
+		var i Timestamp
+		if err := rows.Scan(
+			&i.ID,
+			&i.StartTime,
+			&i.EndTime,
+			&i.UserID,
+			&i.AutoClosed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetRunningTimestamp = `-- name: GetRunningTimestamp :one
+SELECT id, start_time, end_time, user_id, auto_closed FROM timestamps
+WHERE user_id = ?
+AND end_time IS NULL
+`
+
+func (q *Queries) GetRunningTimestamp(ctx context.Context, userID int64) (Timestamp, error) {
+	row := q.db.QueryRowContext(ctx, GetRunningTimestamp, userID)
+	var i Timestamp
+	err := row.Scan(
+		&i.ID,
+		&i.StartTime,
+		&i.EndTime,
+		&i.UserID,
+		&i.AutoClosed,
 	)
 	return i, err
 }
 
 const GetTimestampById = `-- name: GetTimestampById :one
-SELECT id, start_time, end_time, user_id FROM timestamps
+SELECT id, start_time, end_time, user_id, auto_closed FROM timestamps
 WHERE id = ?
 `
 
@@ -124,12 +253,13 @@ func (q *Queries) GetTimestampById(ctx context.Context, id int64) (Timestamp, er
 		&i.StartTime,
 		&i.EndTime,
 		&i.UserID,
+		&i.AutoClosed,
 	)
 	return i, err
 }
 
 const GetTimestampsInRange = `-- name: GetTimestampsInRange :many
-SELECT id, start_time, end_time, user_id FROM timestamps
+SELECT id, start_time, end_time, user_id, auto_closed FROM timestamps
 WHERE user_id = ?
 AND start_time < ?
 AND end_time IS NOT NULL
@@ -156,6 +286,7 @@ func (q *Queries) GetTimestampsInRange(ctx context.Context, arg GetTimestampsInR
 			&i.StartTime,
 			&i.EndTime,
 			&i.UserID,
+			&i.AutoClosed,
 		); err != nil {
 			return nil, err
 		}
@@ -202,7 +333,8 @@ func (q *Queries) GetTotalSecondsInRange(ctx context.Context, arg GetTotalSecond
 const StartTimestamp = `-- name: StartTimestamp :one
 INSERT INTO timestamps (user_id)
 VALUES (?)
-RETURNING id, start_time, end_time, user_id
+ON CONFLICT (user_id) WHERE end_time IS NULL DO NOTHING
+RETURNING id, start_time, end_time, user_id, auto_closed
 `
 
 func (q *Queries) StartTimestamp(ctx context.Context, userID int64) (Timestamp, error) {
@@ -213,6 +345,7 @@ func (q *Queries) StartTimestamp(ctx context.Context, userID int64) (Timestamp,
 		&i.StartTime,
 		&i.EndTime,
 		&i.UserID,
+		&i.AutoClosed,
 	)
 	return i, err
 }
@@ -221,7 +354,7 @@ const StopTimestamp = `-- name: StopTimestamp :one
 UPDATE timestamps
 SET end_time = CURRENT_TIMESTAMP
 WHERE id = ?
-RETURNING id, start_time, end_time, user_id
+RETURNING id, start_time, end_time, user_id, auto_closed
 `
 
 func (q *Queries) StopTimestamp(ctx context.Context, id int64) (Timestamp, error) {
@@ -232,6 +365,7 @@ func (q *Queries) StopTimestamp(ctx context.Context, id int64) (Timestamp, error
 		&i.StartTime,
 		&i.EndTime,
 		&i.UserID,
+		&i.AutoClosed,
 	)
 	return i, err
 }
@@ -239,9 +373,10 @@ func (q *Queries) StopTimestamp(ctx context.Context, id int64) (Timestamp, error
 const UpdateTimestamp = `-- name: UpdateTimestamp :one
 UPDATE timestamps
 SET start_time = ?,
-end_time = ?
+end_time = ?,
+auto_closed = 0
 WHERE id = ?
-RETURNING id, start_time, end_time, user_id
+RETURNING id, start_time, end_time, user_id, auto_closed
 `
 
 type UpdateTimestampParams struct {
@@ -258,6 +393,7 @@ func (q *Queries) UpdateTimestamp(ctx context.Context, arg UpdateTimestampParams
 		&i.StartTime,
 		&i.EndTime,
 		&i.UserID,
+		&i.AutoClosed,
 	)
 	return i, err
 }
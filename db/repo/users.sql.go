@@ -7,24 +7,29 @@ package repo
 
 import (
 	"context"
+	"time"
 )
 
 const CreateUser = `-- name: CreateUser :one
-INSERT INTO users (username, color, vacation_days, email, password, is_superuser, awork_id, workday_hours, workdays_week)
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-RETURNING id, username, email, password, vacation_days, is_superuser, created_at, edited_at, color, role, enabled, awork_id, workday_hours, workdays_week
+INSERT INTO users (username, color, vacation_days, email, password, is_superuser, awork_id, workday_hours, workdays_week, entry_date, exit_date, schedule, region)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+RETURNING id, username, email, password, vacation_days, is_superuser, created_at, edited_at, color, role, enabled, awork_id, workday_hours, workdays_week, entry_date, exit_date, schedule, region
 `
 
 type CreateUserParams struct {
-	Username     string  `json:"username"`
-	Color        string  `json:"color"`
-	VacationDays int64   `json:"vacation_days"`
-	Email        string  `json:"email"`
-	Password     string  `json:"password"`
-	IsSuperuser  bool    `json:"is_superuser"`
-	AworkID      *string `json:"awork_id"`
-	WorkdayHours float64 `json:"workday_hours"`
-	WorkdaysWeek float64 `json:"workdays_week"`
+	Username     string     `json:"username"`
+	Color        string     `json:"color"`
+	VacationDays int64      `json:"vacation_days"`
+	Email        string     `json:"email"`
+	Password     string     `json:"password"`
+	IsSuperuser  bool       `json:"is_superuser"`
+	AworkID      *string    `json:"awork_id"`
+	WorkdayHours float64    `json:"workday_hours"`
+	WorkdaysWeek float64    `json:"workdays_week"`
+	EntryDate    *time.Time `json:"entry_date"`
+	ExitDate     *time.Time `json:"exit_date"`
+	Schedule     *string    `json:"schedule"`
+	Region       *string    `json:"region"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
@@ -38,6 +43,10 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		arg.AworkID,
 		arg.WorkdayHours,
 		arg.WorkdaysWeek,
+		arg.EntryDate,
+		arg.ExitDate,
+		arg.Schedule,
+		arg.Region,
 	)
 	var i User
 	err := row.Scan(
@@ -55,6 +64,10 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.AworkID,
 		&i.WorkdayHours,
 		&i.WorkdaysWeek,
+		&i.EntryDate,
+		&i.ExitDate,
+		&i.Schedule,
+		&i.Region,
 	)
 	return i, err
 }
@@ -70,7 +83,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id int64) error {
 }
 
 const GetAdmins = `-- name: GetAdmins :many
-SELECT id, username, email, password, vacation_days, is_superuser, created_at, edited_at, color, role, enabled, awork_id, workday_hours, workdays_week FROM users
+SELECT id, username, email, password, vacation_days, is_superuser, created_at, edited_at, color, role, enabled, awork_id, workday_hours, workdays_week, entry_date, exit_date, schedule, region FROM users
 WHERE is_superuser = true
 `
 
@@ -98,6 +111,10 @@ func (q *Queries) GetAdmins(ctx context.Context) ([]User, error) {
 			&i.AworkID,
 			&i.WorkdayHours,
 			&i.WorkdaysWeek,
+			&i.EntryDate,
+			&i.ExitDate,
+			&i.Schedule,
+			&i.Region,
 		); err != nil {
 			return nil, err
 		}
@@ -113,7 +130,7 @@ func (q *Queries) GetAdmins(ctx context.Context) ([]User, error) {
 }
 
 const GetAllUsers = `-- name: GetAllUsers :many
-SELECT id, username, email, password, vacation_days, is_superuser, created_at, edited_at, color, role, enabled, awork_id, workday_hours, workdays_week FROM users
+SELECT id, username, email, password, vacation_days, is_superuser, created_at, edited_at, color, role, enabled, awork_id, workday_hours, workdays_week, entry_date, exit_date, schedule, region FROM users
 WHERE id != 1
 `
 
@@ -141,6 +158,10 @@ func (q *Queries) GetAllUsers(ctx context.Context) ([]User, error) {
 			&i.AworkID,
 			&i.WorkdayHours,
 			&i.WorkdaysWeek,
+			&i.EntryDate,
+			&i.ExitDate,
+			&i.Schedule,
+			&i.Region,
 		); err != nil {
 			return nil, err
 		}
@@ -156,7 +177,7 @@ func (q *Queries) GetAllUsers(ctx context.Context) ([]User, error) {
 }
 
 const GetUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, username, email, password, vacation_days, is_superuser, created_at, edited_at, color, role, enabled, awork_id, workday_hours, workdays_week FROM users
+SELECT id, username, email, password, vacation_days, is_superuser, created_at, edited_at, color, role, enabled, awork_id, workday_hours, workdays_week, entry_date, exit_date, schedule, region FROM users
 WHERE email = ?
 `
 
@@ -178,12 +199,16 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.AworkID,
 		&i.WorkdayHours,
 		&i.WorkdaysWeek,
+		&i.EntryDate,
+		&i.ExitDate,
+		&i.Schedule,
+		&i.Region,
 	)
 	return i, err
 }
 
 const GetUserByID = `-- name: GetUserByID :one
-SELECT id, username, email, password, vacation_days, is_superuser, created_at, edited_at, color, role, enabled, awork_id, workday_hours, workdays_week FROM users
+SELECT id, username, email, password, vacation_days, is_superuser, created_at, edited_at, color, role, enabled, awork_id, workday_hours, workdays_week, entry_date, exit_date, schedule, region FROM users
 WHERE id = ?
 `
 
@@ -205,12 +230,16 @@ func (q *Queries) GetUserByID(ctx context.Context, id int64) (User, error) {
 		&i.AworkID,
 		&i.WorkdayHours,
 		&i.WorkdaysWeek,
+		&i.EntryDate,
+		&i.ExitDate,
+		&i.Schedule,
+		&i.Region,
 	)
 	return i, err
 }
 
 const GetUserByName = `-- name: GetUserByName :one
-SELECT id, username, email, password, vacation_days, is_superuser, created_at, edited_at, color, role, enabled, awork_id, workday_hours, workdays_week FROM users
+SELECT id, username, email, password, vacation_days, is_superuser, created_at, edited_at, color, role, enabled, awork_id, workday_hours, workdays_week, entry_date, exit_date, schedule, region FROM users
 WHERE username = ?
 `
 
@@ -232,6 +261,10 @@ func (q *Queries) GetUserByName(ctx context.Context, username string) (User, err
 		&i.AworkID,
 		&i.WorkdayHours,
 		&i.WorkdaysWeek,
+		&i.EntryDate,
+		&i.ExitDate,
+		&i.Schedule,
+		&i.Region,
 	)
 	return i, err
 }
@@ -248,23 +281,31 @@ vacation_days = ?,
 is_superuser = ?,
 workday_hours = ?,
 workdays_week = ?,
+entry_date = ?,
+exit_date = ?,
+schedule = ?,
+region = ?,
 edited_at = CURRENT_TIMESTAMP
 WHERE id = ?
-RETURNING id, username, email, password, vacation_days, is_superuser, created_at, edited_at, color, role, enabled, awork_id, workday_hours, workdays_week
+RETURNING id, username, email, password, vacation_days, is_superuser, created_at, edited_at, color, role, enabled, awork_id, workday_hours, workdays_week, entry_date, exit_date, schedule, region
 `
 
 type UpdateUserParams struct {
-	Color        string  `json:"color"`
-	Username     string  `json:"username"`
-	Email        string  `json:"email"`
-	Password     string  `json:"password"`
-	Role         string  `json:"role"`
-	AworkID      *string `json:"awork_id"`
-	VacationDays int64   `json:"vacation_days"`
-	IsSuperuser  bool    `json:"is_superuser"`
-	WorkdayHours float64 `json:"workday_hours"`
-	WorkdaysWeek float64 `json:"workdays_week"`
-	ID           int64   `json:"id"`
+	Color        string     `json:"color"`
+	Username     string     `json:"username"`
+	Email        string     `json:"email"`
+	Password     string     `json:"password"`
+	Role         string     `json:"role"`
+	AworkID      *string    `json:"awork_id"`
+	VacationDays int64      `json:"vacation_days"`
+	IsSuperuser  bool       `json:"is_superuser"`
+	WorkdayHours float64    `json:"workday_hours"`
+	WorkdaysWeek float64    `json:"workdays_week"`
+	EntryDate    *time.Time `json:"entry_date"`
+	ExitDate     *time.Time `json:"exit_date"`
+	Schedule     *string    `json:"schedule"`
+	Region       *string    `json:"region"`
+	ID           int64      `json:"id"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
@@ -279,6 +320,10 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.IsSuperuser,
 		arg.WorkdayHours,
 		arg.WorkdaysWeek,
+		arg.EntryDate,
+		arg.ExitDate,
+		arg.Schedule,
+		arg.Region,
 		arg.ID,
 	)
 	var i User
@@ -297,6 +342,10 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.AworkID,
 		&i.WorkdayHours,
 		&i.WorkdaysWeek,
+		&i.EntryDate,
+		&i.ExitDate,
+		&i.Schedule,
+		&i.Region,
 	)
 	return i, err
 }
@@ -11,9 +11,9 @@ import (
 )
 
 const CreateVacationToken = `-- name: CreateVacationToken :one
-INSERT INTO vacation_tokens (user_id, start_date, end_date, value)
-VALUES (?,?,?,?)
-RETURNING id, start_date, end_date, value, user_id
+INSERT INTO vacation_tokens (user_id, start_date, end_date, value, reason, request_id, event_id, created_by, absence_id)
+VALUES (?,?,?,?,?,?,?,?,?)
+RETURNING id, start_date, end_date, value, user_id, reason, request_id, event_id, created_by, created_at, absence_id
 `
 
 type CreateVacationTokenParams struct {
@@ -21,6 +21,11 @@ type CreateVacationTokenParams struct {
 	StartDate time.Time `json:"start_date"`
 	EndDate   time.Time `json:"end_date"`
 	Value     float64   `json:"value"`
+	Reason    string    `json:"reason"`
+	RequestID *int64    `json:"request_id"`
+	EventID   *int64    `json:"event_id"`
+	CreatedBy *int64    `json:"created_by"`
+	AbsenceID *int64    `json:"absence_id"`
 }
 
 func (q *Queries) CreateVacationToken(ctx context.Context, arg CreateVacationTokenParams) (VacationToken, error) {
@@ -29,6 +34,11 @@ func (q *Queries) CreateVacationToken(ctx context.Context, arg CreateVacationTok
 		arg.StartDate,
 		arg.EndDate,
 		arg.Value,
+		arg.Reason,
+		arg.RequestID,
+		arg.EventID,
+		arg.CreatedBy,
+		arg.AbsenceID,
 	)
 	var i VacationToken
 	err := row.Scan(
@@ -37,6 +47,12 @@ func (q *Queries) CreateVacationToken(ctx context.Context, arg CreateVacationTok
 		&i.EndDate,
 		&i.Value,
 		&i.UserID,
+		&i.Reason,
+		&i.RequestID,
+		&i.EventID,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.AbsenceID,
 	)
 	return i, err
 }
@@ -79,3 +95,183 @@ func (q *Queries) GetRemainingVacationForUser(ctx context.Context, arg GetRemain
 	err := row.Scan(&sum)
 	return sum, err
 }
+
+const GetVacationPotsForUser = `-- name: GetVacationPotsForUser :many
+SELECT start_date, end_date, CAST(SUM(value) AS FLOAT) AS value
+FROM vacation_tokens
+WHERE user_id = ?
+AND start_date <= ?
+AND end_date >= ?
+GROUP BY start_date, end_date
+ORDER BY end_date, start_date
+`
+
+type GetVacationPotsForUserParams struct {
+	UserID    int64     `json:"user_id"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+type GetVacationPotsForUserRow struct {
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	Value     float64   `json:"value"`
+}
+
+func (q *Queries) GetVacationPotsForUser(ctx context.Context, arg GetVacationPotsForUserParams) ([]GetVacationPotsForUserRow, error) {
+	rows, err := q.db.QueryContext(ctx, GetVacationPotsForUser, arg.UserID, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetVacationPotsForUserRow
+	for rows.Next() {
+		var i GetVacationPotsForUserRow
+		if err := rows.Scan(
+			&i.StartDate,
+			&i.EndDate,
+			&i.Value,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetVacationTokensForAbsence = `-- name: GetVacationTokensForAbsence :many
+SELECT id, start_date, end_date, value, user_id, reason, request_id, event_id, created_by, created_at, absence_id FROM vacation_tokens
+WHERE absence_id = ?
+ORDER BY id
+`
+
+func (q *Queries) GetVacationTokensForAbsence(ctx context.Context, absenceID *int64) ([]VacationToken, error) {
+	rows, err := q.db.QueryContext(ctx, GetVacationTokensForAbsence, absenceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []VacationToken
+	for rows.Next() {
+		var i VacationToken
+		if err := rows.Scan(
+			&i.ID,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Value,
+			&i.UserID,
+			&i.Reason,
+			&i.RequestID,
+			&i.EventID,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.AbsenceID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetVacationTokensForEvent = `-- name: GetVacationTokensForEvent :many
+SELECT id, start_date, end_date, value, user_id, reason, request_id, event_id, created_by, created_at, absence_id FROM vacation_tokens
+WHERE event_id = ?
+ORDER BY id
+`
+
+func (q *Queries) GetVacationTokensForEvent(ctx context.Context, eventID *int64) ([]VacationToken, error) {
+	rows, err := q.db.QueryContext(ctx, GetVacationTokensForEvent, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []VacationToken
+	for rows.Next() {
+		var i VacationToken
+		if err := rows.Scan(
+			&i.ID,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Value,
+			&i.UserID,
+			&i.Reason,
+			&i.RequestID,
+			&i.EventID,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.AbsenceID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const GetVacationTokensForUser = `-- name: GetVacationTokensForUser :many
+SELECT id, start_date, end_date, value, user_id, reason, request_id, event_id, created_by, created_at, absence_id FROM vacation_tokens
+WHERE user_id = ?
+AND start_date >= ?
+AND start_date < ?
+ORDER BY created_at, id
+`
+
+type GetVacationTokensForUserParams struct {
+	UserID      int64     `json:"user_id"`
+	StartDate   time.Time `json:"start_date"`
+	StartDate_2 time.Time `json:"start_date_2"`
+}
+
+func (q *Queries) GetVacationTokensForUser(ctx context.Context, arg GetVacationTokensForUserParams) ([]VacationToken, error) {
+	rows, err := q.db.QueryContext(ctx, GetVacationTokensForUser, arg.UserID, arg.StartDate, arg.StartDate_2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []VacationToken
+	for rows.Next() {
+		var i VacationToken
+		if err := rows.Scan(
+			&i.ID,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Value,
+			&i.UserID,
+			&i.Reason,
+			&i.RequestID,
+			&i.EventID,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.AbsenceID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
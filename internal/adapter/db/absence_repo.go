@@ -0,0 +1,367 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"chrono/db/repo"
+	"chrono/internal/domain"
+)
+
+type SQLAbsenceRepo struct {
+	db  *sql.DB
+	q   *repo.Queries
+	log *slog.Logger
+}
+
+func NewSQLAbsenceRepo(db *sql.DB, log *slog.Logger) domain.AbsenceRepository {
+	return &SQLAbsenceRepo{db: db, q: repo.New(NewDBTX(db)), log: log}
+}
+
+// withTx runs fn inside a transaction and rolls back if fn returns an error.
+func (r *SQLAbsenceRepo) withTx(ctx context.Context, fn func(q *repo.Queries) error) error {
+	tx, err := beginTx(ctx, r.db, r.log)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(r.q.WithTx(tx.Tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// createTokens stores the tokens and links them to the absence and its
+// request and event unless they already reference one.
+func (r *SQLAbsenceRepo) createTokens(
+	ctx context.Context,
+	q *repo.Queries,
+	tokens []domain.CreateVacationToken,
+	absenceId int64,
+	requestId, eventId *int64,
+) error {
+	for i := range tokens {
+		if tokens[i].AbsenceID == nil {
+			tokens[i].AbsenceID = &absenceId
+		}
+		if tokens[i].RequestID == nil {
+			tokens[i].RequestID = requestId
+		}
+		if tokens[i].EventID == nil {
+			tokens[i].EventID = eventId
+		}
+	}
+
+	return createVacationTokens(ctx, q, r.log, tokens)
+}
+
+// requestId returns the id of the request that belongs to the absence, or nil
+// if the absence never needed approval.
+func (r *SQLAbsenceRepo) requestId(ctx context.Context, q *repo.Queries, id int64) (*int64, error) {
+	req, err := q.GetRequestByAbsenceId(ctx, &id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.Error(
+			"GetRequestByAbsenceId failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return &req.ID, nil
+}
+
+func (r *SQLAbsenceRepo) Create(
+	ctx context.Context,
+	a *domain.Absence,
+	days []domain.AbsenceDay,
+	msg *string,
+	tokens []domain.CreateVacationToken,
+) (*domain.Absence, error) {
+	var absence repo.Absence
+
+	err := r.withTx(ctx, func(q *repo.Queries) error {
+		var err error
+		absence, err = q.CreateAbsence(ctx, repo.CreateAbsenceParams{
+			Name:         a.Name,
+			UserID:       a.UserID,
+			StartDate:    a.StartDate,
+			EndDate:      a.EndDate,
+			HalfDayStart: a.HalfDayStart,
+			HalfDayEnd:   a.HalfDayEnd,
+			State:        a.State,
+		})
+		if err != nil {
+			r.log.Error(
+				"CreateAbsence failed",
+				slog.Int64("userId", a.UserID),
+				slog.String("name", a.Name),
+				slog.String("error", err.Error()),
+			)
+			return err
+		}
+
+		var firstEvent int64
+		for i, d := range days {
+			e, err := q.CreateAbsenceEvent(ctx, repo.CreateAbsenceEventParams{
+				Name:        a.Name,
+				UserID:      a.UserID,
+				ScheduledAt: d.Date,
+				State:       a.State,
+				HalfDay:     d.HalfDay,
+				AbsenceID:   &absence.ID,
+			})
+			if err != nil {
+				r.log.Error(
+					"CreateAbsenceEvent failed",
+					slog.Int64("absenceId", absence.ID),
+					slog.String("error", err.Error()),
+				)
+				return err
+			}
+			if i == 0 {
+				firstEvent = e.ID
+			}
+		}
+
+		var requestId *int64
+		if msg != nil {
+			req, err := q.CreateRequest(ctx, repo.CreateRequestParams{
+				Message:   msg,
+				State:     a.State,
+				UserID:    a.UserID,
+				EventID:   firstEvent,
+				AbsenceID: &absence.ID,
+			})
+			if err != nil {
+				r.log.Error(
+					"CreateRequest failed",
+					slog.Int64("absenceId", absence.ID),
+					slog.String("error", err.Error()),
+				)
+				return err
+			}
+			requestId = &req.ID
+		}
+
+		return r.createTokens(ctx, q, tokens, absence.ID, requestId, &firstEvent)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return (*domain.Absence)(&absence), nil
+}
+
+func (r *SQLAbsenceRepo) UpdateState(
+	ctx context.Context,
+	id int64,
+	state string,
+	editorId int64,
+	tokens []domain.CreateVacationToken,
+) (*domain.Absence, error) {
+	var absence repo.Absence
+
+	err := r.withTx(ctx, func(q *repo.Queries) error {
+		requestId, err := r.requestId(ctx, q, id)
+		if err != nil {
+			return err
+		}
+
+		absence, err = q.UpdateAbsenceState(ctx, repo.UpdateAbsenceStateParams{State: state, ID: id})
+		if err != nil {
+			r.log.Error(
+				"UpdateAbsenceState failed",
+				slog.Int64("id", id),
+				slog.String("error", err.Error()),
+			)
+			return err
+		}
+
+		err = q.UpdateAbsenceEventsState(
+			ctx,
+			repo.UpdateAbsenceEventsStateParams{
+				State:     domain.AbsenceEventState(state),
+				AbsenceID: &id,
+			},
+		)
+		if err != nil {
+			r.log.Error(
+				"UpdateAbsenceEventsState failed",
+				slog.Int64("id", id),
+				slog.String("error", err.Error()),
+			)
+			return err
+		}
+
+		err = q.UpdateAbsenceRequestState(
+			ctx,
+			repo.UpdateAbsenceRequestStateParams{State: state, EditedBy: &editorId, AbsenceID: &id},
+		)
+		if err != nil {
+			r.log.Error(
+				"UpdateAbsenceRequestState failed",
+				slog.Int64("id", id),
+				slog.String("error", err.Error()),
+			)
+			return err
+		}
+
+		return r.createTokens(ctx, q, tokens, id, requestId, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return (*domain.Absence)(&absence), nil
+}
+
+func (r *SQLAbsenceRepo) Delete(
+	ctx context.Context,
+	id int64,
+	tokens []domain.CreateVacationToken,
+) error {
+	return r.withTx(ctx, func(q *repo.Queries) error {
+		requestId, err := r.requestId(ctx, q, id)
+		if err != nil {
+			return err
+		}
+
+		err = deleteAbsence(ctx, q, r.log, id)
+		if err != nil {
+			return err
+		}
+
+		return r.createTokens(ctx, q, tokens, id, requestId, nil)
+	})
+}
+
+func (r *SQLAbsenceRepo) GetById(ctx context.Context, id int64) (*domain.Absence, error) {
+	a, err := r.q.GetAbsenceById(ctx, id)
+	if err != nil {
+		r.log.Error(
+			"GetAbsenceById failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.Absence)(&a), nil
+}
+
+func (r *SQLAbsenceRepo) GetByRequestId(
+	ctx context.Context,
+	requestId int64,
+) (*domain.Absence, error) {
+	a, err := r.q.GetAbsenceByRequestId(ctx, requestId)
+	if err != nil {
+		r.log.Error(
+			"GetAbsenceByRequestId failed",
+			slog.Int64("requestId", requestId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.Absence)(&a), nil
+}
+
+func (r *SQLAbsenceRepo) GetForUser(
+	ctx context.Context,
+	userId int64,
+	start, end time.Time,
+) ([]domain.Absence, error) {
+	params := repo.GetAbsencesForUserParams{UserID: userId, EndDate: start, StartDate: end}
+	a, err := r.q.GetAbsencesForUser(ctx, params)
+	if err != nil {
+		r.log.Error(
+			"GetAbsencesForUser failed",
+			slog.Int64("userId", userId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	absences := make([]domain.Absence, len(a))
+	for i := range a {
+		absences[i] = (domain.Absence)(a[i])
+	}
+
+	return absences, nil
+}
+
+func (r *SQLAbsenceRepo) GetEvents(ctx context.Context, id int64) ([]domain.Event, error) {
+	e, err := r.q.GetEventsByAbsenceId(ctx, &id)
+	if err != nil {
+		r.log.Error(
+			"GetEventsByAbsenceId failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	events := make([]domain.Event, len(e))
+	for i := range e {
+		events[i] = (domain.Event)(e[i])
+	}
+
+	return events, nil
+}
+
+// deleteAbsence removes the absence with its events and request and unlinks
+// its cancellations with q, which may be bound to a transaction of another
+// repository. The rows are removed explicitly instead of relying on the
+// foreign key actions.
+func deleteAbsence(ctx context.Context, q *repo.Queries, log *slog.Logger, id int64) error {
+	err := q.DeleteAbsenceEvents(ctx, &id)
+	if err != nil {
+		log.Error(
+			"DeleteAbsenceEvents failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	err = q.DeleteAbsenceRequest(ctx, &id)
+	if err != nil {
+		log.Error(
+			"DeleteAbsenceRequest failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	err = q.ClearCancellationsAbsence(ctx, &id)
+	if err != nil {
+		log.Error(
+			"ClearCancellationsAbsence failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	err = q.DeleteAbsence(ctx, id)
+	if err != nil {
+		log.Error(
+			"DeleteAbsence failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
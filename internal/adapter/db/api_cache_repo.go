@@ -16,14 +16,18 @@ func NewSQLAPICacheRepo(r repo.Querier, log *slog.Logger) domain.ApiCacheReposit
 	return &SQLAPICacheRepo{r: r, log: log}
 }
 
-func (r *SQLAPICacheRepo) Exists(ctx context.Context, year int64) (int64, error) {
-	return r.r.CacheExists(ctx, year)
+func (r *SQLAPICacheRepo) Exists(
+	ctx context.Context,
+	year int64,
+	region domain.Region,
+) (int64, error) {
+	return r.r.CacheExists(ctx, repo.CacheExistsParams{Year: year, Region: string(region)})
 }
 
 func (r *SQLAPICacheRepo) GetAll(ctx context.Context) ([]int64, error) {
 	return r.r.GetApiCacheYears(ctx)
 }
 
-func (r *SQLAPICacheRepo) Create(ctx context.Context, year int64) error {
-	return r.r.CreateCache(ctx, year)
+func (r *SQLAPICacheRepo) Create(ctx context.Context, year int64, region domain.Region) error {
+	return r.r.CreateCache(ctx, repo.CreateCacheParams{Year: year, Region: string(region)})
 }
@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+
+	"chrono/db/repo"
+	"chrono/internal/domain"
+)
+
+type SQLApproverRepo struct {
+	q   repo.Querier
+	log *slog.Logger
+}
+
+func NewSQLApproverRepo(q repo.Querier, log *slog.Logger) domain.ApproverRepository {
+	return &SQLApproverRepo{q: q, log: log}
+}
+
+func (r *SQLApproverRepo) Create(
+	ctx context.Context,
+	a *domain.Approver,
+) (*domain.Approver, error) {
+	approver, err := r.q.CreateApprover(ctx, repo.CreateApproverParams{
+		Stage:      a.Stage,
+		ApproverID: a.ApproverID,
+		UserID:     a.UserID,
+		TeamID:     a.TeamID,
+	})
+	if err != nil {
+		r.log.Error(
+			"CreateApprover failed",
+			slog.Int64("approverId", a.ApproverID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.Approver)(&approver), nil
+}
+
+func (r *SQLApproverRepo) Delete(ctx context.Context, id int64) error {
+	err := r.q.DeleteApprover(ctx, id)
+	if err != nil {
+		r.log.Error(
+			"DeleteApprover failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (r *SQLApproverRepo) GetAll(ctx context.Context) ([]domain.Approver, error) {
+	a, err := r.q.GetAllApprovers(ctx)
+	if err != nil {
+		r.log.Error("GetAllApprovers failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	approvers := make([]domain.Approver, len(a))
+	for i := range a {
+		approvers[i] = (domain.Approver)(a[i])
+	}
+
+	return approvers, nil
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+
+	"chrono/db/repo"
+	"chrono/internal/domain"
+)
+
+type SQLAutoApproveRepo struct {
+	q   repo.Querier
+	log *slog.Logger
+}
+
+func NewSQLAutoApproveRepo(q repo.Querier, log *slog.Logger) domain.AutoApprovePolicyRepository {
+	return &SQLAutoApproveRepo{q: q, log: log}
+}
+
+func (r *SQLAutoApproveRepo) Create(
+	ctx context.Context,
+	p *domain.AutoApprovePolicy,
+) (*domain.AutoApprovePolicy, error) {
+	policy, err := r.q.CreateAutoApprovePolicy(ctx, repo.CreateAutoApprovePolicyParams{
+		Name:      p.Name,
+		EventName: p.EventName,
+		MaxDays:   p.MaxDays,
+	})
+	if err != nil {
+		r.log.Error(
+			"CreateAutoApprovePolicy failed",
+			slog.String("name", p.Name),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.AutoApprovePolicy)(&policy), nil
+}
+
+func (r *SQLAutoApproveRepo) Delete(ctx context.Context, id int64) error {
+	err := r.q.DeleteAutoApprovePolicy(ctx, id)
+	if err != nil {
+		r.log.Error(
+			"DeleteAutoApprovePolicy failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (r *SQLAutoApproveRepo) GetAll(ctx context.Context) ([]domain.AutoApprovePolicy, error) {
+	p, err := r.q.GetAllAutoApprovePolicies(ctx)
+	if err != nil {
+		r.log.Error("GetAllAutoApprovePolicies failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	policies := make([]domain.AutoApprovePolicy, len(p))
+	for i := range p {
+		policies[i] = (domain.AutoApprovePolicy)(p[i])
+	}
+
+	return policies, nil
+}
@@ -0,0 +1,198 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"chrono/db/repo"
+	"chrono/internal/domain"
+)
+
+type SQLCancellationRepo struct {
+	db  *sql.DB
+	q   *repo.Queries
+	log *slog.Logger
+}
+
+func NewSQLCancellationRepo(db *sql.DB, log *slog.Logger) domain.CancellationRepository {
+	return &SQLCancellationRepo{db: db, q: repo.New(NewDBTX(db)), log: log}
+}
+
+func (r *SQLCancellationRepo) Create(
+	ctx context.Context,
+	c *domain.Cancellation,
+) (*domain.Cancellation, error) {
+	cancellation, err := r.q.CreateCancellation(ctx, repo.CreateCancellationParams{
+		AbsenceID: c.AbsenceID,
+		UserID:    c.UserID,
+		StartDate: c.StartDate,
+		EndDate:   c.EndDate,
+		Reason:    c.Reason,
+	})
+	if err != nil {
+		r.log.Error(
+			"CreateCancellation failed",
+			slog.Int64("userId", c.UserID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.Cancellation)(&cancellation), nil
+}
+
+func (r *SQLCancellationRepo) Accept(
+	ctx context.Context,
+	c *domain.Cancellation,
+	editorId int64,
+	absence *domain.Absence,
+	tokens []domain.CreateVacationToken,
+) (*domain.Cancellation, error) {
+	tx, err := beginTx(ctx, r.db, r.log)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	q := r.q.WithTx(tx.Tx)
+
+	err = q.DeleteAbsenceEventsInRange(ctx, repo.DeleteAbsenceEventsInRangeParams{
+		AbsenceID:     c.AbsenceID,
+		ScheduledAt:   c.StartDate,
+		ScheduledAt_2: c.EndDate,
+	})
+	if err != nil {
+		r.log.Error(
+			"DeleteAbsenceEventsInRange failed",
+			slog.Int64("id", c.ID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	if absence == nil {
+		err = deleteAbsence(ctx, q, r.log, *c.AbsenceID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		_, err = q.UpdateAbsenceRange(ctx, repo.UpdateAbsenceRangeParams{
+			StartDate:    absence.StartDate,
+			EndDate:      absence.EndDate,
+			HalfDayStart: absence.HalfDayStart,
+			HalfDayEnd:   absence.HalfDayEnd,
+			ID:           absence.ID,
+		})
+		if err != nil {
+			r.log.Error(
+				"UpdateAbsenceRange failed",
+				slog.Int64("id", absence.ID),
+				slog.String("error", err.Error()),
+			)
+			return nil, err
+		}
+	}
+
+	for i := range tokens {
+		if tokens[i].AbsenceID == nil {
+			tokens[i].AbsenceID = c.AbsenceID
+		}
+	}
+	err = createVacationTokens(ctx, q, r.log, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	cancellation, err := q.UpdateCancellationState(ctx, repo.UpdateCancellationStateParams{
+		State:     "accepted",
+		DecidedBy: &editorId,
+		ID:        c.ID,
+	})
+	if err != nil {
+		r.log.Error(
+			"UpdateCancellationState failed",
+			slog.Int64("id", c.ID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return (*domain.Cancellation)(&cancellation), nil
+}
+
+func (r *SQLCancellationRepo) Decline(
+	ctx context.Context,
+	id int64,
+	editorId int64,
+) (*domain.Cancellation, error) {
+	cancellation, err := r.q.UpdateCancellationState(ctx, repo.UpdateCancellationStateParams{
+		State:     "declined",
+		DecidedBy: &editorId,
+		ID:        id,
+	})
+	if err != nil {
+		r.log.Error(
+			"UpdateCancellationState failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.Cancellation)(&cancellation), nil
+}
+
+func (r *SQLCancellationRepo) GetById(ctx context.Context, id int64) (*domain.Cancellation, error) {
+	c, err := r.q.GetCancellationById(ctx, id)
+	if err != nil {
+		r.log.Error(
+			"GetCancellationById failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.Cancellation)(&c), nil
+}
+
+func (r *SQLCancellationRepo) GetForAbsence(
+	ctx context.Context,
+	absenceId int64,
+) ([]domain.Cancellation, error) {
+	c, err := r.q.GetCancellationsForAbsence(ctx, &absenceId)
+	if err != nil {
+		r.log.Error(
+			"GetCancellationsForAbsence failed",
+			slog.Int64("absenceId", absenceId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return cancellationsToDomain(c), nil
+}
+
+func (r *SQLCancellationRepo) GetPending(ctx context.Context) ([]domain.Cancellation, error) {
+	c, err := r.q.GetPendingCancellations(ctx)
+	if err != nil {
+		r.log.Error("GetPendingCancellations failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return cancellationsToDomain(c), nil
+}
+
+func cancellationsToDomain(c []repo.Cancellation) []domain.Cancellation {
+	cancellations := make([]domain.Cancellation, len(c))
+	for i := range c {
+		cancellations[i] = (domain.Cancellation)(c[i])
+	}
+
+	return cancellations
+}
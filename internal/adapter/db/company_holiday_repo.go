@@ -0,0 +1,243 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"chrono/db/repo"
+	"chrono/internal/domain"
+)
+
+type SQLCompanyHolidayRepo struct {
+	db  *sql.DB
+	q   *repo.Queries
+	log *slog.Logger
+}
+
+func NewSQLCompanyHolidayRepo(db *sql.DB, log *slog.Logger) domain.CompanyHolidayRepository {
+	return &SQLCompanyHolidayRepo{db: db, q: repo.New(NewDBTX(db)), log: log}
+}
+
+func (r *SQLCompanyHolidayRepo) Create(
+	ctx context.Context,
+	h *domain.CompanyHoliday,
+	bot *domain.User,
+	tokens []domain.CreateVacationToken,
+) (*domain.CompanyHoliday, error) {
+	tx, err := beginTx(ctx, r.db, r.log)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	q := r.q.WithTx(tx.Tx)
+
+	event, err := q.CreateHolidayEvent(ctx, repo.CreateHolidayEventParams{
+		Name:        h.Name,
+		UserID:      bot.ID,
+		ScheduledAt: h.Date,
+		HalfDay:     h.Weight < 1,
+		Region:      h.Region,
+	})
+	if err != nil {
+		r.log.Error(
+			"CreateHolidayEvent failed",
+			slog.String("name", h.Name),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	holiday, err := q.CreateCompanyHoliday(ctx, repo.CreateCompanyHolidayParams{
+		Date:             h.Date,
+		Name:             h.Name,
+		Weight:           h.Weight,
+		Region:           h.Region,
+		ConsumesVacation: h.ConsumesVacation,
+		EventID:          &event.ID,
+		CreatedBy:        h.CreatedBy,
+	})
+	if err != nil {
+		r.log.Error(
+			"CreateCompanyHoliday failed",
+			slog.String("name", h.Name),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	for i := range tokens {
+		tokens[i].EventID = &event.ID
+	}
+
+	err = createVacationTokens(ctx, q, r.log, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return (*domain.CompanyHoliday)(&holiday), nil
+}
+
+func (r *SQLCompanyHolidayRepo) Update(
+	ctx context.Context,
+	h *domain.CompanyHoliday,
+	tokens []domain.CreateVacationToken,
+) (*domain.CompanyHoliday, error) {
+	tx, err := beginTx(ctx, r.db, r.log)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	q := r.q.WithTx(tx.Tx)
+
+	holiday, err := q.UpdateCompanyHoliday(ctx, repo.UpdateCompanyHolidayParams{
+		Date:             h.Date,
+		Name:             h.Name,
+		Weight:           h.Weight,
+		Region:           h.Region,
+		ConsumesVacation: h.ConsumesVacation,
+		ID:               h.ID,
+	})
+	if err != nil {
+		r.log.Error(
+			"UpdateCompanyHoliday failed",
+			slog.Int64("id", h.ID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	if holiday.EventID != nil {
+		_, err = q.UpdateHolidayEvent(ctx, repo.UpdateHolidayEventParams{
+			Name:        h.Name,
+			ScheduledAt: h.Date,
+			HalfDay:     h.Weight < 1,
+			Region:      h.Region,
+			ID:          *holiday.EventID,
+		})
+		if err != nil {
+			r.log.Error(
+				"UpdateHolidayEvent failed",
+				slog.Int64("id", *holiday.EventID),
+				slog.String("error", err.Error()),
+			)
+			return nil, err
+		}
+
+		for i := range tokens {
+			tokens[i].EventID = holiday.EventID
+		}
+	}
+
+	err = createVacationTokens(ctx, q, r.log, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return (*domain.CompanyHoliday)(&holiday), nil
+}
+
+func (r *SQLCompanyHolidayRepo) Delete(
+	ctx context.Context,
+	id int64,
+	tokens []domain.CreateVacationToken,
+) error {
+	tx, err := beginTx(ctx, r.db, r.log)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	q := r.q.WithTx(tx.Tx)
+
+	holiday, err := q.GetCompanyHolidayById(ctx, id)
+	if err != nil {
+		r.log.Error(
+			"GetCompanyHolidayById failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	err = createVacationTokens(ctx, q, r.log, tokens)
+	if err != nil {
+		return err
+	}
+
+	err = q.DeleteCompanyHoliday(ctx, id)
+	if err != nil {
+		r.log.Error(
+			"DeleteCompanyHoliday failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	if holiday.EventID != nil {
+		err = q.DeleteEvent(ctx, *holiday.EventID)
+		if err != nil {
+			r.log.Error(
+				"DeleteEvent failed",
+				slog.Int64("id", *holiday.EventID),
+				slog.String("error", err.Error()),
+			)
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *SQLCompanyHolidayRepo) GetById(
+	ctx context.Context,
+	id int64,
+) (*domain.CompanyHoliday, error) {
+	h, err := r.q.GetCompanyHolidayById(ctx, id)
+	if err != nil {
+		r.log.Error(
+			"GetCompanyHolidayById failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.CompanyHoliday)(&h), nil
+}
+
+func (r *SQLCompanyHolidayRepo) GetInRange(
+	ctx context.Context,
+	start, end time.Time,
+) ([]domain.CompanyHoliday, error) {
+	h, err := r.q.GetCompanyHolidaysInRange(
+		ctx,
+		repo.GetCompanyHolidaysInRangeParams{Date: start, Date_2: end},
+	)
+	if err != nil {
+		r.log.Error(
+			"GetCompanyHolidaysInRange failed",
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	holidays := make([]domain.CompanyHoliday, len(h))
+	for i := range h {
+		holidays[i] = (domain.CompanyHoliday)(h[i])
+	}
+
+	return holidays, nil
+}
@@ -23,6 +23,7 @@ func (r *SQLEventRepo) Create(
 	ctx context.Context,
 	data domain.YMDDate,
 	eventType string,
+	state string,
 	user *domain.User,
 ) (*domain.Event, error) {
 	date := time.Date(
@@ -36,12 +37,6 @@ func (r *SQLEventRepo) Create(
 		time.UTC,
 	)
 
-	evt := domain.Event{Name: eventType}
-	state := "pending"
-	if !evt.IsVacation() || user.IsSuperuser {
-		state = "accepted"
-	}
-
 	event, err := r.r.CreateEvent(
 		ctx,
 		repo.CreateEventParams{Name: eventType, UserID: user.ID, ScheduledAt: date, State: state},
@@ -58,6 +53,36 @@ func (r *SQLEventRepo) Create(
 	return (*domain.Event)(&event), nil
 }
 
+func (r *SQLEventRepo) CreateHoliday(
+	ctx context.Context,
+	holiday domain.Holiday,
+	region domain.Region,
+	bot *domain.User,
+) (*domain.Event, error) {
+	reg := string(region)
+	event, err := r.r.CreateHolidayEvent(
+		ctx,
+		repo.CreateHolidayEventParams{
+			Name:        holiday.Name,
+			UserID:      bot.ID,
+			ScheduledAt: holiday.Date,
+			HalfDay:     holiday.Weight < 1,
+			Region:      &reg,
+		},
+	)
+	if err != nil {
+		r.log.Error(
+			"CreateHolidayEvent failed",
+			slog.String("name", holiday.Name),
+			slog.String("region", reg),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.Event)(&event), nil
+}
+
 func (r *SQLEventRepo) Update(
 	ctx context.Context,
 	eventId int64,
@@ -132,6 +157,7 @@ func (r *SQLEventRepo) GetForMonth(
 	ctx context.Context,
 	data domain.YMDate,
 	botName string,
+	region domain.Region,
 	userFilter *domain.User,
 	eventFilter string,
 ) (domain.Month, error) {
@@ -163,6 +189,9 @@ func (r *SQLEventRepo) GetForMonth(
 
 	for _, event := range events {
 		idx := event.ScheduledAt.Day() - 1
+		if event.Region != nil && domain.Region(*event.Region) != region {
+			continue
+		}
 		if userFilter != nil && event.Username != userFilter.Username &&
 			event.Username != botName {
 			continue
@@ -184,6 +213,7 @@ func (r *SQLEventRepo) GetForMonth(
 				CreatedAt:    event.CreatedAt_2,
 				EditedAt:     event.EditedAt_2,
 				Color:        event.Color,
+				Region:       event.Region_2,
 			},
 			Event: domain.Event{
 				Name:        event.Name,
@@ -193,6 +223,9 @@ func (r *SQLEventRepo) GetForMonth(
 				CreatedAt:   event.CreatedAt,
 				EditedAt:    event.EditedAt,
 				UserID:      event.UserID,
+				HalfDay:     event.HalfDay,
+				AbsenceID:   event.AbsenceID,
+				Region:      event.Region,
 			},
 		}
 		month.Days[idx].Events = append(month.Days[idx].Events, newEvent)
@@ -203,6 +236,7 @@ func (r *SQLEventRepo) GetForMonth(
 
 func (r *SQLEventRepo) GetForYear(
 	ctx context.Context,
+	botName string,
 	year int,
 ) ([]domain.EventUser, error) {
 	yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
@@ -210,6 +244,7 @@ func (r *SQLEventRepo) GetForYear(
 	params := repo.GetEventsForYearParams{
 		ScheduledAt:   yearStart,
 		ScheduledAt_2: yearStart.AddDate(1, 0, 0),
+		Username:      botName,
 	}
 
 	events, err := r.r.GetEventsForYear(ctx, params)
@@ -235,6 +270,7 @@ func (r *SQLEventRepo) GetForYear(
 				CreatedAt:    event.CreatedAt_2,
 				EditedAt:     event.EditedAt_2,
 				Color:        event.Color,
+				Region:       event.Region_2,
 			},
 			Event: domain.Event{
 				Name:        event.Name,
@@ -244,6 +280,9 @@ func (r *SQLEventRepo) GetForYear(
 				CreatedAt:   event.CreatedAt,
 				EditedAt:    event.EditedAt,
 				UserID:      event.UserID,
+				HalfDay:     event.HalfDay,
+				AbsenceID:   event.AbsenceID,
+				Region:      event.Region,
 			},
 		}
 	}
@@ -324,39 +363,36 @@ func (r *SQLEventRepo) GetById(ctx context.Context, eventId int64) (*domain.Even
 	return (*domain.Event)(&event), nil
 }
 
-func (r *SQLEventRepo) UpdateInRange(
+func (r *SQLEventRepo) GetAllByUserId(
 	ctx context.Context,
 	userId int64,
-	state string,
-	start, end time.Time,
-) error {
-	err := r.r.UpdateEventsRange(
-		ctx,
-		repo.UpdateEventsRangeParams{
-			UserID:        userId,
-			State:         state,
-			ScheduledAt:   start,
-			ScheduledAt_2: end,
-		},
-	)
+) ([]domain.Event, error) {
+	e, err := r.r.GetEventsByUserId(ctx, userId)
 	if err != nil {
 		r.log.Error(
 			"UpdateEventsRange failed",
 			slog.String("error", err.Error()),
 		)
-		return err
+		return nil, err
 	}
 
-	return nil
+	events := make([]domain.Event, len(e))
+	for i, event := range e {
+		events[i] = (domain.Event)(event)
+	}
+
+	return events, nil
 }
-func (r *SQLEventRepo) GetAllByUserId(
+
+func (r *SQLEventRepo) GetAllInRange(
 	ctx context.Context,
-	userId int64,
+	start, end time.Time,
 ) ([]domain.Event, error) {
-	e, err := r.r.GetEventsByUserId(ctx, userId)
+	params := repo.GetEventsInRangeParams{ScheduledAt: start, ScheduledAt_2: end}
+	e, err := r.r.GetEventsInRange(ctx, params)
 	if err != nil {
 		r.log.Error(
-			"UpdateEventsRange failed",
+			"GetEventsInRange failed",
 			slog.String("error", err.Error()),
 		)
 		return nil, err
@@ -369,3 +405,89 @@ func (r *SQLEventRepo) GetAllByUserId(
 
 	return events, nil
 }
+
+func (r *SQLEventRepo) GetAbsencesInRange(
+	ctx context.Context,
+	start, end time.Time,
+) ([]domain.Event, error) {
+	params := repo.GetAbsenceEventsInRangeParams{ScheduledAt: start, ScheduledAt_2: end}
+	e, err := r.r.GetAbsenceEventsInRange(ctx, params)
+	if err != nil {
+		r.log.Error(
+			"GetAbsenceEventsInRange failed",
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	events := make([]domain.Event, len(e))
+	for i, event := range e {
+		events[i] = (domain.Event)(event)
+	}
+
+	return events, nil
+}
+
+func (r *SQLEventRepo) GetHolidays(
+	ctx context.Context,
+	botName string,
+	region domain.Region,
+	start, end time.Time,
+) ([]domain.Holiday, error) {
+	reg := string(region)
+	h, err := r.r.GetHolidaysForRegion(
+		ctx,
+		repo.GetHolidaysForRegionParams{
+			Username:      botName,
+			Region:        &reg,
+			ScheduledAt:   start,
+			ScheduledAt_2: end,
+		},
+	)
+	if err != nil {
+		r.log.Error(
+			"GetHolidaysForRegion failed",
+			slog.String("region", reg),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	holidays := make([]domain.Holiday, len(h))
+	for i, holiday := range h {
+		weight := 1.0
+		if holiday.HalfDay {
+			weight = 0.5
+		}
+		holidays[i] = domain.Holiday{
+			Name:             holiday.Name,
+			Date:             holiday.ScheduledAt,
+			Weight:           weight,
+			ConsumesVacation: holiday.ConsumesVacation != nil && *holiday.ConsumesVacation,
+		}
+	}
+
+	return holidays, nil
+}
+
+func (r *SQLEventRepo) TagLegacyHolidays(
+	ctx context.Context,
+	botName string,
+	region domain.Region,
+) error {
+	reg := string(region)
+	err := r.r.UpdateLegacyHolidayRegion(
+		ctx,
+		repo.UpdateLegacyHolidayRegionParams{Region: &reg, Username: botName},
+	)
+	if err != nil {
+		r.log.Error(
+			"UpdateLegacyHolidayRegion failed",
+			slog.String("region", reg),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
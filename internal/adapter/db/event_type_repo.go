@@ -0,0 +1,129 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+
+	"chrono/db/repo"
+	"chrono/internal/domain"
+)
+
+type SQLEventTypeRepo struct {
+	q   repo.Querier
+	log *slog.Logger
+}
+
+func NewSQLEventTypeRepo(q repo.Querier, log *slog.Logger) domain.EventTypeRepository {
+	return &SQLEventTypeRepo{q: q, log: log}
+}
+
+func (r *SQLEventTypeRepo) Create(
+	ctx context.Context,
+	t *domain.EventType,
+) (*domain.EventType, error) {
+	params := repo.CreateEventTypeParams{
+		Name:             t.Name,
+		Label:            t.Label,
+		Weight:           t.Weight,
+		ConsumesVacation: t.ConsumesVacation,
+		NeedsApproval:    t.NeedsApproval,
+		CountsAsWorked:   t.CountsAsWorked,
+		Color:            t.Color,
+		ConsumesOvertime: t.ConsumesOvertime,
+	}
+	et, err := r.q.CreateEventType(ctx, params)
+	if err != nil {
+		r.log.Error(
+			"CreateEventType failed",
+			slog.String("name", t.Name),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.EventType)(&et), nil
+}
+
+func (r *SQLEventTypeRepo) Update(
+	ctx context.Context,
+	t *domain.EventType,
+) (*domain.EventType, error) {
+	params := repo.UpdateEventTypeParams{
+		ID:               t.ID,
+		Label:            t.Label,
+		Weight:           t.Weight,
+		ConsumesVacation: t.ConsumesVacation,
+		NeedsApproval:    t.NeedsApproval,
+		CountsAsWorked:   t.CountsAsWorked,
+		Color:            t.Color,
+		ConsumesOvertime: t.ConsumesOvertime,
+	}
+	et, err := r.q.UpdateEventType(ctx, params)
+	if err != nil {
+		r.log.Error(
+			"UpdateEventType failed",
+			slog.Int64("id", t.ID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.EventType)(&et), nil
+}
+
+func (r *SQLEventTypeRepo) Delete(ctx context.Context, id int64) error {
+	err := r.q.DeleteEventType(ctx, id)
+	if err != nil {
+		r.log.Error(
+			"DeleteEventType failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (r *SQLEventTypeRepo) GetById(ctx context.Context, id int64) (*domain.EventType, error) {
+	et, err := r.q.GetEventTypeById(ctx, id)
+	if err != nil {
+		r.log.Error(
+			"GetEventTypeById failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.EventType)(&et), nil
+}
+
+func (r *SQLEventTypeRepo) GetByName(ctx context.Context, name string) (*domain.EventType, error) {
+	et, err := r.q.GetEventTypeByName(ctx, name)
+	if err != nil {
+		r.log.Error(
+			"GetEventTypeByName failed",
+			slog.String("name", name),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.EventType)(&et), nil
+}
+
+func (r *SQLEventTypeRepo) GetAll(ctx context.Context) ([]domain.EventType, error) {
+	et, err := r.q.GetAllEventTypes(ctx)
+	if err != nil {
+		r.log.Error("GetAllEventTypes failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	types := make([]domain.EventType, len(et))
+	for i := range et {
+		types[i] = (domain.EventType)(et[i])
+	}
+
+	return types, nil
+}
@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"chrono/db/repo"
+	"chrono/internal/domain"
+)
+
+type SQLIdempotencyKeyRepo struct {
+	q   repo.Querier
+	log *slog.Logger
+}
+
+func NewSQLIdempotencyKeyRepo(q repo.Querier, log *slog.Logger) domain.IdempotencyKeyRepository {
+	return &SQLIdempotencyKeyRepo{q: q, log: log}
+}
+
+func (r *SQLIdempotencyKeyRepo) Get(
+	ctx context.Context,
+	userId int64,
+	key string,
+	since time.Time,
+) (*domain.IdempotencyKey, error) {
+	k, err := r.q.GetIdempotencyKey(ctx, repo.GetIdempotencyKeyParams{
+		UserID:    userId,
+		Key:       key,
+		CreatedAt: since,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.Error(
+			"GetIdempotencyKey failed",
+			slog.Int64("userId", userId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.IdempotencyKey)(&k), nil
+}
+
+func (r *SQLIdempotencyKeyRepo) Save(
+	ctx context.Context,
+	k *domain.IdempotencyKey,
+) (*domain.IdempotencyKey, error) {
+	saved, err := r.q.SaveIdempotencyKey(ctx, repo.SaveIdempotencyKeyParams{
+		UserID:      k.UserID,
+		Key:         k.Key,
+		Action:      k.Action,
+		TimestampID: k.TimestampID,
+	})
+	if err != nil {
+		r.log.Error(
+			"SaveIdempotencyKey failed",
+			slog.Int64("userId", k.UserID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.IdempotencyKey)(&saved), nil
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+
+	"chrono/db/repo"
+	"chrono/internal/domain"
+)
+
+type SQLJobRunRepo struct {
+	q   repo.Querier
+	log *slog.Logger
+}
+
+func NewSQLJobRunRepo(q repo.Querier, log *slog.Logger) domain.JobRunRepository {
+	return &SQLJobRunRepo{q: q, log: log}
+}
+
+func (r *SQLJobRunRepo) Start(ctx context.Context, job string) (*domain.JobRun, error) {
+	run, err := r.q.CreateJobRun(ctx, job)
+	if err != nil {
+		r.log.Error(
+			"CreateJobRun failed",
+			slog.String("job", job),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.JobRun)(&run), nil
+}
+
+func (r *SQLJobRunRepo) Finish(
+	ctx context.Context,
+	id int64,
+	jobErr error,
+) (*domain.JobRun, error) {
+	var msg *string
+	if jobErr != nil {
+		e := jobErr.Error()
+		msg = &e
+	}
+
+	run, err := r.q.FinishJobRun(ctx, repo.FinishJobRunParams{Error: msg, ID: id})
+	if err != nil {
+		r.log.Error(
+			"FinishJobRun failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.JobRun)(&run), nil
+}
+
+func (r *SQLJobRunRepo) GetLast(ctx context.Context, job string) (*domain.JobRun, error) {
+	run, err := r.q.GetLastJobRun(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+
+	return (*domain.JobRun)(&run), nil
+}
+
+func (r *SQLJobRunRepo) GetLastForAll(ctx context.Context) ([]domain.JobRun, error) {
+	j, err := r.q.GetLastJobRuns(ctx)
+	if err != nil {
+		r.log.Error("GetLastJobRuns failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	runs := make([]domain.JobRun, len(j))
+	for i := range j {
+		runs[i] = (domain.JobRun)(j[i])
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,183 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"chrono/db/repo"
+	"chrono/internal/domain"
+)
+
+type SQLOvertimeRepo struct {
+	q   repo.Querier
+	log *slog.Logger
+}
+
+func NewSQLOvertimeRepo(q repo.Querier, log *slog.Logger) domain.OvertimeRepository {
+	return &SQLOvertimeRepo{q: q, log: log}
+}
+
+func (r *SQLOvertimeRepo) CreateBooking(
+	ctx context.Context,
+	b *domain.OvertimeBooking,
+) (*domain.OvertimeBooking, error) {
+	created, err := r.q.CreateOvertimeBooking(ctx, repo.CreateOvertimeBookingParams{
+		UserID:    b.UserID,
+		Date:      b.Date,
+		Kind:      b.Kind,
+		Hours:     b.Hours,
+		Reason:    b.Reason,
+		CreatedBy: b.CreatedBy,
+	})
+	if err != nil {
+		r.log.Error(
+			"CreateOvertimeBooking failed",
+			slog.Int64("userId", b.UserID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.OvertimeBooking)(&created), nil
+}
+
+func (r *SQLOvertimeRepo) GetBookings(
+	ctx context.Context,
+	userId int64,
+) ([]domain.OvertimeBooking, error) {
+	b, err := r.q.GetOvertimeBookingsForUser(ctx, userId)
+	if err != nil {
+		r.log.Error(
+			"GetOvertimeBookingsForUser failed",
+			slog.Int64("userId", userId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return bookingsToDomain(b), nil
+}
+
+func (r *SQLOvertimeRepo) GetBookingsInRange(
+	ctx context.Context,
+	userId int64,
+	start time.Time,
+	end time.Time,
+) ([]domain.OvertimeBooking, error) {
+	b, err := r.q.GetOvertimeBookingsInRange(ctx, repo.GetOvertimeBookingsInRangeParams{
+		UserID:    userId,
+		StartDate: start,
+		EndDate:   end,
+	})
+	if err != nil {
+		r.log.Error(
+			"GetOvertimeBookingsInRange failed",
+			slog.Int64("userId", userId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return bookingsToDomain(b), nil
+}
+
+func (r *SQLOvertimeRepo) GetOpening(
+	ctx context.Context,
+	userId int64,
+) (*domain.OvertimeBooking, error) {
+	b, err := r.q.GetOpeningOvertimeBooking(ctx, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.Error(
+			"GetOpeningOvertimeBooking failed",
+			slog.Int64("userId", userId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.OvertimeBooking)(&b), nil
+}
+
+func (r *SQLOvertimeRepo) CreateSnapshot(
+	ctx context.Context,
+	s *domain.OvertimeSnapshot,
+) (*domain.OvertimeSnapshot, error) {
+	created, err := r.q.CreateOvertimeSnapshot(ctx, repo.CreateOvertimeSnapshotParams{
+		UserID:   s.UserID,
+		Month:    s.Month,
+		Worked:   s.Worked,
+		Expected: s.Expected,
+		Booked:   s.Booked,
+		Balance:  s.Balance,
+		TimeOff:  s.TimeOff,
+	})
+	if err != nil {
+		r.log.Error(
+			"CreateOvertimeSnapshot failed",
+			slog.Int64("userId", s.UserID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.OvertimeSnapshot)(&created), nil
+}
+
+func (r *SQLOvertimeRepo) GetSnapshots(
+	ctx context.Context,
+	userId int64,
+) ([]domain.OvertimeSnapshot, error) {
+	s, err := r.q.GetOvertimeSnapshotsForUser(ctx, userId)
+	if err != nil {
+		r.log.Error(
+			"GetOvertimeSnapshotsForUser failed",
+			slog.Int64("userId", userId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	snapshots := make([]domain.OvertimeSnapshot, len(s))
+	for i := range s {
+		snapshots[i] = (domain.OvertimeSnapshot)(s[i])
+	}
+	return snapshots, nil
+}
+
+func (r *SQLOvertimeRepo) GetLastSnapshotBefore(
+	ctx context.Context,
+	userId int64,
+	month time.Time,
+) (*domain.OvertimeSnapshot, error) {
+	s, err := r.q.GetLastOvertimeSnapshotBefore(ctx, repo.GetLastOvertimeSnapshotBeforeParams{
+		UserID: userId,
+		Month:  month,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.Error(
+			"GetLastOvertimeSnapshotBefore failed",
+			slog.Int64("userId", userId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.OvertimeSnapshot)(&s), nil
+}
+
+func bookingsToDomain(b []repo.OvertimeBooking) []domain.OvertimeBooking {
+	bookings := make([]domain.OvertimeBooking, len(b))
+	for i := range b {
+		bookings[i] = (domain.OvertimeBooking)(b[i])
+	}
+	return bookings
+}
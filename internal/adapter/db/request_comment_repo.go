@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+
+	"chrono/db/repo"
+	"chrono/internal/domain"
+)
+
+type SQLRequestCommentRepo struct {
+	q   repo.Querier
+	log *slog.Logger
+}
+
+func NewSQLRequestCommentRepo(q repo.Querier, log *slog.Logger) domain.RequestCommentRepository {
+	return &SQLRequestCommentRepo{q: q, log: log}
+}
+
+func (r *SQLRequestCommentRepo) Create(
+	ctx context.Context,
+	c *domain.RequestComment,
+) (*domain.RequestComment, error) {
+	comment, err := r.q.CreateRequestComment(ctx, repo.CreateRequestCommentParams{
+		RequestID: c.RequestID,
+		UserID:    c.UserID,
+		Kind:      c.Kind,
+		Body:      c.Body,
+	})
+	if err != nil {
+		r.log.Error(
+			"CreateRequestComment failed",
+			slog.Int64("request_id", c.RequestID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return &domain.RequestComment{
+		ID:        comment.ID,
+		RequestID: comment.RequestID,
+		UserID:    comment.UserID,
+		Kind:      comment.Kind,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt,
+		Username:  c.Username,
+	}, nil
+}
+
+func (r *SQLRequestCommentRepo) GetForRequest(
+	ctx context.Context,
+	requestId int64,
+) ([]domain.RequestComment, error) {
+	c, err := r.q.GetCommentsForRequest(ctx, requestId)
+	if err != nil {
+		r.log.Error(
+			"GetCommentsForRequest failed",
+			slog.Int64("request_id", requestId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	comments := make([]domain.RequestComment, len(c))
+	for i := range c {
+		comments[i] = (domain.RequestComment)(c[i])
+	}
+
+	return comments, nil
+}
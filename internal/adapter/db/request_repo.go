@@ -18,33 +18,6 @@ func NewSQLRequestRepo(r repo.Querier, log *slog.Logger) domain.RequestRepositor
 	return &SQLRequestRepo{r: r, log: log}
 }
 
-func (r *SQLRequestRepo) Create(
-	ctx context.Context,
-	msg string,
-	user *domain.User,
-	event *domain.Event,
-) (*domain.Request, error) {
-	params := repo.CreateRequestParams{
-		Message: &msg,
-		State:   "pending",
-		UserID:  user.ID,
-		EventID: event.ID,
-	}
-
-	request, err := r.r.CreateRequest(ctx, params)
-	if err != nil {
-		r.log.Error(
-			"CreateRequest failed",
-			slog.String("username", user.Username),
-			slog.String("event", event.Name),
-			slog.String("error", err.Error()),
-		)
-		return nil, err
-	}
-
-	return (*domain.Request)(&request), nil
-}
-
 func (r *SQLRequestRepo) Update(
 	ctx context.Context,
 	editor *domain.User,
@@ -67,7 +40,7 @@ func (r *SQLRequestRepo) Update(
 	return (*domain.Request)(&request), nil
 }
 
-func (r *SQLRequestRepo) GetPending(ctx context.Context) ([]domain.RequestEventUser, error) {
+func (r *SQLRequestRepo) GetPending(ctx context.Context) ([]domain.RequestAbsenceUser, error) {
 	result, err := r.r.GetPendingRequests(ctx)
 	if err != nil {
 		r.log.Error(
@@ -77,9 +50,9 @@ func (r *SQLRequestRepo) GetPending(ctx context.Context) ([]domain.RequestEventU
 		return nil, err
 	}
 
-	requests := make([]domain.RequestEventUser, len(result))
+	requests := make([]domain.RequestAbsenceUser, len(result))
 	for i := range result {
-		requests[i] = (domain.RequestEventUser)(result[i])
+		requests[i] = (domain.RequestAbsenceUser)(result[i])
 	}
 
 	return requests, nil
@@ -121,27 +94,58 @@ func (r *SQLRequestRepo) GetInRange(
 	return events, nil
 }
 
-func (r *SQLRequestRepo) UpdateInRange(
-	ctx context.Context,
-	state string,
-	editor, reqUserId int64,
-	start, end time.Time,
-) (int64, error) {
-	params := repo.UpdateRequestStateRangeParams{
-		UserID:        reqUserId,
-		EditedBy:      &editor,
-		State:         state,
-		ScheduledAt:   start,
-		ScheduledAt_2: end,
+func (r *SQLRequestRepo) GetById(ctx context.Context, id int64) (*domain.Request, error) {
+	request, err := r.r.GetRequestById(ctx, id)
+	if err != nil {
+		r.log.Error(
+			"GetRequestById failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
 	}
 
-	reqId, err := r.r.UpdateRequestStateRange(ctx, params)
+	return (*domain.Request)(&request), nil
+}
+
+func (r *SQLRequestRepo) GetByAbsenceId(ctx context.Context, absenceId int64) (*domain.Request, error) {
+	request, err := r.r.GetRequestByAbsenceId(ctx, &absenceId)
+	if err != nil {
+		r.log.Error(
+			"GetRequestByAbsenceId failed",
+			slog.Int64("absence_id", absenceId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.Request)(&request), nil
+}
+
+func (r *SQLRequestRepo) MarkReminded(ctx context.Context, id int64, at time.Time) error {
+	err := r.r.UpdateRequestReminder(ctx, repo.UpdateRequestReminderParams{LastRemindedAt: &at, ID: id})
+	if err != nil {
+		r.log.Error(
+			"UpdateRequestReminder failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (r *SQLRequestRepo) MarkEscalated(ctx context.Context, id int64, at time.Time) error {
+	err := r.r.UpdateRequestEscalation(ctx, repo.UpdateRequestEscalationParams{EscalatedAt: &at, ID: id})
 	if err != nil {
 		r.log.Error(
-			"UpdateRequestStateRange failed",
+			"UpdateRequestEscalation failed",
+			slog.Int64("id", id),
 			slog.String("error", err.Error()),
 		)
+		return err
 	}
 
-	return reqId, nil
+	return nil
 }
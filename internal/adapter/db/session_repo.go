@@ -51,6 +51,16 @@ func (r *SQLSessionRepo) DeleteAll(ctx context.Context) error {
 	return nil
 }
 
+func (r *SQLSessionRepo) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	n, err := r.q.DeleteExpiredSessions(ctx, before)
+	if err != nil {
+		r.log.Error("DeleteExpiredSessions failed", slog.String("error", err.Error()))
+		return 0, err
+	}
+
+	return n, nil
+}
+
 func (r *SQLSessionRepo) GetById(ctx context.Context, cookie string) (*domain.Session, error) {
 	session, err := r.q.GetSessionById(ctx, cookie)
 	if err != nil {
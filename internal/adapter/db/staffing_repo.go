@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"chrono/db/repo"
+	"chrono/internal/domain"
+)
+
+type SQLStaffingRepo struct {
+	q   repo.Querier
+	log *slog.Logger
+}
+
+func NewSQLStaffingRepo(q repo.Querier, log *slog.Logger) domain.StaffingRepository {
+	return &SQLStaffingRepo{q: q, log: log}
+}
+
+func (r *SQLStaffingRepo) CreateRule(
+	ctx context.Context,
+	s *domain.StaffingRule,
+) (*domain.StaffingRule, error) {
+	rule, err := r.q.CreateStaffingRule(ctx, repo.CreateStaffingRuleParams{
+		TeamID:     s.TeamID,
+		MinPresent: s.MinPresent,
+		Severity:   s.Severity,
+	})
+	if err != nil {
+		r.log.Error("CreateStaffingRule failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return (*domain.StaffingRule)(&rule), nil
+}
+
+func (r *SQLStaffingRepo) DeleteRule(ctx context.Context, id int64) error {
+	err := r.q.DeleteStaffingRule(ctx, id)
+	if err != nil {
+		r.log.Error(
+			"DeleteStaffingRule failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (r *SQLStaffingRepo) GetRules(ctx context.Context) ([]domain.StaffingRule, error) {
+	s, err := r.q.GetAllStaffingRules(ctx)
+	if err != nil {
+		r.log.Error("GetAllStaffingRules failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	rules := make([]domain.StaffingRule, len(s))
+	for i := range s {
+		rules[i] = (domain.StaffingRule)(s[i])
+	}
+
+	return rules, nil
+}
+
+func (r *SQLStaffingRepo) CreateBlackout(
+	ctx context.Context,
+	b *domain.BlackoutPeriod,
+) (*domain.BlackoutPeriod, error) {
+	period, err := r.q.CreateBlackoutPeriod(ctx, repo.CreateBlackoutPeriodParams{
+		Name:      b.Name,
+		StartDate: b.StartDate,
+		EndDate:   b.EndDate,
+		TeamID:    b.TeamID,
+		Severity:  b.Severity,
+	})
+	if err != nil {
+		r.log.Error(
+			"CreateBlackoutPeriod failed",
+			slog.String("name", b.Name),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.BlackoutPeriod)(&period), nil
+}
+
+func (r *SQLStaffingRepo) DeleteBlackout(ctx context.Context, id int64) error {
+	err := r.q.DeleteBlackoutPeriod(ctx, id)
+	if err != nil {
+		r.log.Error(
+			"DeleteBlackoutPeriod failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (r *SQLStaffingRepo) GetBlackouts(ctx context.Context) ([]domain.BlackoutPeriod, error) {
+	b, err := r.q.GetAllBlackoutPeriods(ctx)
+	if err != nil {
+		r.log.Error("GetAllBlackoutPeriods failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return blackoutsToDomain(b), nil
+}
+
+func (r *SQLStaffingRepo) GetBlackoutsInRange(
+	ctx context.Context,
+	start, end time.Time,
+) ([]domain.BlackoutPeriod, error) {
+	params := repo.GetBlackoutPeriodsInRangeParams{EndDate: start, StartDate: end}
+	b, err := r.q.GetBlackoutPeriodsInRange(ctx, params)
+	if err != nil {
+		r.log.Error("GetBlackoutPeriodsInRange failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return blackoutsToDomain(b), nil
+}
+
+func blackoutsToDomain(b []repo.BlackoutPeriod) []domain.BlackoutPeriod {
+	periods := make([]domain.BlackoutPeriod, len(b))
+	for i := range b {
+		periods[i] = (domain.BlackoutPeriod)(b[i])
+	}
+
+	return periods
+}
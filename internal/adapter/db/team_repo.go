@@ -0,0 +1,133 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+
+	"chrono/db/repo"
+	"chrono/internal/domain"
+)
+
+type SQLTeamRepo struct {
+	q   repo.Querier
+	log *slog.Logger
+}
+
+func NewSQLTeamRepo(q repo.Querier, log *slog.Logger) domain.TeamRepository {
+	return &SQLTeamRepo{q: q, log: log}
+}
+
+func (r *SQLTeamRepo) Create(ctx context.Context, name string) (*domain.Team, error) {
+	team, err := r.q.CreateTeam(ctx, name)
+	if err != nil {
+		r.log.Error(
+			"CreateTeam failed",
+			slog.String("name", name),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.Team)(&team), nil
+}
+
+func (r *SQLTeamRepo) Update(ctx context.Context, t *domain.Team) (*domain.Team, error) {
+	team, err := r.q.UpdateTeam(ctx, repo.UpdateTeamParams{Name: t.Name, ID: t.ID})
+	if err != nil {
+		r.log.Error(
+			"UpdateTeam failed",
+			slog.Int64("id", t.ID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.Team)(&team), nil
+}
+
+func (r *SQLTeamRepo) Delete(ctx context.Context, id int64) error {
+	err := r.q.DeleteTeam(ctx, id)
+	if err != nil {
+		r.log.Error(
+			"DeleteTeam failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (r *SQLTeamRepo) GetById(ctx context.Context, id int64) (*domain.Team, error) {
+	team, err := r.q.GetTeamById(ctx, id)
+	if err != nil {
+		r.log.Error(
+			"GetTeamById failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.Team)(&team), nil
+}
+
+func (r *SQLTeamRepo) GetAll(ctx context.Context) ([]domain.Team, error) {
+	t, err := r.q.GetAllTeams(ctx)
+	if err != nil {
+		r.log.Error("GetAllTeams failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	teams := make([]domain.Team, len(t))
+	for i := range t {
+		teams[i] = (domain.Team)(t[i])
+	}
+
+	return teams, nil
+}
+
+func (r *SQLTeamRepo) SetMember(ctx context.Context, userId, teamId int64) error {
+	err := r.q.SetTeamMember(ctx, repo.SetTeamMemberParams{UserID: userId, TeamID: teamId})
+	if err != nil {
+		r.log.Error(
+			"SetTeamMember failed",
+			slog.Int64("userId", userId),
+			slog.Int64("teamId", teamId),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (r *SQLTeamRepo) DeleteMember(ctx context.Context, userId int64) error {
+	err := r.q.DeleteTeamMember(ctx, userId)
+	if err != nil {
+		r.log.Error(
+			"DeleteTeamMember failed",
+			slog.Int64("userId", userId),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (r *SQLTeamRepo) GetAllMembers(ctx context.Context) ([]domain.TeamMember, error) {
+	m, err := r.q.GetAllTeamMembers(ctx)
+	if err != nil {
+		r.log.Error("GetAllTeamMembers failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	members := make([]domain.TeamMember, len(m))
+	for i := range m {
+		members[i] = (domain.TeamMember)(m[i])
+	}
+
+	return members, nil
+}
@@ -0,0 +1,221 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"chrono/db/repo"
+	"chrono/internal/domain"
+)
+
+type SQLTimestampBreakRepo struct {
+	q   repo.Querier
+	log *slog.Logger
+}
+
+func NewSQLTimestampBreakRepo(q repo.Querier, log *slog.Logger) domain.TimestampBreakRepository {
+	return &SQLTimestampBreakRepo{q: q, log: log}
+}
+
+func (r *SQLTimestampBreakRepo) Create(
+	ctx context.Context,
+	b *domain.TimestampBreak,
+) (domain.TimestampBreak, error) {
+	created, err := r.q.CreateTimestampBreak(ctx, repo.CreateTimestampBreakParams{
+		TimestampID: b.TimestampID,
+		StartTime:   b.StartTime,
+		EndTime:     b.EndTime,
+	})
+	if err != nil {
+		r.log.Error(
+			"CreateTimestampBreak failed",
+			slog.Int64("timestampId", b.TimestampID),
+			slog.String("error", err.Error()),
+		)
+		return domain.TimestampBreak{}, err
+	}
+
+	return (domain.TimestampBreak)(created), nil
+}
+
+func (r *SQLTimestampBreakRepo) Start(
+	ctx context.Context,
+	timestampId int64,
+) (domain.TimestampBreak, error) {
+	b, err := r.q.StartTimestampBreak(ctx, timestampId)
+	if err != nil {
+		r.log.Error(
+			"StartTimestampBreak failed",
+			slog.Int64("timestampId", timestampId),
+			slog.String("error", err.Error()),
+		)
+		return domain.TimestampBreak{}, err
+	}
+
+	return (domain.TimestampBreak)(b), nil
+}
+
+func (r *SQLTimestampBreakRepo) Stop(
+	ctx context.Context,
+	timestampId int64,
+) (domain.TimestampBreak, error) {
+	b, err := r.q.StopTimestampBreak(ctx, timestampId)
+	if err != nil {
+		return domain.TimestampBreak{}, err
+	}
+
+	return (domain.TimestampBreak)(b), nil
+}
+
+func (r *SQLTimestampBreakRepo) EndOpen(
+	ctx context.Context,
+	timestampId int64,
+	end time.Time,
+) error {
+	err := r.q.DeleteTimestampBreaksFrom(ctx, repo.DeleteTimestampBreaksFromParams{
+		TimestampID: timestampId,
+		StartTime:   end,
+	})
+	if err != nil {
+		r.log.Error(
+			"DeleteTimestampBreaksFrom failed",
+			slog.Int64("timestampId", timestampId),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	err = r.q.EndOpenTimestampBreaks(ctx, repo.EndOpenTimestampBreaksParams{
+		EndTime:     &end,
+		TimestampID: timestampId,
+	})
+	if err != nil {
+		r.log.Error(
+			"EndOpenTimestampBreaks failed",
+			slog.Int64("timestampId", timestampId),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (r *SQLTimestampBreakRepo) Delete(ctx context.Context, id int64) error {
+	err := r.q.DeleteTimestampBreak(ctx, id)
+	if err != nil {
+		r.log.Error(
+			"DeleteTimestampBreak failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func (r *SQLTimestampBreakRepo) GetById(
+	ctx context.Context,
+	id int64,
+) (domain.TimestampBreak, error) {
+	b, err := r.q.GetTimestampBreakById(ctx, id)
+	if err != nil {
+		return domain.TimestampBreak{}, err
+	}
+
+	return (domain.TimestampBreak)(b), nil
+}
+
+func (r *SQLTimestampBreakRepo) GetForTimestamp(
+	ctx context.Context,
+	timestampId int64,
+) ([]domain.TimestampBreak, error) {
+	b, err := r.q.GetBreaksForTimestamp(ctx, timestampId)
+	if err != nil {
+		r.log.Error(
+			"GetBreaksForTimestamp failed",
+			slog.Int64("timestampId", timestampId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return breaksToDomain(b), nil
+}
+
+func (r *SQLTimestampBreakRepo) GetInRange(
+	ctx context.Context,
+	userId int64,
+	start time.Time,
+	stop time.Time,
+) ([]domain.TimestampBreak, error) {
+	b, err := r.q.GetTimestampBreaksInRange(ctx, repo.GetTimestampBreaksInRangeParams{
+		UserID:    userId,
+		EndTime:   stop,
+		StartTime: &start,
+	})
+	if err != nil {
+		r.log.Error(
+			"GetTimestampBreaksInRange failed",
+			slog.Int64("userId", userId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return breaksToDomain(b), nil
+}
+
+func (r *SQLTimestampBreakRepo) GetAllInRange(
+	ctx context.Context,
+	start time.Time,
+	stop time.Time,
+) ([]domain.TimestampBreak, error) {
+	b, err := r.q.GetAllTimestampBreaksInRange(ctx, repo.GetAllTimestampBreaksInRangeParams{
+		EndTime:   stop,
+		StartTime: &start,
+	})
+	if err != nil {
+		r.log.Error("GetAllTimestampBreaksInRange failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return breaksToDomain(b), nil
+}
+
+func (r *SQLTimestampBreakRepo) GetTotalSecondsInRange(
+	ctx context.Context,
+	userId int64,
+	start time.Time,
+	stop time.Time,
+) (float64, error) {
+	seconds, err := r.q.GetTotalBreakSecondsInRange(ctx, repo.GetTotalBreakSecondsInRangeParams{
+		RangeEnd:   stop,
+		RangeStart: start,
+		UserID:     userId,
+	})
+	if err != nil {
+		r.log.Error(
+			"GetTotalBreakSecondsInRange failed",
+			slog.Int64("userId", userId),
+			slog.String("error", err.Error()),
+		)
+		return 0, err
+	}
+
+	if seconds == nil {
+		return 0, nil
+	}
+
+	return *seconds, nil
+}
+
+func breaksToDomain(b []repo.TimestampBreak) []domain.TimestampBreak {
+	breaks := make([]domain.TimestampBreak, len(b))
+	for i := range b {
+		breaks[i] = (domain.TimestampBreak)(b[i])
+	}
+	return breaks
+}
@@ -0,0 +1,135 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"chrono/db/repo"
+	"chrono/internal/domain"
+)
+
+type SQLTimestampCorrectionRepo struct {
+	q   repo.Querier
+	log *slog.Logger
+}
+
+func NewSQLTimestampCorrectionRepo(
+	q repo.Querier,
+	log *slog.Logger,
+) domain.TimestampCorrectionRepository {
+	return &SQLTimestampCorrectionRepo{q: q, log: log}
+}
+
+func (r *SQLTimestampCorrectionRepo) Create(
+	ctx context.Context,
+	c *domain.TimestampCorrection,
+) (*domain.TimestampCorrection, error) {
+	created, err := r.q.CreateTimestampCorrection(ctx, repo.CreateTimestampCorrectionParams{
+		UserID:      c.UserID,
+		TimestampID: c.TimestampID,
+		Kind:        c.Kind,
+		StartTime:   c.StartTime,
+		EndTime:     c.EndTime,
+		Reason:      c.Reason,
+	})
+	if err != nil {
+		r.log.Error(
+			"CreateTimestampCorrection failed",
+			slog.Int64("userId", c.UserID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.TimestampCorrection)(&created), nil
+}
+
+func (r *SQLTimestampCorrectionRepo) Decide(
+	ctx context.Context,
+	c *domain.TimestampCorrection,
+	editorId int64,
+) (*domain.TimestampCorrection, error) {
+	decided, err := r.q.DecideTimestampCorrection(ctx, repo.DecideTimestampCorrectionParams{
+		State:       c.State,
+		TimestampID: c.TimestampID,
+		DecidedBy:   &editorId,
+		ID:          c.ID,
+	})
+	if err != nil {
+		r.log.Error(
+			"DecideTimestampCorrection failed",
+			slog.Int64("id", c.ID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.TimestampCorrection)(&decided), nil
+}
+
+func (r *SQLTimestampCorrectionRepo) GetById(
+	ctx context.Context,
+	id int64,
+) (*domain.TimestampCorrection, error) {
+	c, err := r.q.GetTimestampCorrectionById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return (*domain.TimestampCorrection)(&c), nil
+}
+
+func (r *SQLTimestampCorrectionRepo) GetForUser(
+	ctx context.Context,
+	userId int64,
+) ([]domain.TimestampCorrection, error) {
+	c, err := r.q.GetTimestampCorrectionsForUser(ctx, userId)
+	if err != nil {
+		r.log.Error(
+			"GetTimestampCorrectionsForUser failed",
+			slog.Int64("userId", userId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return correctionsToDomain(c), nil
+}
+
+func (r *SQLTimestampCorrectionRepo) GetPending(
+	ctx context.Context,
+) ([]domain.TimestampCorrection, error) {
+	c, err := r.q.GetPendingTimestampCorrections(ctx)
+	if err != nil {
+		r.log.Error("GetPendingTimestampCorrections failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return correctionsToDomain(c), nil
+}
+
+func (r *SQLTimestampCorrectionRepo) MarkReminded(ctx context.Context, id int64, at time.Time) error {
+	err := r.q.UpdateTimestampCorrectionReminder(
+		ctx,
+		repo.UpdateTimestampCorrectionReminderParams{LastRemindedAt: &at, ID: id},
+	)
+	if err != nil {
+		r.log.Error(
+			"UpdateTimestampCorrectionReminder failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
+
+func correctionsToDomain(c []repo.TimestampCorrection) []domain.TimestampCorrection {
+	corrections := make([]domain.TimestampCorrection, len(c))
+	for i := range c {
+		corrections[i] = (domain.TimestampCorrection)(c[i])
+	}
+	return corrections
+}
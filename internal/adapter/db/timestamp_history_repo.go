@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+
+	"chrono/db/repo"
+	"chrono/internal/domain"
+)
+
+type SQLTimestampHistoryRepo struct {
+	q   repo.Querier
+	log *slog.Logger
+}
+
+func NewSQLTimestampHistoryRepo(q repo.Querier, log *slog.Logger) domain.TimestampHistoryRepository {
+	return &SQLTimestampHistoryRepo{q: q, log: log}
+}
+
+func (r *SQLTimestampHistoryRepo) Create(
+	ctx context.Context,
+	h *domain.TimestampHistory,
+) (*domain.TimestampHistory, error) {
+	created, err := r.q.CreateTimestampHistory(ctx, repo.CreateTimestampHistoryParams{
+		TimestampID:  h.TimestampID,
+		UserID:       h.UserID,
+		CorrectionID: h.CorrectionID,
+		Kind:         h.Kind,
+		OldStartTime: h.OldStartTime,
+		OldEndTime:   h.OldEndTime,
+		NewStartTime: h.NewStartTime,
+		NewEndTime:   h.NewEndTime,
+		ChangedBy:    h.ChangedBy,
+	})
+	if err != nil {
+		r.log.Error(
+			"CreateTimestampHistory failed",
+			slog.Int64("timestampId", h.TimestampID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.TimestampHistory)(&created), nil
+}
+
+func (r *SQLTimestampHistoryRepo) GetForTimestamp(
+	ctx context.Context,
+	timestampId int64,
+) ([]domain.TimestampHistory, error) {
+	h, err := r.q.GetTimestampHistory(ctx, timestampId)
+	if err != nil {
+		r.log.Error(
+			"GetTimestampHistory failed",
+			slog.Int64("timestampId", timestampId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	history := make([]domain.TimestampHistory, len(h))
+	for i := range h {
+		history[i] = (domain.TimestampHistory)(h[i])
+	}
+	return history, nil
+}
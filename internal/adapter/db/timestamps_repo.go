@@ -2,6 +2,8 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"log/slog"
 	"time"
 
@@ -30,6 +32,10 @@ func (r *SQLTimestampsRepo) GetById(ctx context.Context, id int64) (domain.Times
 
 func (r *SQLTimestampsRepo) Start(ctx context.Context, userId int64) (domain.Timestamp, error) {
 	t, err := r.q.StartTimestamp(ctx, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		// the user already has a running timer
+		t, err = r.q.GetRunningTimestamp(ctx, userId)
+	}
 	if err != nil {
 		r.log.Error("repo.StartTimestamp failed:", slog.String("error", err.Error()))
 		return domain.Timestamp{}, err
@@ -38,6 +44,24 @@ func (r *SQLTimestampsRepo) Start(ctx context.Context, userId int64) (domain.Tim
 	return (domain.Timestamp)(t), nil
 }
 
+func (r *SQLTimestampsRepo) Create(
+	ctx context.Context,
+	ts *domain.Timestamp,
+) (domain.Timestamp, error) {
+	params := repo.CreateTimestampParams{
+		UserID:    ts.UserID,
+		StartTime: ts.StartTime,
+		EndTime:   ts.EndTime,
+	}
+	t, err := r.q.CreateTimestamp(ctx, params)
+	if err != nil {
+		r.log.Error("repo.CreateTimestamp failed:", slog.String("error", err.Error()))
+		return domain.Timestamp{}, err
+	}
+
+	return (domain.Timestamp)(t), nil
+}
+
 func (r *SQLTimestampsRepo) Stop(ctx context.Context, id int64) (domain.Timestamp, error) {
 	t, err := r.q.StopTimestamp(ctx, id)
 	if err != nil {
@@ -130,6 +154,19 @@ func (r *SQLTimestampsRepo) GetLatest(ctx context.Context, userId int64) (domain
 	return (domain.Timestamp)(t), nil
 }
 
+func (r *SQLTimestampsRepo) GetFirst(ctx context.Context, userId int64) (*domain.Timestamp, error) {
+	t, err := r.q.GetFirstTimestamp(ctx, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.Error("repo.GetFirstTimestamp failed:", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return (*domain.Timestamp)(&t), nil
+}
+
 func (r *SQLTimestampsRepo) Update(
 	ctx context.Context,
 	ts *domain.Timestamp,
@@ -161,3 +198,42 @@ func (r *SQLTimestampsRepo) GetAllForUser(
 
 	return timestamps, nil
 }
+
+func (r *SQLTimestampsRepo) GetOpenBefore(
+	ctx context.Context,
+	before time.Time,
+) ([]domain.Timestamp, error) {
+	t, err := r.q.GetOpenTimestampsBefore(ctx, before)
+	if err != nil {
+		r.log.Error("repo.GetOpenTimestampsBefore failed:", slog.String("error", err.Error()))
+		return []domain.Timestamp{}, err
+	}
+
+	timestamps := make([]domain.Timestamp, len(t))
+	for i, x := range t {
+		timestamps[i] = (domain.Timestamp)(x)
+	}
+
+	return timestamps, nil
+}
+
+func (r *SQLTimestampsRepo) AutoClose(
+	ctx context.Context,
+	id int64,
+	end time.Time,
+) (*domain.Timestamp, error) {
+	t, err := r.q.AutoCloseTimestamp(ctx, repo.AutoCloseTimestampParams{EndTime: &end, ID: id})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.Error(
+			"AutoCloseTimestamp failed",
+			slog.Int64("id", id),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return (*domain.Timestamp)(&t), nil
+}
@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"chrono/db/repo"
+	"chrono/internal/domain"
+)
+
+type txKey struct{}
+
+// txFrom returns the transaction of the unit of work running in ctx.
+func txFrom(ctx context.Context) (*sql.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*sql.Tx)
+	return tx, ok
+}
+
+type SQLUnitOfWork struct {
+	db  *sql.DB
+	log *slog.Logger
+}
+
+func NewSQLUnitOfWork(db *sql.DB, log *slog.Logger) domain.UnitOfWork {
+	return &SQLUnitOfWork{db: db, log: log}
+}
+
+func (u *SQLUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := txFrom(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := u.db.BeginTx(ctx, nil)
+	if err != nil {
+		u.log.Error("BeginTx failed", slog.String("error", err.Error()))
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		u.log.Error("Commit failed", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// txDB runs the statements on the transaction of the unit of work in their
+// context and on db outside of one, so the queries of every repository take
+// part in a running unit of work.
+type txDB struct {
+	db *sql.DB
+}
+
+// NewDBTX returns the connection the queries of the repositories run on.
+func NewDBTX(db *sql.DB) repo.DBTX {
+	return &txDB{db: db}
+}
+
+func (d *txDB) conn(ctx context.Context) repo.DBTX {
+	if tx, ok := txFrom(ctx); ok {
+		return tx
+	}
+	return d.db
+}
+
+func (d *txDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return d.conn(ctx).ExecContext(ctx, query, args...)
+}
+
+func (d *txDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return d.conn(ctx).PrepareContext(ctx, query)
+}
+
+func (d *txDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return d.conn(ctx).QueryContext(ctx, query, args...)
+}
+
+func (d *txDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return d.conn(ctx).QueryRowContext(ctx, query, args...)
+}
+
+// repoTx is the transaction of a repository method. Inside a unit of work it
+// is the transaction of the unit, which is then committed or rolled back by
+// the unit of work only.
+type repoTx struct {
+	*sql.Tx
+	owned bool
+}
+
+// beginTx starts the transaction of a repository method, or joins the
+// transaction of the unit of work in ctx.
+func beginTx(ctx context.Context, db *sql.DB, log *slog.Logger) (*repoTx, error) {
+	if tx, ok := txFrom(ctx); ok {
+		return &repoTx{Tx: tx}, nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Error("BeginTx failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return &repoTx{Tx: tx, owned: true}, nil
+}
+
+func (t *repoTx) Commit() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+func (t *repoTx) Rollback() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
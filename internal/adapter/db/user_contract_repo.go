@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"chrono/db/repo"
+	"chrono/internal/domain"
+)
+
+type SQLUserContractRepo struct {
+	db  *sql.DB
+	q   *repo.Queries
+	log *slog.Logger
+}
+
+func NewSQLUserContractRepo(db *sql.DB, log *slog.Logger) domain.UserContractRepository {
+	return &SQLUserContractRepo{db: db, q: repo.New(NewDBTX(db)), log: log}
+}
+
+func (r *SQLUserContractRepo) Save(
+	ctx context.Context,
+	contracts []domain.UserContract,
+) ([]domain.UserContract, error) {
+	tx, err := beginTx(ctx, r.db, r.log)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	q := r.q.WithTx(tx.Tx)
+
+	saved := make([]domain.UserContract, len(contracts))
+	for i, c := range contracts {
+		var contract repo.UserContract
+		if c.ID == 0 {
+			contract, err = q.CreateUserContract(ctx, repo.CreateUserContractParams{
+				ValidFrom:    c.ValidFrom,
+				ValidTo:      c.ValidTo,
+				WorkdayHours: c.WorkdayHours,
+				WorkdaysWeek: c.WorkdaysWeek,
+				VacationDays: c.VacationDays,
+				UserID:       c.UserID,
+				CreatedBy:    c.CreatedBy,
+				Schedule:     c.Schedule,
+			})
+			if err != nil {
+				r.log.Error(
+					"CreateUserContract failed",
+					slog.Int64("userId", c.UserID),
+					slog.String("error", err.Error()),
+				)
+				return nil, err
+			}
+		} else {
+			contract, err = q.UpdateUserContract(ctx, repo.UpdateUserContractParams{
+				ValidFrom:    c.ValidFrom,
+				ValidTo:      c.ValidTo,
+				WorkdayHours: c.WorkdayHours,
+				WorkdaysWeek: c.WorkdaysWeek,
+				VacationDays: c.VacationDays,
+				Schedule:     c.Schedule,
+				ID:           c.ID,
+			})
+			if err != nil {
+				r.log.Error(
+					"UpdateUserContract failed",
+					slog.Int64("id", c.ID),
+					slog.String("error", err.Error()),
+				)
+				return nil, err
+			}
+		}
+		saved[i] = (domain.UserContract)(contract)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+func (r *SQLUserContractRepo) GetForUser(
+	ctx context.Context,
+	userId int64,
+) ([]domain.UserContract, error) {
+	c, err := r.q.GetUserContractsForUser(ctx, userId)
+	if err != nil {
+		r.log.Error(
+			"GetUserContractsForUser failed",
+			slog.Int64("userId", userId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	contracts := make([]domain.UserContract, len(c))
+	for i := range c {
+		contracts[i] = (domain.UserContract)(c[i])
+	}
+
+	return contracts, nil
+}
+
+func (r *SQLUserContractRepo) GetAll(
+	ctx context.Context,
+) (map[int64][]domain.UserContract, error) {
+	c, err := r.q.GetAllUserContracts(ctx)
+	if err != nil {
+		r.log.Error("GetAllUserContracts failed", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	contracts := map[int64][]domain.UserContract{}
+	for i := range c {
+		contracts[c[i].UserID] = append(contracts[c[i].UserID], (domain.UserContract)(c[i]))
+	}
+
+	return contracts, nil
+}
@@ -59,6 +59,10 @@ func (r *SQLUserRepo) Update(ctx context.Context, user *domain.User) (*domain.Us
 			ID:           user.ID,
 			WorkdayHours: user.WorkdayHours,
 			WorkdaysWeek: user.WorkdaysWeek,
+			EntryDate:    user.EntryDate,
+			ExitDate:     user.ExitDate,
+			Schedule:     user.Schedule,
+			Region:       user.Region,
 		},
 	)
 	if err != nil {
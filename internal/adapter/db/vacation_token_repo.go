@@ -27,6 +27,11 @@ func (r *SQLVacationTokenRepo) Create(
 		Value:     t.Value,
 		StartDate: t.StartDate,
 		EndDate:   t.EndDate,
+		Reason:    string(t.Reason),
+		RequestID: t.RequestID,
+		EventID:   t.EventID,
+		CreatedBy: t.CreatedBy,
+		AbsenceID: t.AbsenceID,
 	}
 	token, err := r.q.CreateVacationToken(ctx, params)
 	if err != nil {
@@ -37,13 +42,57 @@ func (r *SQLVacationTokenRepo) Create(
 		return nil, err
 	}
 
-	return &domain.VacationToken{
-		ID:        token.ID,
-		StartDate: token.StartDate,
-		EndDate:   token.EndDate,
-		Value:     token.Value,
-		UserID:    token.UserID,
-	}, nil
+	vac := vacationTokenToDomain(token)
+	return &vac, nil
+}
+
+// createVacationTokens stores the tokens with q, which may be bound to a
+// transaction of another repository.
+func createVacationTokens(
+	ctx context.Context,
+	q *repo.Queries,
+	log *slog.Logger,
+	tokens []domain.CreateVacationToken,
+) error {
+	for _, t := range tokens {
+		_, err := q.CreateVacationToken(ctx, repo.CreateVacationTokenParams{
+			UserID:    t.UserID,
+			StartDate: t.StartDate,
+			EndDate:   t.EndDate,
+			Value:     t.Value,
+			Reason:    string(t.Reason),
+			RequestID: t.RequestID,
+			EventID:   t.EventID,
+			CreatedBy: t.CreatedBy,
+			AbsenceID: t.AbsenceID,
+		})
+		if err != nil {
+			log.Error(
+				"CreateVacationToken failed",
+				slog.Int64("user_id", t.UserID),
+				slog.String("error", err.Error()))
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+func vacationTokenToDomain(t repo.VacationToken) domain.VacationToken {
+	return domain.VacationToken{
+		ID:        t.ID,
+		StartDate: t.StartDate,
+		EndDate:   t.EndDate,
+		Value:     t.Value,
+		UserID:    t.UserID,
+		Reason:    domain.VacationReason(t.Reason),
+		RequestID: t.RequestID,
+		EventID:   t.EventID,
+		CreatedBy: t.CreatedBy,
+		CreatedAt: t.CreatedAt,
+		AbsenceID: t.AbsenceID,
+	}
 }
 
 func (r *SQLVacationTokenRepo) Delete(ctx context.Context, id int64) error {
@@ -95,3 +144,101 @@ func (r *SQLVacationTokenRepo) GetRemainingVacationForUser(
 
 	return *vac, nil
 }
+
+func (r *SQLVacationTokenRepo) GetForUser(
+	ctx context.Context,
+	userId int64,
+	year int,
+) ([]domain.VacationToken, error) {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	params := repo.GetVacationTokensForUserParams{
+		UserID:      userId,
+		StartDate:   start,
+		StartDate_2: start.AddDate(1, 0, 0),
+	}
+	t, err := r.q.GetVacationTokensForUser(ctx, params)
+	if err != nil {
+		r.log.Error(
+			"GetVacationTokensForUser failed",
+			slog.Int64("user_id", userId),
+			slog.Int("year", year),
+			slog.String("error", err.Error()))
+
+		return nil, err
+	}
+
+	tokens := make([]domain.VacationToken, len(t))
+	for i := range t {
+		tokens[i] = vacationTokenToDomain(t[i])
+	}
+
+	return tokens, nil
+}
+
+func (r *SQLVacationTokenRepo) GetForAbsence(
+	ctx context.Context,
+	absenceId int64,
+) ([]domain.VacationToken, error) {
+	t, err := r.q.GetVacationTokensForAbsence(ctx, &absenceId)
+	if err != nil {
+		r.log.Error(
+			"GetVacationTokensForAbsence failed",
+			slog.Int64("absence_id", absenceId),
+			slog.String("error", err.Error()))
+
+		return nil, err
+	}
+
+	tokens := make([]domain.VacationToken, len(t))
+	for i := range t {
+		tokens[i] = vacationTokenToDomain(t[i])
+	}
+
+	return tokens, nil
+}
+
+func (r *SQLVacationTokenRepo) GetForEvent(
+	ctx context.Context,
+	eventId int64,
+) ([]domain.VacationToken, error) {
+	t, err := r.q.GetVacationTokensForEvent(ctx, &eventId)
+	if err != nil {
+		r.log.Error(
+			"GetVacationTokensForEvent failed",
+			slog.Int64("event_id", eventId),
+			slog.String("error", err.Error()))
+
+		return nil, err
+	}
+
+	tokens := make([]domain.VacationToken, len(t))
+	for i := range t {
+		tokens[i] = vacationTokenToDomain(t[i])
+	}
+
+	return tokens, nil
+}
+
+func (r *SQLVacationTokenRepo) GetPots(
+	ctx context.Context,
+	userId int64,
+	start, end time.Time,
+) ([]domain.VacationPot, error) {
+	params := repo.GetVacationPotsForUserParams{UserID: userId, StartDate: end, EndDate: start}
+	p, err := r.q.GetVacationPotsForUser(ctx, params)
+	if err != nil {
+		r.log.Error(
+			"GetVacationPotsForUser failed",
+			slog.Int64("user_id", userId),
+			slog.String("error", err.Error()))
+
+		return nil, err
+	}
+
+	pots := make([]domain.VacationPot, len(p))
+	for i := range p {
+		pots[i] = (domain.VacationPot)(p[i])
+	}
+
+	return pots, nil
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"chrono/db/repo"
+	"chrono/internal/domain"
+)
+
+type SQLYearClosingRepo struct {
+	db  *sql.DB
+	q   *repo.Queries
+	log *slog.Logger
+}
+
+func NewSQLYearClosingRepo(db *sql.DB, log *slog.Logger) domain.YearClosingRepository {
+	return &SQLYearClosingRepo{db: db, q: repo.New(NewDBTX(db)), log: log}
+}
+
+func (r *SQLYearClosingRepo) Close(
+	ctx context.Context,
+	year int,
+	closedBy *int64,
+	tokens []domain.CreateVacationToken,
+) (*domain.YearClosing, error) {
+	tx, err := beginTx(ctx, r.db, r.log)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	q := r.q.WithTx(tx.Tx)
+
+	closing, err := q.CreateYearClosing(
+		ctx,
+		repo.CreateYearClosingParams{Year: int64(year), ClosedBy: closedBy},
+	)
+	if err != nil {
+		r.log.Error(
+			"CreateYearClosing failed",
+			slog.Int("year", year),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	err = createVacationTokens(ctx, q, r.log, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &domain.YearClosing{
+		Year:      int(closing.Year),
+		CreatedAt: closing.CreatedAt,
+		ClosedBy:  closing.ClosedBy,
+	}, nil
+}
+
+func (r *SQLYearClosingRepo) GetByYear(ctx context.Context, year int) (*domain.YearClosing, error) {
+	closing, err := r.q.GetYearClosing(ctx, int64(year))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		r.log.Error(
+			"GetYearClosing failed",
+			slog.Int("year", year),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return &domain.YearClosing{
+		Year:      int(closing.Year),
+		CreatedAt: closing.CreatedAt,
+		ClosedBy:  closing.ClosedBy,
+	}, nil
+}
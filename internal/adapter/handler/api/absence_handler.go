@@ -0,0 +1,116 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"chrono/internal/domain"
+	"chrono/internal/service"
+)
+
+type APIAbsenceHandler struct {
+	absence *service.AbsenceService
+	log     *slog.Logger
+}
+
+func NewAPIAbsenceHandler(a *service.AbsenceService, log *slog.Logger) APIAbsenceHandler {
+	return APIAbsenceHandler{absence: a, log: log}
+}
+
+func (h *APIAbsenceHandler) RegisterRoutes(group *echo.Group) {
+	group.POST("/absences", h.CreateAbsence)
+	group.GET("/absences/quote", h.QuoteAbsence)
+	group.GET("/absences/:id", h.GetAbsence)
+	group.DELETE("/absences/:id", h.DeleteAbsence)
+}
+
+func (h *APIAbsenceHandler) CreateAbsence(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+
+	var form domain.CreateAbsence
+	if err := c.Bind(&form); err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid parameters")
+	}
+
+	absence, warnings, err := h.absence.Create(c.Request().Context(), form, &currUser)
+	var ruleErr *domain.RuleError
+	if errors.As(err, &ruleErr) {
+		return NewErrorDataResponse(c, http.StatusConflict, err.Error(), ruleErr.Violations)
+	}
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	response := struct {
+		*domain.Absence
+		Warnings []domain.RuleViolation `json:"warnings"`
+	}{absence, warnings}
+
+	return NewJsonResponse(c, response)
+}
+
+func (h *APIAbsenceHandler) QuoteAbsence(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+
+	var form domain.AbsenceQuoteForm
+	if err := c.Bind(&form); err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid parameters")
+	}
+
+	quote, err := h.absence.Quote(c.Request().Context(), form, &currUser)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, quote)
+}
+
+func (h *APIAbsenceHandler) GetAbsence(c echo.Context) error {
+	ctx := c.Request().Context()
+	currUser := c.Get("user").(domain.User)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid absence id")
+	}
+
+	absence, err := h.absence.GetById(ctx, id)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusNotFound, "absence not found")
+	}
+	if !currUser.IsAdmin() && currUser.ID != absence.UserID {
+		return NewErrorResponse(c, http.StatusNotFound, "absence not found")
+	}
+
+	events, err := h.absence.GetEvents(ctx, id)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "Failed to get absence days.")
+	}
+
+	response := map[string]any{
+		"absence": absence,
+		"events":  events,
+	}
+
+	return NewJsonResponse(c, response)
+}
+
+func (h *APIAbsenceHandler) DeleteAbsence(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid absence id")
+	}
+
+	_, err = h.absence.Delete(c.Request().Context(), id, &currUser)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, nil)
+}
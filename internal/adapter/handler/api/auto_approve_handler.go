@@ -0,0 +1,65 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"chrono/internal/domain"
+	"chrono/internal/service"
+)
+
+type APIAutoApproveHandler struct {
+	policy *service.AutoApproveService
+	log    *slog.Logger
+}
+
+func NewAPIAutoApproveHandler(p *service.AutoApproveService, log *slog.Logger) APIAutoApproveHandler {
+	return APIAutoApproveHandler{policy: p, log: log}
+}
+
+func (h *APIAutoApproveHandler) RegisterRoutes(group *echo.Group) {
+	p := group.Group("/auto-approve-policies")
+	p.GET("", h.GetPolicies)
+	p.POST("", h.CreatePolicy)
+	p.DELETE("/:id", h.DeletePolicy)
+}
+
+func (h *APIAutoApproveHandler) GetPolicies(c echo.Context) error {
+	policies, err := h.policy.GetAll(c.Request().Context())
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "Failed to get auto approve policies.")
+	}
+
+	return NewJsonResponse(c, policies)
+}
+
+func (h *APIAutoApproveHandler) CreatePolicy(c echo.Context) error {
+	var form domain.CreateAutoApprovePolicy
+	if err := c.Bind(&form); err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid parameters")
+	}
+
+	policy, err := h.policy.Create(c.Request().Context(), form)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, policy)
+}
+
+func (h *APIAutoApproveHandler) DeletePolicy(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid policy id")
+	}
+
+	err = h.policy.Delete(c.Request().Context(), id)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "Failed to delete auto approve policy.")
+	}
+
+	return NewJsonResponse(c, nil)
+}
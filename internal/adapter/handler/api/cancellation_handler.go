@@ -0,0 +1,111 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"chrono/internal/domain"
+	"chrono/internal/service"
+)
+
+type APICancellationHandler struct {
+	cancellation *service.CancellationService
+	absence      *service.AbsenceService
+	log          *slog.Logger
+}
+
+func NewAPICancellationHandler(
+	c *service.CancellationService,
+	a *service.AbsenceService,
+	log *slog.Logger,
+) APICancellationHandler {
+	return APICancellationHandler{cancellation: c, absence: a, log: log}
+}
+
+func (h *APICancellationHandler) RegisterRoutes(group *echo.Group) {
+	group.POST("/absences/:id/cancellations", h.CreateCancellation)
+	group.GET("/absences/:id/cancellations", h.GetAbsenceCancellations)
+	group.GET("/cancellations", h.GetPendingCancellations)
+	group.PATCH("/cancellations/:id", h.UpdateCancellation)
+}
+
+func (h *APICancellationHandler) CreateCancellation(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid absence id")
+	}
+
+	var form domain.CreateCancellation
+	if err := c.Bind(&form); err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid parameters")
+	}
+
+	cancellation, err := h.cancellation.Create(c.Request().Context(), id, form, &currUser)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, cancellation)
+}
+
+func (h *APICancellationHandler) GetAbsenceCancellations(c echo.Context) error {
+	ctx := c.Request().Context()
+	currUser := c.Get("user").(domain.User)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid absence id")
+	}
+
+	absence, err := h.absence.GetById(ctx, id)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusNotFound, "absence not found")
+	}
+	if !currUser.IsAdmin() && currUser.ID != absence.UserID {
+		return NewErrorResponse(c, http.StatusNotFound, "absence not found")
+	}
+
+	cancellations, err := h.cancellation.GetForAbsence(ctx, id)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "failed to get cancellations")
+	}
+
+	return NewJsonResponse(c, cancellations)
+}
+
+func (h *APICancellationHandler) GetPendingCancellations(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+
+	cancellations, err := h.cancellation.GetPending(c.Request().Context(), &currUser)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "failed to get cancellations")
+	}
+
+	return NewJsonResponse(c, cancellations)
+}
+
+func (h *APICancellationHandler) UpdateCancellation(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid cancellation id")
+	}
+
+	var form domain.UpdateCancellation
+	if err := c.Bind(&form); err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid parameters")
+	}
+
+	cancellation, err := h.cancellation.UpdateState(c.Request().Context(), id, form, &currUser)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, cancellation)
+}
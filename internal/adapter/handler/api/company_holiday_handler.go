@@ -0,0 +1,110 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"chrono/internal/domain"
+	"chrono/internal/service"
+)
+
+type APICompanyHolidayHandler struct {
+	holiday *service.CompanyHolidayService
+	log     *slog.Logger
+}
+
+func NewAPICompanyHolidayHandler(
+	h *service.CompanyHolidayService,
+	log *slog.Logger,
+) APICompanyHolidayHandler {
+	return APICompanyHolidayHandler{holiday: h, log: log}
+}
+
+func (h *APICompanyHolidayHandler) RegisterRoutes(auth *echo.Group, admin *echo.Group) {
+	auth.GET("/company-holidays/:year", h.GetCompanyHolidays)
+
+	g := admin.Group("/company-holidays")
+	g.POST("", h.CreateCompanyHoliday)
+	g.PATCH("/:id", h.UpdateCompanyHoliday)
+	g.DELETE("/:id", h.DeleteCompanyHoliday)
+}
+
+func (h *APICompanyHolidayHandler) GetCompanyHolidays(c echo.Context) error {
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid year")
+	}
+
+	holidays, err := h.holiday.GetForYear(c.Request().Context(), year)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "Failed to get company holidays.")
+	}
+
+	return NewJsonResponse(c, holidays)
+}
+
+func (h *APICompanyHolidayHandler) CreateCompanyHoliday(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+
+	var form domain.CreateCompanyHoliday
+	if err := c.Bind(&form); err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid parameters")
+	}
+
+	holiday, err := h.holiday.Create(c.Request().Context(), form, &currUser)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, holiday)
+}
+
+func (h *APICompanyHolidayHandler) UpdateCompanyHoliday(c echo.Context) error {
+	ctx := c.Request().Context()
+	currUser := c.Get("user").(domain.User)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid company holiday id")
+	}
+
+	if _, err := h.holiday.GetById(ctx, id); err != nil {
+		return NewErrorResponse(c, http.StatusNotFound, "company holiday not found")
+	}
+
+	var form domain.CreateCompanyHoliday
+	if err := c.Bind(&form); err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid parameters")
+	}
+
+	holiday, err := h.holiday.Update(ctx, id, form, &currUser)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, holiday)
+}
+
+func (h *APICompanyHolidayHandler) DeleteCompanyHoliday(c echo.Context) error {
+	ctx := c.Request().Context()
+	currUser := c.Get("user").(domain.User)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid company holiday id")
+	}
+
+	if _, err := h.holiday.GetById(ctx, id); err != nil {
+		return NewErrorResponse(c, http.StatusNotFound, "company holiday not found")
+	}
+
+	err = h.holiday.Delete(ctx, id, &currUser)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "Failed to delete company holiday.")
+	}
+
+	return NewJsonResponse(c, nil)
+}
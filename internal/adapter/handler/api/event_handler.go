@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -53,7 +54,7 @@ func (h *APIEventHandler) GetEventsForMonth(c echo.Context) error {
 		)
 	}
 
-	month, err := h.event.GetForMonth(ctx, date, nil, "")
+	month, err := h.event.GetForMonth(ctx, date, h.event.HolidayRegion(&currUser), nil, "")
 	if err != nil {
 		return NewErrorResponse(
 			c,
@@ -67,6 +68,7 @@ func (h *APIEventHandler) GetEventsForMonth(c echo.Context) error {
 
 func (h *APIEventHandler) GetVacationGraph(c echo.Context) error {
 	ctx := c.Request().Context()
+	currUser := c.Get("user").(domain.User)
 
 	yearParam := c.Param("year")
 	year, err := strconv.Atoi(yearParam)
@@ -74,7 +76,7 @@ func (h *APIEventHandler) GetVacationGraph(c echo.Context) error {
 		return NewErrorResponse(c, http.StatusBadRequest, "invalid year parameter")
 	}
 
-	data, err := h.event.GetHistogramForYear(ctx, year)
+	data, err := h.event.GetHistogramForYear(ctx, year, h.event.HolidayRegion(&currUser))
 	if err != nil {
 		return NewErrorResponse(
 			c,
@@ -104,19 +106,26 @@ func (h *APIEventHandler) CreateEvent(c echo.Context) error {
 		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
 	}
 
-	event, err := h.event.Create(
+	event, warnings, err := h.event.Create(
 		ctx,
 		domain.YMDDate{Year: eventForm.Year, Month: eventForm.Month, Day: eventForm.Day},
 		strings.ToLower(eventForm.EventName),
 		&currUser,
 	)
+	var ruleErr *domain.RuleError
+	if errors.As(err, &ruleErr) {
+		return NewErrorDataResponse(c, http.StatusConflict, err.Error(), ruleErr.Violations)
+	}
 	if err != nil {
 		return NewErrorResponse(c, http.StatusInternalServerError, "Failed to create event.")
 	}
 
-	eventUser := domain.EventUser{User: currUser, Event: *event}
+	response := struct {
+		domain.EventUser
+		Warnings []domain.RuleViolation `json:"warnings"`
+	}{domain.EventUser{User: currUser, Event: *event}, warnings}
 
-	return NewJsonResponse(c, eventUser)
+	return NewJsonResponse(c, response)
 }
 
 func (h *APIEventHandler) DeleteEvent(c echo.Context) error {
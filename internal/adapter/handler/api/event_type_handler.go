@@ -0,0 +1,127 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"chrono/internal/domain"
+	"chrono/internal/service"
+)
+
+type APIEventTypeHandler struct {
+	eventType *service.EventTypeService
+	log       *slog.Logger
+}
+
+func NewAPIEventTypeHandler(et *service.EventTypeService, log *slog.Logger) APIEventTypeHandler {
+	return APIEventTypeHandler{eventType: et, log: log}
+}
+
+func (h *APIEventTypeHandler) RegisterRoutes(auth *echo.Group, admin *echo.Group) {
+	auth.GET("/event-types", h.GetEventTypes)
+
+	g := admin.Group("/event-types")
+	g.POST("", h.CreateEventType)
+	g.PATCH("/:id", h.UpdateEventType)
+	g.DELETE("/:id", h.DeleteEventType)
+}
+
+func (h *APIEventTypeHandler) GetEventTypes(c echo.Context) error {
+	types, err := h.eventType.GetAll(c.Request().Context())
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "Failed to get event types.")
+	}
+
+	return NewJsonResponse(c, types)
+}
+
+func (h *APIEventTypeHandler) CreateEventType(c echo.Context) error {
+	var form domain.EventTypeForm
+	if err := c.Bind(&form); err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid parameters")
+	}
+
+	t, err := h.eventType.Create(
+		c.Request().Context(),
+		&domain.EventType{
+			Name:             form.Name,
+			Label:            form.Label,
+			Weight:           form.Weight,
+			ConsumesVacation: form.ConsumesVacation,
+			NeedsApproval:    form.NeedsApproval,
+			CountsAsWorked:   form.CountsAsWorked,
+			ConsumesOvertime: form.ConsumesOvertime,
+			Color:            form.Color,
+		},
+	)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, t)
+}
+
+func (h *APIEventTypeHandler) UpdateEventType(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid event type id")
+	}
+
+	existing, err := h.eventType.GetById(ctx, id)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusNotFound, "event type not found")
+	}
+
+	var form domain.PatchEventTypeForm
+	if err := c.Bind(&form); err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid parameters")
+	}
+
+	if form.Label != "" {
+		existing.Label = form.Label
+	}
+	if form.Weight != 0 {
+		existing.Weight = form.Weight
+	}
+	if form.Color != "" {
+		existing.Color = form.Color
+	}
+	if form.ConsumesVacation != nil {
+		existing.ConsumesVacation = *form.ConsumesVacation
+	}
+	if form.NeedsApproval != nil {
+		existing.NeedsApproval = *form.NeedsApproval
+	}
+	if form.CountsAsWorked != nil {
+		existing.CountsAsWorked = *form.CountsAsWorked
+	}
+	if form.ConsumesOvertime != nil {
+		existing.ConsumesOvertime = *form.ConsumesOvertime
+	}
+
+	t, err := h.eventType.Update(ctx, existing)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, t)
+}
+
+func (h *APIEventTypeHandler) DeleteEventType(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid event type id")
+	}
+
+	err = h.eventType.Delete(c.Request().Context(), id)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "Failed to delete event type.")
+	}
+
+	return NewJsonResponse(c, nil)
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"chrono/internal/service"
+)
+
+type APIJobHandler struct {
+	scheduler *service.Scheduler
+}
+
+func NewAPIJobHandler(s *service.Scheduler) APIJobHandler {
+	return APIJobHandler{scheduler: s}
+}
+
+func (h *APIJobHandler) RegisterRoutes(g *echo.Group) {
+	g.GET("/jobs", h.GetJobs)
+}
+
+func (h *APIJobHandler) GetJobs(c echo.Context) error {
+	status, err := h.scheduler.Status(c.Request().Context())
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "Failed to get jobs.")
+	}
+
+	return NewJsonResponse(c, status)
+}
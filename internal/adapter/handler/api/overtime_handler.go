@@ -0,0 +1,169 @@
+package api
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"chrono/internal/domain"
+	"chrono/internal/service"
+)
+
+type APIOvertimeHandler struct {
+	overtime *service.OvertimeService
+	user     *service.UserService
+	log      *slog.Logger
+}
+
+func NewAPIOvertimeHandler(
+	o *service.OvertimeService,
+	u *service.UserService,
+	log *slog.Logger,
+) APIOvertimeHandler {
+	return APIOvertimeHandler{overtime: o, user: u, log: log}
+}
+
+func (h *APIOvertimeHandler) RegisterRoutes(auth *echo.Group, admin *echo.Group) {
+	auth.GET("/overtime", h.GetBalance)
+	auth.GET("/overtime/bookings", h.GetBookings)
+	auth.GET("/overtime/snapshots", h.GetSnapshots)
+
+	admin.GET("/overtime/users/:id", h.GetBalanceForUser)
+	admin.GET("/overtime/users/:id/bookings", h.GetBookingsForUser)
+	admin.GET("/overtime/users/:id/snapshots", h.GetSnapshotsForUser)
+	admin.POST("/overtime/users/:id/bookings", h.CreateBooking)
+}
+
+// GetBalance returns the overtime balance of the current user at the end of
+// the date query parameter, today if it is missing.
+func (h *APIOvertimeHandler) GetBalance(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+
+	date, err := balanceDate(c)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+	}
+
+	balance, err := h.overtime.Balance(c.Request().Context(), &currUser, date)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return NewJsonResponse(c, balance)
+}
+
+func (h *APIOvertimeHandler) GetBookings(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+
+	bookings, err := h.overtime.GetBookings(c.Request().Context(), currUser.ID)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "failed to get bookings")
+	}
+
+	return NewJsonResponse(c, bookings)
+}
+
+func (h *APIOvertimeHandler) GetSnapshots(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+
+	snapshots, err := h.overtime.GetSnapshots(c.Request().Context(), currUser.ID)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "failed to get snapshots")
+	}
+
+	return NewJsonResponse(c, snapshots)
+}
+
+func (h *APIOvertimeHandler) GetBalanceForUser(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid user id")
+	}
+
+	date, err := balanceDate(c)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+	}
+
+	user, err := h.user.GetById(ctx, id)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusNotFound, "user not found")
+	}
+
+	balance, err := h.overtime.Balance(ctx, user, date)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return NewJsonResponse(c, balance)
+}
+
+func (h *APIOvertimeHandler) GetBookingsForUser(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid user id")
+	}
+
+	bookings, err := h.overtime.GetBookings(c.Request().Context(), id)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "failed to get bookings")
+	}
+
+	return NewJsonResponse(c, bookings)
+}
+
+func (h *APIOvertimeHandler) GetSnapshotsForUser(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid user id")
+	}
+
+	snapshots, err := h.overtime.GetSnapshots(c.Request().Context(), id)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "failed to get snapshots")
+	}
+
+	return NewJsonResponse(c, snapshots)
+}
+
+func (h *APIOvertimeHandler) CreateBooking(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid user id")
+	}
+
+	var form domain.CreateOvertimeBooking
+	if err := c.Bind(&form); err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid parameters")
+	}
+
+	booking, err := h.overtime.AddBooking(c.Request().Context(), id, form, &currUser)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, booking)
+}
+
+// balanceDate parses the optional date query parameter, today if it is
+// missing.
+func balanceDate(c echo.Context) (time.Time, error) {
+	param := c.QueryParam("date")
+	if param == "" {
+		return time.Now().UTC(), nil
+	}
+
+	date, err := time.Parse(time.DateOnly, param)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date")
+	}
+	return date, nil
+}
@@ -1,8 +1,10 @@
 package api
 
 import (
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 
@@ -12,29 +14,31 @@ import (
 
 type APIRequestsHandler struct {
 	request *service.RequestService
-	event   *service.EventService
-	token   *service.TokenService
+	absence *service.AbsenceService
 	log     *slog.Logger
 }
 
 func NewAPIRequestsHandler(
 	r *service.RequestService,
-	e *service.EventService,
-	t *service.TokenService,
+	a *service.AbsenceService,
 	log *slog.Logger,
 ) APIRequestsHandler {
-	return APIRequestsHandler{request: r, event: e, token: t, log: log}
+	return APIRequestsHandler{request: r, absence: a, log: log}
 }
 
 func (h *APIRequestsHandler) RegisterRoutes(group *echo.Group) {
 	group.GET("/requests", h.Requests)
 	group.PATCH("/requests", h.PatchRequests)
+	group.GET("/requests/:id/comments", h.GetComments)
+	group.POST("/requests/:id/comments", h.CreateComment)
 }
 
 func (h *APIRequestsHandler) Requests(c echo.Context) error {
 	ctx := c.Request().Context()
 
-	requests, err := h.request.GetPending(ctx)
+	currUser := c.Get("user").(domain.User)
+
+	requests, err := h.request.GetPending(ctx, &currUser)
 	if err != nil {
 		return NewErrorResponse(
 			c,
@@ -55,36 +59,83 @@ func (h *APIRequestsHandler) PatchRequests(c echo.Context) error {
 		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid parameters")
 	}
 
-	oldForm := domain.PatchRequestForm{
-		UserID:    form.UserID,
-		State:     form.State,
-		Reason:    form.Reason,
-		StartDate: form.StartDate.Unix(),
-		EndDate:   form.EndDate.Unix(),
+	var absence *domain.Absence
+	var err error
+	if form.RequestID != 0 {
+		absence, err = h.absence.GetByRequestId(ctx, form.RequestID)
+	} else {
+		absence, err = h.pendingAbsenceInRange(c, form)
+	}
+	if err != nil {
+		return NewErrorResponse(c, http.StatusNotFound, "request not found")
 	}
 
-	reqId, err := h.request.UpdateInRange(ctx, currUser.ID, oldForm)
+	_, err = h.absence.UpdateState(ctx, absence.ID, form.State, form.Reason, &currUser)
 	if err != nil {
-		return NewErrorResponse(c, http.StatusBadRequest, "failed updating request")
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
 	}
 
-	err = h.event.UpdateInRange(ctx, form.UserID, form.State, form.StartDate, form.EndDate)
+	return NewJsonResponse(c, nil)
+}
+
+func (h *APIRequestsHandler) GetComments(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		return NewErrorResponse(c, http.StatusBadRequest, "Failed updating events")
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid request id")
 	}
 
-	eventName, err := h.request.GetEventName(ctx, reqId)
+	comments, err := h.request.GetComments(c.Request().Context(), id, &currUser)
 	if err != nil {
-		return NewErrorResponse(c, http.StatusBadRequest, "Failed getting event name")
+		return NewErrorResponse(c, http.StatusNotFound, "request not found")
 	}
 
-	if form.State == "accepted" {
-		days := (form.StartDate.Sub(form.EndDate).Hours() / 24) - 1.0
-		if eventName == "urlaub halbtags" {
-			days /= 2
+	return NewJsonResponse(c, comments)
+}
+
+func (h *APIRequestsHandler) CreateComment(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid request id")
+	}
+
+	var form domain.CreateRequestComment
+	if err := c.Bind(&form); err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid parameters")
+	}
+
+	comment, err := h.request.AddComment(c.Request().Context(), id, form, &currUser)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, comment)
+}
+
+// pendingAbsenceInRange finds the undecided absence of the form's user that
+// overlaps the given date range, for clients that don't send a request id.
+func (h *APIRequestsHandler) pendingAbsenceInRange(
+	c echo.Context,
+	form domain.ApiPatchRequestForm,
+) (*domain.Absence, error) {
+	absences, err := h.absence.GetForUser(
+		c.Request().Context(),
+		form.UserID,
+		form.StartDate,
+		form.EndDate.AddDate(0, 0, 1),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range absences {
+		if absences[i].IsAwaitingDecision() {
+			return &absences[i], nil
 		}
-		h.token.CreateVacationToken(ctx, days, form.StartDate.Year(), form.UserID)
 	}
 
-	return NewJsonResponse(c, nil)
+	return nil, fmt.Errorf("no pending absence for user %v in range", form.UserID)
 }
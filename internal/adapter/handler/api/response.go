@@ -20,6 +20,17 @@ func NewErrorResponse(c echo.Context, statusCode int, message string) error {
 	return c.JSON(statusCode, &r)
 }
 
+// NewErrorDataResponse returns an error together with details the client can
+// show, e.g. the rules an absence violates.
+func NewErrorDataResponse(c echo.Context, statusCode int, message string, data any) error {
+	r := ApiResponse{
+		Message: message,
+		Data:    data,
+	}
+
+	return c.JSON(statusCode, &r)
+}
+
 func NewJsonResponse(c echo.Context, data any) error {
 	r := ApiResponse{
 		Message: "success",
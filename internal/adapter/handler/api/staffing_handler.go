@@ -0,0 +1,108 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"chrono/internal/domain"
+	"chrono/internal/service"
+)
+
+type APIStaffingHandler struct {
+	staffing *service.StaffingService
+	log      *slog.Logger
+}
+
+func NewAPIStaffingHandler(s *service.StaffingService, log *slog.Logger) APIStaffingHandler {
+	return APIStaffingHandler{staffing: s, log: log}
+}
+
+func (h *APIStaffingHandler) RegisterRoutes(auth *echo.Group, admin *echo.Group) {
+	auth.GET("/blackouts", h.GetBlackouts)
+
+	b := admin.Group("/blackouts")
+	b.POST("", h.CreateBlackout)
+	b.DELETE("/:id", h.DeleteBlackout)
+
+	r := admin.Group("/staffing-rules")
+	r.GET("", h.GetRules)
+	r.POST("", h.CreateRule)
+	r.DELETE("/:id", h.DeleteRule)
+}
+
+func (h *APIStaffingHandler) GetRules(c echo.Context) error {
+	rules, err := h.staffing.GetRules(c.Request().Context())
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "Failed to get staffing rules.")
+	}
+
+	return NewJsonResponse(c, rules)
+}
+
+func (h *APIStaffingHandler) CreateRule(c echo.Context) error {
+	var form domain.CreateStaffingRule
+	if err := c.Bind(&form); err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid parameters")
+	}
+
+	rule, err := h.staffing.CreateRule(c.Request().Context(), form)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, rule)
+}
+
+func (h *APIStaffingHandler) DeleteRule(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid staffing rule id")
+	}
+
+	err = h.staffing.DeleteRule(c.Request().Context(), id)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "Failed to delete staffing rule.")
+	}
+
+	return NewJsonResponse(c, nil)
+}
+
+func (h *APIStaffingHandler) GetBlackouts(c echo.Context) error {
+	blackouts, err := h.staffing.GetBlackouts(c.Request().Context())
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "Failed to get blackout periods.")
+	}
+
+	return NewJsonResponse(c, blackouts)
+}
+
+func (h *APIStaffingHandler) CreateBlackout(c echo.Context) error {
+	var form domain.CreateBlackoutPeriod
+	if err := c.Bind(&form); err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid parameters")
+	}
+
+	blackout, err := h.staffing.CreateBlackout(c.Request().Context(), form)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, blackout)
+}
+
+func (h *APIStaffingHandler) DeleteBlackout(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid blackout period id")
+	}
+
+	err = h.staffing.DeleteBlackout(c.Request().Context(), id)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "Failed to delete blackout period.")
+	}
+
+	return NewJsonResponse(c, nil)
+}
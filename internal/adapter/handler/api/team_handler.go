@@ -0,0 +1,185 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"chrono/internal/domain"
+	"chrono/internal/service"
+)
+
+type APITeamHandler struct {
+	approval *service.ApprovalService
+	log      *slog.Logger
+}
+
+func NewAPITeamHandler(a *service.ApprovalService, log *slog.Logger) APITeamHandler {
+	return APITeamHandler{approval: a, log: log}
+}
+
+func (h *APITeamHandler) RegisterRoutes(group *echo.Group) {
+	t := group.Group("/teams")
+	t.GET("", h.GetTeams)
+	t.POST("", h.CreateTeam)
+	t.PATCH("/:id", h.UpdateTeam)
+	t.DELETE("/:id", h.DeleteTeam)
+	t.GET("/members", h.GetTeamMembers)
+	t.PUT("/:id/members/:userId", h.SetTeamMember)
+	t.DELETE("/members/:userId", h.DeleteTeamMember)
+
+	a := group.Group("/approvers")
+	a.GET("", h.GetApprovers)
+	a.POST("", h.CreateApprover)
+	a.DELETE("/:id", h.DeleteApprover)
+}
+
+func (h *APITeamHandler) GetTeams(c echo.Context) error {
+	teams, err := h.approval.GetTeams(c.Request().Context())
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "Failed to get teams.")
+	}
+
+	return NewJsonResponse(c, teams)
+}
+
+func (h *APITeamHandler) CreateTeam(c echo.Context) error {
+	var form domain.CreateTeam
+	if err := c.Bind(&form); err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid parameters")
+	}
+
+	team, err := h.approval.CreateTeam(c.Request().Context(), form.Name)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, team)
+}
+
+func (h *APITeamHandler) UpdateTeam(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid team id")
+	}
+
+	team, err := h.approval.GetTeamById(ctx, id)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusNotFound, "team not found")
+	}
+
+	var form domain.CreateTeam
+	if err := c.Bind(&form); err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid parameters")
+	}
+	team.Name = form.Name
+
+	team, err = h.approval.UpdateTeam(ctx, team)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, team)
+}
+
+func (h *APITeamHandler) DeleteTeam(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid team id")
+	}
+
+	err = h.approval.DeleteTeam(c.Request().Context(), id)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "Failed to delete team.")
+	}
+
+	return NewJsonResponse(c, nil)
+}
+
+func (h *APITeamHandler) GetTeamMembers(c echo.Context) error {
+	members, err := h.approval.GetTeamMembers(c.Request().Context())
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "Failed to get team members.")
+	}
+
+	return NewJsonResponse(c, members)
+}
+
+func (h *APITeamHandler) SetTeamMember(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid team id")
+	}
+	userId, err := strconv.ParseInt(c.Param("userId"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid user id")
+	}
+
+	if _, err := h.approval.GetTeamById(ctx, id); err != nil {
+		return NewErrorResponse(c, http.StatusNotFound, "team not found")
+	}
+
+	err = h.approval.SetTeamMember(ctx, userId, id)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, nil)
+}
+
+func (h *APITeamHandler) DeleteTeamMember(c echo.Context) error {
+	userId, err := strconv.ParseInt(c.Param("userId"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid user id")
+	}
+
+	err = h.approval.DeleteTeamMember(c.Request().Context(), userId)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "Failed to remove team member.")
+	}
+
+	return NewJsonResponse(c, nil)
+}
+
+func (h *APITeamHandler) GetApprovers(c echo.Context) error {
+	approvers, err := h.approval.GetApprovers(c.Request().Context())
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "Failed to get approvers.")
+	}
+
+	return NewJsonResponse(c, approvers)
+}
+
+func (h *APITeamHandler) CreateApprover(c echo.Context) error {
+	var form domain.CreateApprover
+	if err := c.Bind(&form); err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid parameters")
+	}
+
+	approver, err := h.approval.CreateApprover(c.Request().Context(), form)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, approver)
+}
+
+func (h *APITeamHandler) DeleteApprover(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid approver id")
+	}
+
+	err = h.approval.DeleteApprover(c.Request().Context(), id)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "Failed to delete approver.")
+	}
+
+	return NewJsonResponse(c, nil)
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"chrono/internal/domain"
+	"chrono/internal/service"
+)
+
+type APITimestampCorrectionHandler struct {
+	correction *service.TimestampCorrectionService
+	log        *slog.Logger
+}
+
+func NewAPITimestampCorrectionHandler(
+	c *service.TimestampCorrectionService,
+	log *slog.Logger,
+) APITimestampCorrectionHandler {
+	return APITimestampCorrectionHandler{correction: c, log: log}
+}
+
+func (h *APITimestampCorrectionHandler) RegisterRoutes(auth *echo.Group, admin *echo.Group) {
+	auth.POST("/timestamps/corrections", h.CreateCorrection)
+	auth.GET("/timestamps/corrections", h.GetCorrections)
+
+	admin.GET("/timestamps/corrections/pending", h.GetPendingCorrections)
+	admin.PATCH("/timestamps/corrections/:id", h.UpdateCorrection)
+}
+
+func (h *APITimestampCorrectionHandler) CreateCorrection(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+
+	var form domain.CreateTimestampCorrection
+	if err := c.Bind(&form); err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid parameters")
+	}
+
+	correction, err := h.correction.Create(c.Request().Context(), form, &currUser)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, correction)
+}
+
+func (h *APITimestampCorrectionHandler) GetCorrections(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+
+	corrections, err := h.correction.GetForUser(c.Request().Context(), currUser.ID)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "failed to get corrections")
+	}
+
+	return NewJsonResponse(c, corrections)
+}
+
+func (h *APITimestampCorrectionHandler) GetPendingCorrections(c echo.Context) error {
+	corrections, err := h.correction.GetPending(c.Request().Context())
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, "failed to get corrections")
+	}
+
+	return NewJsonResponse(c, corrections)
+}
+
+func (h *APITimestampCorrectionHandler) UpdateCorrection(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid correction id")
+	}
+
+	var form domain.UpdateTimestampCorrection
+	if err := c.Bind(&form); err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid parameters")
+	}
+
+	correction, err := h.correction.UpdateState(c.Request().Context(), id, form, &currUser)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, correction)
+}
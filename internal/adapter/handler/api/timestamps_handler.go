@@ -1,7 +1,9 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"time"
 
@@ -11,6 +13,10 @@ import (
 	"chrono/internal/service"
 )
 
+// idempotencyKeyHeader carries the key clients send with clock in and out to
+// retry them safely.
+const idempotencyKeyHeader = "Idempotency-Key"
+
 type APITimestampsHandler struct {
 	timestamps *service.TimestampsService
 	user       *service.UserService
@@ -30,15 +36,24 @@ func (s *APITimestampsHandler) RegisterRoutes(auth *echo.Group, admin *echo.Grou
 	g.GET("/latest", s.GetLatestTimestamp)
 	g.GET("/worked/:year", s.GetWorkHoursForYear)
 	g.GET("/worked/:year/all", s.GetWorkHoursForYearForAllUsers)
+	g.POST("/:id/pause", s.Pause)
+	g.POST("/:id/resume", s.Resume)
+	g.GET("/:id/breaks", s.GetBreaks)
+	g.POST("/:id/breaks", s.AddBreak)
+	g.DELETE("/:id/breaks/:breakId", s.DeleteBreak)
+	g.GET("/:id/history", s.GetHistory)
+	g.GET("/compliance", s.GetCompliance)
 
 	admin.GET("/timestamps/all", s.GetAllTimestamps)
+	admin.GET("/timestamps/compliance/all", s.GetComplianceForAllUsers)
 }
 
 func (h *APITimestampsHandler) Start(c echo.Context) error {
 	currUser := c.Get("user").(domain.User)
 	ctx := c.Request().Context()
 
-	t, err := h.timestamps.Start(ctx, currUser.ID)
+	key := c.Request().Header.Get(idempotencyKeyHeader)
+	t, err := h.timestamps.Start(ctx, currUser.ID, key)
 	if err != nil {
 		return NewErrorResponse(c, http.StatusInternalServerError, err.Error())
 	}
@@ -47,6 +62,7 @@ func (h *APITimestampsHandler) Start(c echo.Context) error {
 }
 
 func (h *APITimestampsHandler) Stop(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
 	ctx := c.Request().Context()
 	idParam := c.Param("id")
 	id, err := strconv.ParseInt(idParam, 10, 64)
@@ -54,9 +70,10 @@ func (h *APITimestampsHandler) Stop(c echo.Context) error {
 		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid id")
 	}
 
-	t, err := h.timestamps.Stop(ctx, id)
+	key := c.Request().Header.Get(idempotencyKeyHeader)
+	t, err := h.timestamps.Stop(ctx, id, &currUser, key)
 	if err != nil {
-		return NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
 	}
 
 	return NewJsonResponse(c, t)
@@ -99,7 +116,7 @@ func (h *APITimestampsHandler) Update(c echo.Context) error {
 		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid form parameters")
 	}
 
-	t, err := h.timestamps.Update(ctx, &tsForm)
+	t, err := h.timestamps.Update(ctx, &tsForm, currUser.ID)
 	if err != nil {
 		return NewErrorResponse(c, http.StatusNotFound, err.Error())
 	}
@@ -151,7 +168,7 @@ func (h *APITimestampsHandler) GetWorkHoursForYear(c echo.Context) error {
 		return NewErrorResponse(c, http.StatusUnprocessableEntity, "year parameter is missing")
 	}
 
-	work, err := h.timestamps.GetWorkHoursForYear(ctx, currUser.ID, year, currUser.WorkdayHours)
+	work, err := h.timestamps.GetWorkHoursForYear(ctx, &currUser, year)
 	if err != nil {
 		return NewErrorResponse(c, http.StatusInternalServerError, err.Error())
 	}
@@ -216,3 +233,186 @@ func (h *APITimestampsHandler) GetAllTimestamps(c echo.Context) error {
 
 	return NewJsonResponse(c, t)
 }
+
+func (h *APITimestampsHandler) Pause(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+	ctx := c.Request().Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid id")
+	}
+
+	b, err := h.timestamps.Pause(ctx, id, &currUser)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, b)
+}
+
+func (h *APITimestampsHandler) Resume(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+	ctx := c.Request().Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid id")
+	}
+
+	b, err := h.timestamps.Resume(ctx, id, &currUser)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, b)
+}
+
+func (h *APITimestampsHandler) GetBreaks(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+	ctx := c.Request().Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid id")
+	}
+
+	b, err := h.timestamps.GetBreaks(ctx, id, &currUser)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusNotFound, err.Error())
+	}
+
+	return NewJsonResponse(c, b)
+}
+
+func (h *APITimestampsHandler) GetHistory(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+	ctx := c.Request().Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid id")
+	}
+
+	history, err := h.timestamps.GetHistory(ctx, id, &currUser)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusNotFound, err.Error())
+	}
+
+	return NewJsonResponse(c, history)
+}
+
+func (h *APITimestampsHandler) AddBreak(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+	ctx := c.Request().Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid id")
+	}
+
+	var form domain.CreateTimestampBreak
+	if err := c.Bind(&form); err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid form parameters")
+	}
+
+	b, err := h.timestamps.AddBreak(ctx, id, form, &currUser)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, b)
+}
+
+func (h *APITimestampsHandler) DeleteBreak(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+	ctx := c.Request().Context()
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid id")
+	}
+	breakId, err := strconv.ParseInt(c.Param("breakId"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid break id")
+	}
+
+	err = h.timestamps.DeleteBreak(ctx, id, breakId, &currUser)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetCompliance returns the working days of the current user from the from
+// until the to query parameter with their violations of the working time act.
+func (h *APITimestampsHandler) GetCompliance(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+	ctx := c.Request().Context()
+
+	from, to, err := complianceRange(c)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+	}
+
+	days, err := h.timestamps.GetCompliance(ctx, &currUser.ID, from, to)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return NewJsonResponse(c, days)
+}
+
+// GetComplianceForAllUsers returns the working days of all users, or of the
+// user of the optional user_id query parameter, like GetCompliance. With
+// violations=1 only the days that break a rule are returned.
+func (h *APITimestampsHandler) GetComplianceForAllUsers(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	from, to, err := complianceRange(c)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+	}
+
+	var userId *int64
+	if param := c.QueryParam("user_id"); param != "" {
+		id, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid user_id")
+		}
+		userId = &id
+	}
+
+	days, err := h.timestamps.GetCompliance(ctx, userId, from, to)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if c.QueryParam("violations") == "1" {
+		days = slices.DeleteFunc(days, func(d domain.ComplianceDay) bool {
+			return d.IsCompliant()
+		})
+	}
+
+	return NewJsonResponse(c, days)
+}
+
+// complianceRange parses the from and to query parameters as local calendar
+// days, both are required and inclusive.
+func complianceRange(c echo.Context) (time.Time, time.Time, error) {
+	from, err := time.ParseInLocation(time.DateOnly, c.QueryParam("from"), time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid from")
+	}
+
+	to, err := time.ParseInLocation(time.DateOnly, c.QueryParam("to"), time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid to")
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("to must not be before from")
+	}
+
+	return from, to, nil
+}
@@ -14,23 +14,26 @@ import (
 )
 
 type APITokenHandler struct {
-	user  *service.UserService
-	token *service.TokenService
-	notif *service.NotificationService
-	log   *slog.Logger
+	user      *service.UserService
+	token     *service.TokenService
+	yearClose *service.YearCloseService
+	notif     *service.NotificationService
+	log       *slog.Logger
 }
 
 func NewAPITokenHandler(
 	t *service.TokenService,
+	yc *service.YearCloseService,
 	u *service.UserService,
 	n *service.NotificationService,
 	log *slog.Logger,
 ) APITokenHandler {
-	return APITokenHandler{token: t, user: u, notif: n, log: log}
+	return APITokenHandler{token: t, yearClose: yc, user: u, notif: n, log: log}
 }
 
 func (h *APITokenHandler) RegisterRoutes(group *echo.Group) {
 	group.POST("/tokens", h.CreateTokens)
+	group.POST("/tokens/year-close", h.CloseYear)
 }
 
 func (h *APITokenHandler) CreateTokens(c echo.Context) error {
@@ -58,7 +61,14 @@ func (h *APITokenHandler) CreateTokens(c echo.Context) error {
 		return NewErrorResponse(c, http.StatusInternalServerError, "Failed to get user.")
 	}
 
-	_, err = h.token.CreateVacationToken(ctx, tokenNum, time.Now().Year(), user.ID)
+	token := domain.NewVacationToken(
+		tokenNum,
+		time.Now().Year(),
+		user.ID,
+		domain.ReasonAdminAdjustment,
+	)
+	token.CreatedBy = &currUser.ID
+	_, err = h.token.CreateVacationToken(ctx, token)
 	if err != nil {
 		return NewErrorResponse(
 			c,
@@ -75,3 +85,19 @@ func (h *APITokenHandler) CreateTokens(c echo.Context) error {
 
 	return NewJsonResponse(c, nil)
 }
+
+func (h *APITokenHandler) CloseYear(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+
+	year, err := strconv.Atoi(c.FormValue("year"))
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, "invalid year")
+	}
+
+	closing, err := h.yearClose.Close(c.Request().Context(), year, &currUser.ID)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, closing)
+}
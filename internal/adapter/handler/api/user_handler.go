@@ -4,6 +4,7 @@ import (
 	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
@@ -12,11 +13,13 @@ import (
 )
 
 type APIUserHandler struct {
-	user  *service.UserService
-	event *service.EventService
-	auth  *service.AuthService
-	token *service.TokenService
-	log   *slog.Logger
+	user     *service.UserService
+	event    *service.EventService
+	auth     *service.AuthService
+	token    *service.TokenService
+	contract *service.UserContractService
+	holiday  *service.HolidayService
+	log      *slog.Logger
 }
 
 func NewAPIUserHandler(
@@ -24,13 +27,26 @@ func NewAPIUserHandler(
 	e *service.EventService,
 	a *service.AuthService,
 	t *service.TokenService,
+	c *service.UserContractService,
+	hol *service.HolidayService,
 	log *slog.Logger,
 ) APIUserHandler {
-	return APIUserHandler{user: u, event: e, auth: a, token: t, log: log}
+	return APIUserHandler{
+		user:     u,
+		event:    e,
+		auth:     a,
+		token:    t,
+		contract: c,
+		holiday:  hol,
+		log:      log,
+	}
 }
 
 func (h *APIUserHandler) RegisterRoutes(group *echo.Group) {
 	group.GET("/users/:id", h.GetUserById)
+	group.GET("/users/:id/vacation-ledger", h.GetVacationLedger)
+	group.GET("/users/:id/contracts", h.GetContracts)
+	group.POST("/users/:id/contracts", h.CreateContract)
 	group.PATCH("/users/:id", h.ProfileEdit)
 	group.GET("/users", h.GetUsers)
 }
@@ -155,14 +171,62 @@ func (h *APIUserHandler) ProfileEdit(c echo.Context) error {
 		color = patchedData.Color
 	}
 
-	vacDays := userToEdit.VacationDays
-	if currUser.IsAdmin() && patchedData.VacationDays != nil {
-		vacDays = *patchedData.VacationDays
+	// changed working terms are recorded as a new contract from today, only
+	// admins change them
+	terms := domain.UserContract{
+		ValidFrom:    time.Now().UTC().Truncate(time.Hour * 24),
+		WorkdayHours: userToEdit.WorkdayHours,
+		WorkdaysWeek: userToEdit.WorkdaysWeek,
+		VacationDays: userToEdit.VacationDays,
+		UserID:       userToEdit.ID,
+	}
+	terms.Schedule = userToEdit.Schedule
+	if currUser.IsAdmin() {
+		if patchedData.VacationDays != nil {
+			terms.VacationDays = *patchedData.VacationDays
+		}
+		if patchedData.WorkdayHours > 0 {
+			terms.WorkdayHours = patchedData.WorkdayHours
+		}
+		if patchedData.WorkdaysWeek > 0 {
+			terms.WorkdaysWeek = patchedData.WorkdaysWeek
+		}
+		if patchedData.Schedule != nil {
+			terms.Schedule = patchedData.Schedule
+			if *patchedData.Schedule == "" {
+				terms.Schedule = nil
+			}
+		}
 	}
 
-	h.user.SetVacation(ctx, userToEdit.ID, int(vacDays), domain.CurrentYear())
-	if err := c.Bind(&patchedData); err != nil {
-		return NewErrorResponse(c, http.StatusInternalServerError, "Failed updating vacation")
+	entryDate := userToEdit.EntryDate
+	exitDate := userToEdit.ExitDate
+	if currUser.IsAdmin() {
+		entryDate, err = parseOptionalDate(patchedData.EntryDate, entryDate)
+		if err != nil {
+			return NewErrorResponse(c, http.StatusUnprocessableEntity, "Invalid entry date")
+		}
+		exitDate, err = parseOptionalDate(patchedData.ExitDate, exitDate)
+		if err != nil {
+			return NewErrorResponse(c, http.StatusUnprocessableEntity, "Invalid exit date")
+		}
+		if entryDate != nil && exitDate != nil && exitDate.Before(*entryDate) {
+			return NewErrorResponse(
+				c,
+				http.StatusUnprocessableEntity,
+				"Exit date must not be before entry date",
+			)
+		}
+	}
+
+	region := userToEdit.Region
+	if currUser.IsAdmin() && patchedData.Region != nil {
+		region = patchedData.Region
+		if *patchedData.Region == "" {
+			region = nil
+		} else if !domain.IsValidRegion(domain.Region(*patchedData.Region)) {
+			return NewErrorResponse(c, http.StatusUnprocessableEntity, "Invalid holiday region")
+		}
 	}
 
 	role := userToEdit.Role
@@ -189,10 +253,14 @@ func (h *APIUserHandler) ProfileEdit(c echo.Context) error {
 		AworkID:      aworkId,
 		Enabled:      enabled,
 		IsSuperuser:  superuser,
-		VacationDays: vacDays,
+		VacationDays: userToEdit.VacationDays,
 		Password:     userToEdit.Password,
-		WorkdayHours: patchedData.WorkdayHours,
-		WorkdaysWeek: patchedData.WorkdaysWeek,
+		WorkdayHours: userToEdit.WorkdayHours,
+		WorkdaysWeek: userToEdit.WorkdaysWeek,
+		Schedule:     userToEdit.Schedule,
+		EntryDate:    entryDate,
+		ExitDate:     exitDate,
+		Region:       region,
 	}
 
 	if patchedData.Password != "" {
@@ -219,5 +287,169 @@ func (h *APIUserHandler) ProfileEdit(c echo.Context) error {
 		)
 	}
 
+	// holidays of a region are only created once a user works there, if that
+	// fails the holidays job creates them later
+	if !sameRegion(userToEdit.Region, updatedUser.Region) {
+		err = h.holiday.Update(ctx, domain.CurrentYear(), h.event.HolidayRegion(updatedUser))
+		if err != nil {
+			h.log.Error(
+				"Failed creating holidays",
+				slog.Int64("userId", updatedUser.ID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	if terms.WorkdayHours != updatedUser.WorkdayHours ||
+		terms.WorkdaysWeek != updatedUser.WorkdaysWeek ||
+		terms.VacationDays != updatedUser.VacationDays ||
+		!sameSchedule(terms.Schedule, updatedUser.Schedule) {
+		_, err = h.contract.Add(ctx, terms, &currUser)
+		if err != nil {
+			return NewErrorResponse(c, http.StatusUnprocessableEntity, err.Error())
+		}
+
+		updatedUser, err = h.user.GetById(ctx, updatedUser.ID)
+		if err != nil {
+			return NewErrorResponse(c, http.StatusNotFound, "user id does not exist")
+		}
+	} else if !sameDate(userToEdit.EntryDate, updatedUser.EntryDate) ||
+		!sameDate(userToEdit.ExitDate, updatedUser.ExitDate) {
+		// the entitlement also depends on the employment dates
+		err = h.token.UpdateYearlyTokens(ctx, updatedUser, domain.CurrentYear(), currUser.ID)
+		if err != nil {
+			return NewErrorResponse(c, http.StatusInternalServerError, "Failed updating vacation")
+		}
+	}
+
 	return NewJsonResponse(c, updatedUser)
 }
+
+// sameSchedule compares two optional schedules by their hours.
+func sameSchedule(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	wa, errA := domain.ParseWeekSchedule(*a)
+	wb, errB := domain.ParseWeekSchedule(*b)
+	if errA != nil || errB != nil {
+		return *a == *b
+	}
+
+	return wa == wb
+}
+
+// sameRegion compares two optional holiday regions.
+func sameRegion(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+// sameDate compares two optional dates.
+func sameDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
+
+// parseOptionalDate parses a date form value, nil keeps the current value and
+// an empty string clears it.
+func parseOptionalDate(val *string, curr *time.Time) (*time.Time, error) {
+	if val == nil {
+		return curr, nil
+	}
+	if *val == "" {
+		return nil, nil
+	}
+
+	d, err := time.Parse(time.DateOnly, *val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+func (h *APIUserHandler) GetVacationLedger(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+
+	userId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, "invalid user id")
+	}
+
+	if !currUser.IsAdmin() && currUser.ID != userId {
+		return NewErrorResponse(c, http.StatusForbidden, "not allowed to view this ledger")
+	}
+
+	year, err := strconv.Atoi(c.QueryParam("year"))
+	if err != nil {
+		year = domain.CurrentYear()
+	}
+
+	ledger, err := h.token.GetLedger(c.Request().Context(), userId, year)
+	if err != nil {
+		return NewErrorResponse(
+			c,
+			http.StatusInternalServerError,
+			"Failed to get vacation ledger.",
+		)
+	}
+
+	return NewJsonResponse(c, ledger)
+}
+
+func (h *APIUserHandler) GetContracts(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+
+	userId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, "invalid user id")
+	}
+
+	if !currUser.IsAdmin() && currUser.ID != userId {
+		return NewErrorResponse(c, http.StatusForbidden, "not allowed to view these contracts")
+	}
+
+	contracts, err := h.contract.GetForUser(c.Request().Context(), userId)
+	if err != nil {
+		return NewErrorResponse(
+			c,
+			http.StatusInternalServerError,
+			"Failed to get contracts.",
+		)
+	}
+
+	return NewJsonResponse(c, contracts)
+}
+
+func (h *APIUserHandler) CreateContract(c echo.Context) error {
+	currUser := c.Get("user").(domain.User)
+
+	if !currUser.IsAdmin() {
+		return NewErrorResponse(c, http.StatusForbidden, "not allowed to add contracts")
+	}
+
+	userId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, "invalid user id")
+	}
+
+	var form domain.CreateUserContract
+	if err := c.Bind(&form); err != nil {
+		return NewErrorResponse(c, http.StatusUnprocessableEntity, "invalid parameters")
+	}
+
+	contract, err := h.contract.Create(c.Request().Context(), userId, form, &currUser)
+	if err != nil {
+		return NewErrorResponse(c, http.StatusBadRequest, err.Error())
+	}
+
+	return NewJsonResponse(c, contract)
+}
@@ -0,0 +1,197 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type Absence struct {
+	ID           int64     `json:"id"`
+	Name         string    `json:"name"`
+	StartDate    time.Time `json:"start_date"`
+	EndDate      time.Time `json:"end_date"`
+	HalfDayStart bool      `json:"half_day_start"`
+	HalfDayEnd   bool      `json:"half_day_end"`
+	State        string    `json:"state"`
+	CreatedAt    time.Time `json:"created_at"`
+	EditedAt     time.Time `json:"edited_at"`
+	UserID       int64     `json:"user_id"`
+}
+
+func (a *Absence) IsAccepted() bool {
+	return a.State == "accepted"
+}
+
+func (a *Absence) IsPending() bool {
+	return a.State == "pending"
+}
+
+// IsAwaitingDecision reports whether an approver still has to decide on the
+// absence.
+func (a *Absence) IsAwaitingDecision() bool {
+	return a.State == "pending" || a.State == "approved_by_lead"
+}
+
+// Stage returns the approval stage the absence is waiting for.
+func (a *Absence) Stage() int64 {
+	if a.State == "approved_by_lead" {
+		return StageFinal
+	}
+	return StageLead
+}
+
+// AbsenceEventState returns the state of the events of an absence in the
+// given state, the events stay pending until the final decision.
+func AbsenceEventState(state string) string {
+	if state == "approved_by_lead" {
+		return "pending"
+	}
+	return state
+}
+
+func (a *Absence) RequestMsg(username string) string {
+	start := a.StartDate.Format(time.DateOnly)
+	end := a.EndDate.Format(time.DateOnly)
+	if start == end {
+		return fmt.Sprintf("%v sent a new request for %v on %v.", username, a.Name, start)
+	}
+	return fmt.Sprintf("%v sent a new request for %v from %v to %v.", username, a.Name, start, end)
+}
+
+// AbsenceDay is a single calendar day covered by an absence.
+type AbsenceDay struct {
+	Date    time.Time
+	HalfDay bool
+}
+
+// AbsenceDays derives the working days between start and end (inclusive).
+// Weekends and the given holidays are skipped, the half day flags apply to
+// the first and last requested date and half day holidays leave half a day.
+func AbsenceDays(
+	start, end time.Time,
+	halfDayStart, halfDayEnd bool,
+	holidays []Holiday,
+) []AbsenceDay {
+	start = truncateDay(start)
+	end = truncateDay(end)
+
+	off := make(map[time.Time]float64, len(holidays))
+	for _, h := range holidays {
+		day := truncateDay(h.Date)
+		off[day] = max(off[day], h.Weight)
+	}
+
+	days := []AbsenceDay{}
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		wd := d.Weekday()
+		if wd == time.Saturday || wd == time.Sunday || off[d] >= 1 {
+			continue
+		}
+
+		half := (d.Equal(start) && halfDayStart) || (d.Equal(end) && halfDayEnd) || off[d] > 0
+		days = append(days, AbsenceDay{Date: d, HalfDay: half})
+	}
+
+	return days
+}
+
+// AbsenceCost sums the vacation days per year that the given days consume for
+// an event type.
+func AbsenceCost(days []AbsenceDay, t EventType) map[int]float64 {
+	cost := map[int]float64{}
+	for _, d := range days {
+		e := Event{HalfDay: d.HalfDay}
+		cost[d.Date.Year()] += e.Days(t)
+	}
+
+	return cost
+}
+
+// AbsenceQuote is what a planned absence would cost before it is requested.
+type AbsenceQuote struct {
+	EventName     string          `json:"event_name"`
+	StartDate     time.Time       `json:"start_date"`
+	EndDate       time.Time       `json:"end_date"`
+	Days          float64         `json:"days"`
+	Cost          float64         `json:"cost"`
+	BalanceBefore float64         `json:"balance_before"`
+	BalanceAfter  float64         `json:"balance_after"`
+	Shortfall     float64         `json:"shortfall"`
+	NeedsApproval bool            `json:"needs_approval"`
+	Violations    []RuleViolation `json:"violations"`
+}
+
+// QuoteAbsence sums the chargeable days and their vacation cost against the
+// pots that are valid during the absence. Types that do not consume vacation
+// cost nothing.
+func QuoteAbsence(days []AbsenceDay, t EventType, pots []VacationPot) AbsenceQuote {
+	quote := AbsenceQuote{EventName: t.Name, Days: AbsenceLength(days)}
+	if len(days) > 0 {
+		quote.StartDate = days[0].Date
+		quote.EndDate = days[len(days)-1].Date
+	}
+
+	for _, p := range pots {
+		quote.BalanceBefore += p.Value
+	}
+
+	if t.ConsumesVacation {
+		for _, c := range AbsenceCost(days, t) {
+			quote.Cost += c
+		}
+		quote.Shortfall = VacationShortfall(days, t, pots)
+	}
+	quote.BalanceAfter = quote.BalanceBefore - quote.Cost
+
+	return quote
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+type CreateAbsence struct {
+	EventName    string `form:"event_name"`
+	StartDate    string `form:"start_date"`
+	EndDate      string `form:"end_date"`
+	HalfDayStart bool   `form:"half_day_start"`
+	HalfDayEnd   bool   `form:"half_day_end"`
+	Message      string `form:"message"`
+}
+
+type AbsenceQuoteForm struct {
+	EventName    string `query:"type"`
+	StartDate    string `query:"start"`
+	EndDate      string `query:"end"`
+	HalfDayStart bool   `query:"half_day_start"`
+	HalfDayEnd   bool   `query:"half_day_end"`
+}
+
+type AbsenceRepository interface {
+	// Create stores the absence together with one event per day, the request
+	// (if msg is not nil) and the vacation tokens in a single transaction.
+	Create(
+		ctx context.Context,
+		a *Absence,
+		days []AbsenceDay,
+		msg *string,
+		tokens []CreateVacationToken,
+	) (*Absence, error)
+	// UpdateState sets the state of the absence, its events and its request
+	// and stores the vacation tokens in a single transaction.
+	UpdateState(
+		ctx context.Context,
+		id int64,
+		state string,
+		editorId int64,
+		tokens []CreateVacationToken,
+	) (*Absence, error)
+	// Delete removes the absence with its events and request and stores the
+	// vacation tokens in a single transaction.
+	Delete(ctx context.Context, id int64, tokens []CreateVacationToken) error
+	GetById(ctx context.Context, id int64) (*Absence, error)
+	GetByRequestId(ctx context.Context, requestId int64) (*Absence, error)
+	GetForUser(ctx context.Context, userId int64, start, end time.Time) ([]Absence, error)
+	GetEvents(ctx context.Context, id int64) ([]Event, error)
+}
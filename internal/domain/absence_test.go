@@ -0,0 +1,138 @@
+package domain_test
+
+import (
+	"chrono/internal/domain"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// TestAbsenceDays checks that weekends and holidays are skipped, half day
+// holidays leave half a day and the half day flags only apply to the first and
+// last requested day.
+func TestAbsenceDays(t *testing.T) {
+	tests := []struct {
+		name         string
+		start, end   time.Time
+		halfDayStart bool
+		halfDayEnd   bool
+		holidays     []domain.Holiday
+		expectedDays int
+		expectedHalf int
+	}{
+		{"single day", date(2026, 3, 2), date(2026, 3, 2), false, false, nil, 1, 0},
+		{"over weekend", date(2026, 3, 5), date(2026, 3, 10), false, false, nil, 4, 0},
+		{"only weekend", date(2026, 3, 7), date(2026, 3, 8), false, false, nil, 0, 0},
+		{
+			"with holiday", date(2026, 12, 21), date(2027, 1, 1), false, false,
+			[]domain.Holiday{{Date: date(2026, 12, 25), Weight: 1}, {Date: date(2027, 1, 1), Weight: 1}},
+			8, 0,
+		},
+		{
+			"with half day holiday", date(2026, 12, 23), date(2026, 12, 24), false, false,
+			[]domain.Holiday{{Date: date(2026, 12, 24), Weight: 0.5}}, 2, 1,
+		},
+		{"half days", date(2026, 3, 2), date(2026, 3, 6), true, true, nil, 5, 2},
+		{"half day start on weekend", date(2026, 3, 7), date(2026, 3, 9), true, false, nil, 1, 0},
+		{"single half day", date(2026, 3, 2), date(2026, 3, 2), false, true, nil, 1, 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			days := domain.AbsenceDays(
+				tc.start,
+				tc.end,
+				tc.halfDayStart,
+				tc.halfDayEnd,
+				tc.holidays,
+			)
+			if len(days) != tc.expectedDays {
+				t.Fatalf("AbsenceDays() returned %d days, want %d", len(days), tc.expectedDays)
+			}
+
+			half := 0
+			for _, d := range days {
+				if wd := d.Date.Weekday(); wd == time.Saturday || wd == time.Sunday {
+					t.Errorf("AbsenceDays() contains weekend day %v", d.Date)
+				}
+				if d.HalfDay {
+					half++
+				}
+			}
+			if half != tc.expectedHalf {
+				t.Errorf("AbsenceDays() returned %d half days, want %d", half, tc.expectedHalf)
+			}
+		})
+	}
+}
+
+// TestAbsenceCost checks that the cost is split by year and weighted.
+func TestAbsenceCost(t *testing.T) {
+	typ := domain.EventType{Name: "urlaub", Weight: 1.0, ConsumesVacation: true}
+	days := domain.AbsenceDays(date(2026, 12, 30), date(2027, 1, 4), false, true, nil)
+
+	cost := domain.AbsenceCost(days, typ)
+	if cost[2026] != 2 {
+		t.Errorf("cost for 2026 = %v, want 2", cost[2026])
+	}
+	if cost[2027] != 1.5 {
+		t.Errorf("cost for 2027 = %v, want 1.5", cost[2027])
+	}
+}
+
+// TestQuoteAbsence checks the cost and balance of a planned absence and that
+// part time users are not charged for their free weekdays.
+func TestQuoteAbsence(t *testing.T) {
+	vacation := domain.EventType{Name: "urlaub", Weight: 1.0, ConsumesVacation: true}
+	homeOffice := domain.EventType{Name: "homeoffice", Weight: 1.0}
+	start, end := domain.VacationYear(2027)
+	pots := []domain.VacationPot{{StartDate: start, EndDate: end, Value: 3}}
+	// monday to wednesday
+	partTime := "8,8,8,0,0,0,0"
+
+	tests := []struct {
+		name     string
+		user     domain.User
+		typ      domain.EventType
+		halfEnd  bool
+		expected domain.AbsenceQuote
+	}{
+		{
+			name:     "full time",
+			user:     domain.User{WorkdayHours: 8},
+			typ:      vacation,
+			expected: domain.AbsenceQuote{Days: 5, Cost: 5, BalanceBefore: 3, BalanceAfter: -2, Shortfall: 2},
+		},
+		{
+			name:     "part time",
+			user:     domain.User{WorkdayHours: 8, Schedule: &partTime},
+			typ:      vacation,
+			halfEnd:  true,
+			expected: domain.AbsenceQuote{Days: 3, Cost: 3, BalanceBefore: 3, BalanceAfter: 0},
+		},
+		{
+			name:     "free type",
+			user:     domain.User{WorkdayHours: 8},
+			typ:      homeOffice,
+			expected: domain.AbsenceQuote{Days: 5, BalanceBefore: 3, BalanceAfter: 3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			days := domain.AbsenceDays(date(2027, 3, 1), date(2027, 3, 5), false, tc.halfEnd, nil)
+			days = tc.user.ScheduledDays(nil, days)
+			got := domain.QuoteAbsence(days, tc.typ, pots)
+			if got.Days != tc.expected.Days ||
+				got.Cost != tc.expected.Cost ||
+				got.BalanceBefore != tc.expected.BalanceBefore ||
+				got.BalanceAfter != tc.expected.BalanceAfter ||
+				got.Shortfall != tc.expected.Shortfall {
+				t.Errorf("QuoteAbsence() = %+v, want %+v", got, tc.expected)
+			}
+		})
+	}
+}
@@ -9,12 +9,11 @@ type ApiCache struct {
 	ID        int64     `json:"id"`
 	Year      int64     `json:"year"`
 	CreatedAt time.Time `json:"created_at"`
+	Region    string    `json:"region"`
 }
 
-type Holidays = map[string]map[string]string
-
 type ApiCacheRepository interface {
-	Exists(ctx context.Context, year int64) (int64, error)
+	Exists(ctx context.Context, year int64, region Region) (int64, error)
 	GetAll(ctx context.Context) ([]int64, error)
-	Create(ctx context.Context, year int64) error
+	Create(ctx context.Context, year int64, region Region) error
 }
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// AutoApprovePolicy accepts absence requests without an approver, for a
+// single event type or any type without EventName, up to MaxDays working
+// days or of any length without MaxDays.
+type AutoApprovePolicy struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	EventName *string   `json:"event_name"`
+	MaxDays   *float64  `json:"max_days"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Matches reports whether the policy covers an absence of the event type
+// that is length working days long.
+func (p *AutoApprovePolicy) Matches(eventName string, length float64) bool {
+	if p.EventName != nil && *p.EventName != eventName {
+		return false
+	}
+
+	return p.MaxDays == nil || length <= *p.MaxDays
+}
+
+// MatchingPolicy returns the first of the policies that matches, or nil.
+func MatchingPolicy(policies []AutoApprovePolicy, eventName string, length float64) *AutoApprovePolicy {
+	for i := range policies {
+		if policies[i].Matches(eventName, length) {
+			return &policies[i]
+		}
+	}
+
+	return nil
+}
+
+// AbsenceLength counts the working days of an absence, half days count half.
+func AbsenceLength(days []AbsenceDay) float64 {
+	length := 0.0
+	for _, d := range days {
+		if d.HalfDay {
+			length += 0.5
+		} else {
+			length++
+		}
+	}
+
+	return length
+}
+
+type CreateAutoApprovePolicy struct {
+	Name      string   `form:"name"`
+	EventName string   `form:"event_name"`
+	MaxDays   *float64 `form:"max_days"`
+}
+
+type AutoApprovePolicyRepository interface {
+	Create(ctx context.Context, p *AutoApprovePolicy) (*AutoApprovePolicy, error)
+	Delete(ctx context.Context, id int64) error
+	GetAll(ctx context.Context) ([]AutoApprovePolicy, error)
+}
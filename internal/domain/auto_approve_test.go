@@ -0,0 +1,58 @@
+package domain_test
+
+import (
+	"chrono/internal/domain"
+	"testing"
+)
+
+// TestMatchingPolicy checks that policies match by event type and length and
+// that the first matching policy wins.
+func TestMatchingPolicy(t *testing.T) {
+	homeOffice := domain.AutoApprovePolicy{ID: 1, EventName: ptr("homeoffice")}
+	short := domain.AutoApprovePolicy{ID: 2, MaxDays: ptr(1.0)}
+	policies := []domain.AutoApprovePolicy{homeOffice, short}
+
+	tests := []struct {
+		name      string
+		eventName string
+		days      []domain.AbsenceDay
+		expected  int64
+	}{
+		{
+			name:      "event type of any length",
+			eventName: "homeoffice",
+			days:      domain.AbsenceDays(date(2027, 3, 1), date(2027, 3, 5), false, false, nil),
+			expected:  1,
+		},
+		{
+			name:      "single day",
+			eventName: "urlaub",
+			days:      domain.AbsenceDays(date(2027, 3, 1), date(2027, 3, 1), false, false, nil),
+			expected:  2,
+		},
+		{
+			name:      "two half days",
+			eventName: "urlaub",
+			days:      domain.AbsenceDays(date(2027, 3, 1), date(2027, 3, 2), true, true, nil),
+			expected:  2,
+		},
+		{
+			name:      "too long",
+			eventName: "urlaub",
+			days:      domain.AbsenceDays(date(2027, 3, 1), date(2027, 3, 2), false, true, nil),
+			expected:  0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := domain.MatchingPolicy(policies, tc.eventName, domain.AbsenceLength(tc.days))
+			if (got == nil) != (tc.expected == 0) {
+				t.Fatalf("MatchingPolicy() = %v, want policy %v", got, tc.expected)
+			}
+			if got != nil && got.ID != tc.expected {
+				t.Errorf("MatchingPolicy().ID = %v, want %v", got.ID, tc.expected)
+			}
+		})
+	}
+}
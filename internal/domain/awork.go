@@ -44,4 +44,12 @@ type WorkHours struct {
 	Expected float64 `json:"expected"`
 	Holidays float64 `json:"holidays"`
 	Vacation float64 `json:"vacation"`
+	// TimeOff is the time off in lieu, taken off the overtime.
+	TimeOff float64 `json:"time_off"`
+}
+
+// Overtime returns the hours worked beyond the expected ones less the time
+// off in lieu.
+func (h WorkHours) Overtime() float64 {
+	return h.Worked - h.Expected - h.TimeOff
 }
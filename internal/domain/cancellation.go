@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Cancellation asks to withdraw the days of an accepted absence in
+// [StartDate, EndDate]. The days and their vacation are only given back once
+// an approver accepts it.
+type Cancellation struct {
+	ID        int64      `json:"id"`
+	AbsenceID *int64     `json:"absence_id"`
+	UserID    int64      `json:"user_id"`
+	StartDate time.Time  `json:"start_date"`
+	EndDate   time.Time  `json:"end_date"`
+	Reason    *string    `json:"reason"`
+	State     string     `json:"state"`
+	CreatedAt time.Time  `json:"created_at"`
+	DecidedBy *int64     `json:"decided_by"`
+	DecidedAt *time.Time `json:"decided_at"`
+}
+
+func (c *Cancellation) IsPending() bool {
+	return c.State == "pending"
+}
+
+// Overlaps reports whether the cancellation covers any day of [start, end].
+func (c *Cancellation) Overlaps(start, end time.Time) bool {
+	return !c.StartDate.After(end) && !c.EndDate.Before(start)
+}
+
+func (c *Cancellation) RequestMsg(username string, absence string) string {
+	start := c.StartDate.Format(time.DateOnly)
+	end := c.EndDate.Format(time.DateOnly)
+	if start == end {
+		return fmt.Sprintf("%v asked to cancel %v on %v.", username, absence, start)
+	}
+	return fmt.Sprintf("%v asked to cancel %v from %v to %v.", username, absence, start, end)
+}
+
+// CancelledRange returns the absence narrowed to the days it still has after
+// a cancellation. The half day flags are kept for the days that still start or
+// end the absence.
+func (a *Absence) CancelledRange(remaining []Event) Absence {
+	left := *a
+	if len(remaining) == 0 {
+		return left
+	}
+
+	first := truncateDay(remaining[0].ScheduledAt)
+	last := truncateDay(remaining[len(remaining)-1].ScheduledAt)
+	left.HalfDayStart = a.HalfDayStart && first.Equal(truncateDay(a.StartDate))
+	left.HalfDayEnd = a.HalfDayEnd && last.Equal(truncateDay(a.EndDate))
+	left.StartDate = first
+	left.EndDate = last
+
+	return left
+}
+
+type CreateCancellation struct {
+	StartDate string `form:"start_date"`
+	EndDate   string `form:"end_date"`
+	Reason    string `form:"reason"`
+}
+
+type UpdateCancellation struct {
+	State  string `form:"state"`
+	Reason string `form:"reason"`
+}
+
+type CancellationRepository interface {
+	Create(ctx context.Context, c *Cancellation) (*Cancellation, error)
+	// Accept marks the cancellation as accepted, deletes the days of the
+	// absence in its range and stores the vacation tokens in a single
+	// transaction. The absence is updated to the given range, or deleted if
+	// absence is nil.
+	Accept(
+		ctx context.Context,
+		c *Cancellation,
+		editorId int64,
+		absence *Absence,
+		tokens []CreateVacationToken,
+	) (*Cancellation, error)
+	Decline(ctx context.Context, id int64, editorId int64) (*Cancellation, error)
+	GetById(ctx context.Context, id int64) (*Cancellation, error)
+	GetForAbsence(ctx context.Context, absenceId int64) ([]Cancellation, error)
+	GetPending(ctx context.Context) ([]Cancellation, error)
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// CompanyHoliday is a day the company closes besides the public holidays, e.g.
+// Dec 24 or a bridge day. Closures that consume vacation are booked from the
+// vacation of every user that would have worked on the day.
+type CompanyHoliday struct {
+	ID               int64     `json:"id"`
+	Date             time.Time `json:"date"`
+	Name             string    `json:"name"`
+	Weight           float64   `json:"weight"`
+	Region           *string   `json:"region"`
+	ConsumesVacation bool      `json:"consumes_vacation"`
+	CreatedAt        time.Time `json:"created_at"`
+	EventID          *int64    `json:"event_id"`
+	CreatedBy        *int64    `json:"created_by"`
+}
+
+// AppliesTo reports whether the closure applies in the region, closures
+// without a region apply everywhere.
+func (h *CompanyHoliday) AppliesTo(region Region) bool {
+	return h.Region == nil || Region(*h.Region) == region
+}
+
+// Holiday returns the closure as a holiday.
+func (h *CompanyHoliday) Holiday() Holiday {
+	return Holiday{
+		Name:             h.Name,
+		Date:             h.Date,
+		Weight:           h.Weight,
+		ConsumesVacation: h.ConsumesVacation,
+	}
+}
+
+type CreateCompanyHoliday struct {
+	Date             string  `form:"date"`
+	Name             string  `form:"name"`
+	Weight           float64 `form:"weight"`
+	Region           string  `form:"region"`
+	ConsumesVacation bool    `form:"consumes_vacation"`
+}
+
+type CompanyHolidayRepository interface {
+	// Create stores the closure, its holiday event for the bot user and the
+	// vacation tokens linked to that event in a single transaction.
+	Create(
+		ctx context.Context,
+		h *CompanyHoliday,
+		bot *User,
+		tokens []CreateVacationToken,
+	) (*CompanyHoliday, error)
+	// Update changes the closure and its holiday event and stores the vacation
+	// tokens in a single transaction.
+	Update(ctx context.Context, h *CompanyHoliday, tokens []CreateVacationToken) (*CompanyHoliday, error)
+	// Delete removes the closure with its holiday event and stores the vacation
+	// tokens in a single transaction.
+	Delete(ctx context.Context, id int64, tokens []CreateVacationToken) error
+	GetById(ctx context.Context, id int64) (*CompanyHoliday, error)
+	GetInRange(ctx context.Context, start, end time.Time) ([]CompanyHoliday, error)
+}
@@ -0,0 +1,234 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Limits of the German working time act (ArbZG).
+const (
+	MaxDailyWork = 10 * time.Hour
+	MinDailyRest = 11 * time.Hour
+	// MinBreakPart is the shortest interruption that counts as a break.
+	MinBreakPart = 15 * time.Minute
+)
+
+var (
+	ComplianceMaxWork = "max_work"
+	ComplianceBreak   = "break"
+	ComplianceRest    = "rest"
+)
+
+// RequiredBreak returns the break the ArbZG requires for the worked time, 30
+// minutes after 6 hours and 45 minutes after 9 hours.
+func RequiredBreak(worked time.Duration) time.Duration {
+	switch {
+	case worked > 9*time.Hour:
+		return 45 * time.Minute
+	case worked > 6*time.Hour:
+		return 30 * time.Minute
+	}
+	return 0
+}
+
+type ComplianceViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ComplianceDay sums up the timestamps a user started on a day in minutes.
+// Breaks count the recorded breaks and the gaps between the timestamps that
+// last at least MinBreakPart, Deducted is the missing break taken off the
+// worked time by the automatic break deduction. Rest is the time since the
+// end of the previous working day, nil for the first one.
+type ComplianceDay struct {
+	UserID     int64                 `json:"user_id"`
+	Date       time.Time             `json:"date"`
+	Start      time.Time             `json:"start"`
+	End        time.Time             `json:"end"`
+	Worked     int64                 `json:"worked_minutes"`
+	Break      int64                 `json:"break_minutes"`
+	Deducted   int64                 `json:"deducted_minutes"`
+	Rest       *int64                `json:"rest_minutes"`
+	Violations []ComplianceViolation `json:"violations"`
+}
+
+// IsCompliant reports whether the day breaks none of the rules.
+func (d *ComplianceDay) IsCompliant() bool {
+	return len(d.Violations) == 0
+}
+
+// CheckCompliance groups the finished timestamps per user and the calendar day
+// in loc they started on and flags the days with more than MaxDailyWork of work, less
+// break than RequiredBreak or less than MinDailyRest since the previous
+// working day. With deduct the missing break is taken off the worked time
+// instead of being flagged. The days are sorted by user and date.
+func CheckCompliance(
+	timestamps []Timestamp,
+	breaks []TimestampBreak,
+	deduct bool,
+	loc *time.Location,
+) []ComplianceDay {
+	byTimestamp := map[int64][]TimestampBreak{}
+	for _, b := range breaks {
+		if b.EndTime != nil {
+			byTimestamp[b.TimestampID] = append(byTimestamp[b.TimestampID], b)
+		}
+	}
+
+	type key struct {
+		user int64
+		day  time.Time
+	}
+	grouped := map[key][]Timestamp{}
+	for _, t := range timestamps {
+		if t.EndTime == nil || !t.EndTime.After(t.StartTime) {
+			continue
+		}
+		start := t.StartTime.In(loc)
+		day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+		k := key{t.UserID, day}
+		grouped[k] = append(grouped[k], t)
+	}
+
+	days := make([]ComplianceDay, 0, len(grouped))
+	for k, ts := range grouped {
+		days = append(days, workDay(k.user, k.day, ts, byTimestamp, deduct))
+	}
+	slices.SortFunc(days, func(a, b ComplianceDay) int {
+		if c := cmp.Compare(a.UserID, b.UserID); c != 0 {
+			return c
+		}
+		return a.Date.Compare(b.Date)
+	})
+
+	for i := range days {
+		if i > 0 && days[i-1].UserID == days[i].UserID {
+			rest := days[i].Start.Sub(days[i-1].End)
+			restMin := minutes(rest)
+			days[i].Rest = &restMin
+			if rest < MinDailyRest {
+				days[i].Violations = append(days[i].Violations, ComplianceViolation{
+					Rule: ComplianceRest,
+					Message: fmt.Sprintf(
+						"rested %v since the previous working day, at least %v are required",
+						formatDuration(rest),
+						formatDuration(MinDailyRest),
+					),
+				})
+			}
+		}
+	}
+
+	return days
+}
+
+// workDay sums up the timestamps of a user on a day and checks the limits
+// that only depend on the day itself.
+func workDay(
+	userId int64,
+	date time.Time,
+	timestamps []Timestamp,
+	breaks map[int64][]TimestampBreak,
+	deduct bool,
+) ComplianceDay {
+	slices.SortFunc(timestamps, func(a, b Timestamp) int {
+		return a.StartTime.Compare(b.StartTime)
+	})
+
+	day := ComplianceDay{
+		UserID:     userId,
+		Date:       date,
+		Start:      timestamps[0].StartTime,
+		End:        *timestamps[0].EndTime,
+		Violations: []ComplianceViolation{},
+	}
+
+	var worked, taken, deducted time.Duration
+	for i, t := range timestamps {
+		worked += t.EndTime.Sub(t.StartTime)
+		if t.EndTime.After(day.End) {
+			day.End = *t.EndTime
+		}
+
+		for _, b := range breaks[t.ID] {
+			start := maxTime(b.StartTime, t.StartTime)
+			end := minTime(*b.EndTime, *t.EndTime)
+			if !end.After(start) {
+				continue
+			}
+			worked -= end.Sub(start)
+			if end.Sub(start) >= MinBreakPart {
+				taken += end.Sub(start)
+			}
+		}
+
+		if i > 0 {
+			gap := t.StartTime.Sub(*timestamps[i-1].EndTime)
+			if gap >= MinBreakPart {
+				taken += gap
+			}
+		}
+	}
+
+	required := RequiredBreak(worked)
+	if missing := required - taken; missing > 0 {
+		if deduct {
+			deducted = missing
+			worked -= missing
+		} else {
+			day.Violations = append(day.Violations, ComplianceViolation{
+				Rule: ComplianceBreak,
+				Message: fmt.Sprintf(
+					"took %v of break after %v of work, %v are required",
+					formatDuration(taken),
+					formatDuration(worked),
+					formatDuration(required),
+				),
+			})
+		}
+	}
+
+	if worked > MaxDailyWork {
+		day.Violations = append(day.Violations, ComplianceViolation{
+			Rule: ComplianceMaxWork,
+			Message: fmt.Sprintf(
+				"worked %v, at most %v are allowed",
+				formatDuration(worked),
+				formatDuration(MaxDailyWork),
+			),
+		})
+	}
+
+	day.Worked = minutes(worked)
+	day.Break = minutes(taken)
+	day.Deducted = minutes(deducted)
+
+	return day
+}
+
+func minutes(d time.Duration) int64 {
+	return int64(d / time.Minute)
+}
+
+// formatDuration formats the duration as hours and minutes, like 9h05m.
+func formatDuration(d time.Duration) string {
+	d = d.Truncate(time.Minute)
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
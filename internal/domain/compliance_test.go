@@ -0,0 +1,133 @@
+package domain_test
+
+import (
+	"chrono/internal/domain"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestCheckCompliance checks the daily limits of the ArbZG with recorded
+// breaks, gaps between timestamps and the automatic break deduction.
+func TestCheckCompliance(t *testing.T) {
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2027, 3, day, hour, min, 0, 0, time.UTC)
+	}
+	stamp := func(id int64, start, end time.Time) domain.Timestamp {
+		return domain.Timestamp{ID: id, UserID: 1, StartTime: start, EndTime: &end}
+	}
+	pause := func(id int64, start, end time.Time) domain.TimestampBreak {
+		return domain.TimestampBreak{TimestampID: id, StartTime: start, EndTime: &end}
+	}
+
+	tests := []struct {
+		name       string
+		timestamps []domain.Timestamp
+		breaks     []domain.TimestampBreak
+		deduct     bool
+		loc        *time.Location
+		worked     int64
+		expected   []string
+	}{
+		{
+			name:       "short day needs no break",
+			timestamps: []domain.Timestamp{stamp(1, at(1, 8, 0), at(1, 14, 0))},
+			worked:     360,
+			expected:   []string{},
+		},
+		{
+			name:       "missing break after 6h",
+			timestamps: []domain.Timestamp{stamp(1, at(1, 8, 0), at(1, 14, 30))},
+			worked:     390,
+			expected:   []string{domain.ComplianceBreak},
+		},
+		{
+			name:       "recorded break",
+			timestamps: []domain.Timestamp{stamp(1, at(1, 8, 0), at(1, 15, 0))},
+			breaks:     []domain.TimestampBreak{pause(1, at(1, 12, 0), at(1, 12, 30))},
+			worked:     390,
+			expected:   []string{},
+		},
+		{
+			name: "gap between timestamps counts",
+			timestamps: []domain.Timestamp{
+				stamp(1, at(1, 8, 0), at(1, 12, 0)),
+				stamp(2, at(1, 12, 30), at(1, 15, 0)),
+			},
+			worked:   390,
+			expected: []string{},
+		},
+		{
+			name:       "breaks below 15 minutes do not count",
+			timestamps: []domain.Timestamp{stamp(1, at(1, 8, 0), at(1, 15, 0))},
+			breaks: []domain.TimestampBreak{
+				pause(1, at(1, 10, 0), at(1, 10, 10)),
+				pause(1, at(1, 12, 0), at(1, 12, 20)),
+			},
+			worked:   390,
+			expected: []string{domain.ComplianceBreak},
+		},
+		{
+			name:       "45 minutes after 9h",
+			timestamps: []domain.Timestamp{stamp(1, at(1, 7, 0), at(1, 17, 0))},
+			breaks:     []domain.TimestampBreak{pause(1, at(1, 12, 0), at(1, 12, 30))},
+			worked:     570,
+			expected:   []string{domain.ComplianceBreak},
+		},
+		{
+			name:       "more than 10h",
+			timestamps: []domain.Timestamp{stamp(1, at(1, 6, 0), at(1, 17, 0))},
+			breaks:     []domain.TimestampBreak{pause(1, at(1, 12, 0), at(1, 12, 45))},
+			worked:     615,
+			expected:   []string{domain.ComplianceMaxWork},
+		},
+		{
+			name:       "deduction takes the missing break",
+			timestamps: []domain.Timestamp{stamp(1, at(1, 8, 0), at(1, 15, 0))},
+			breaks:     []domain.TimestampBreak{pause(1, at(1, 12, 0), at(1, 12, 15))},
+			deduct:     true,
+			worked:     390,
+			expected:   []string{},
+		},
+		{
+			name: "short rest",
+			timestamps: []domain.Timestamp{
+				stamp(1, at(1, 14, 0), at(1, 20, 0)),
+				stamp(2, at(2, 6, 0), at(2, 12, 0)),
+			},
+			worked:   360,
+			expected: []string{domain.ComplianceRest},
+		},
+		{
+			name: "days follow the local calendar",
+			timestamps: []domain.Timestamp{
+				stamp(1, at(1, 22, 30), at(1, 23, 30)),
+				stamp(2, at(2, 6, 0), at(2, 11, 0)),
+			},
+			loc:      time.FixedZone("CEST", 2*60*60),
+			worked:   360,
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.loc == nil {
+				tc.loc = time.UTC
+			}
+			days := domain.CheckCompliance(tc.timestamps, tc.breaks, tc.deduct, tc.loc)
+			last := days[len(days)-1]
+			if last.Worked != tc.worked {
+				t.Errorf("Worked = %v, want %v", last.Worked, tc.worked)
+			}
+
+			rules := []string{}
+			for _, v := range last.Violations {
+				rules = append(rules, v.Rule)
+			}
+			if !slices.Equal(rules, tc.expected) {
+				t.Errorf("Violations = %v, want %v", last.Violations, tc.expected)
+			}
+		})
+	}
+}
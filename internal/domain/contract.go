@@ -0,0 +1,251 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WeekSchedule holds the working hours of each weekday, indexed by
+// time.Weekday.
+type WeekSchedule [7]float64
+
+// DefaultWeekSchedule spreads the hours per day over Monday to Friday.
+func DefaultWeekSchedule(hours float64) WeekSchedule {
+	return WeekSchedule{0, hours, hours, hours, hours, hours, 0}
+}
+
+// ParseWeekSchedule parses the hours from Monday to Sunday separated by
+// commas, e.g. "8,8,8,8,6,0,0".
+func ParseWeekSchedule(s string) (WeekSchedule, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 7 {
+		return WeekSchedule{}, fmt.Errorf("schedule needs hours for 7 days, got %v", len(parts))
+	}
+
+	w := WeekSchedule{}
+	for i, p := range parts {
+		hours, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil || hours < 0 || hours > 24 {
+			return WeekSchedule{}, fmt.Errorf("invalid hours %q in schedule", p)
+		}
+		w[(i+1)%7] = hours
+	}
+
+	return w, nil
+}
+
+// String formats the schedule from Monday to Sunday as parsed by
+// ParseWeekSchedule.
+func (w WeekSchedule) String() string {
+	parts := make([]string, 7)
+	for i := range parts {
+		parts[i] = strconv.FormatFloat(w[(i+1)%7], 'f', -1, 64)
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// Hours returns the scheduled hours on the weekday of the given day.
+func (w WeekSchedule) Hours(day time.Time) float64 {
+	return w[day.Weekday()]
+}
+
+// Workdays counts the weekdays with scheduled hours.
+func (w WeekSchedule) Workdays() float64 {
+	days := 0.0
+	for _, h := range w {
+		if h > 0 {
+			days++
+		}
+	}
+
+	return days
+}
+
+// Total sums the scheduled hours of the week.
+func (w WeekSchedule) Total() float64 {
+	total := 0.0
+	for _, h := range w {
+		total += h
+	}
+
+	return total
+}
+
+// UserContract holds the working terms of a user for a period, ValidTo is
+// inclusive and nil for an open ended contract.
+type UserContract struct {
+	ID           int64      `json:"id"`
+	ValidFrom    time.Time  `json:"valid_from"`
+	ValidTo      *time.Time `json:"valid_to"`
+	WorkdayHours float64    `json:"workday_hours"`
+	WorkdaysWeek float64    `json:"workdays_week"`
+	VacationDays int64      `json:"vacation_days"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UserID       int64      `json:"user_id"`
+	CreatedBy    *int64     `json:"created_by"`
+	Schedule     *string    `json:"schedule"`
+}
+
+// WeekSchedule returns the hours per weekday of the contract. Contracts
+// without a schedule work WorkdayHours from Monday to Friday.
+func (c *UserContract) WeekSchedule() WeekSchedule {
+	if c.Schedule != nil {
+		if w, err := ParseWeekSchedule(*c.Schedule); err == nil {
+			return w
+		}
+	}
+
+	return DefaultWeekSchedule(c.WorkdayHours)
+}
+
+// Contains reports whether the contract is in effect on the given day.
+func (c *UserContract) Contains(day time.Time) bool {
+	day = truncateDay(day)
+	if day.Before(truncateDay(c.ValidFrom)) {
+		return false
+	}
+
+	return c.ValidTo == nil || !day.After(truncateDay(*c.ValidTo))
+}
+
+// ContractAt returns the contract in effect on the given day. Days that are
+// not covered by any contract fall back to the terms stored on the user.
+func (u *User) ContractAt(contracts []UserContract, day time.Time) UserContract {
+	for _, c := range contracts {
+		if c.Contains(day) {
+			return c
+		}
+	}
+
+	return UserContract{
+		ValidFrom:    truncateDay(day),
+		WorkdayHours: u.WorkdayHours,
+		WorkdaysWeek: u.WorkdaysWeek,
+		VacationDays: u.VacationDays,
+		UserID:       u.ID,
+		Schedule:     u.Schedule,
+	}
+}
+
+// WorksOn reports whether the user is employed on the day and the contract in
+// effect schedules work on it.
+func (u *User) WorksOn(contracts []UserContract, day time.Time) bool {
+	if !u.EmployedOn(day) {
+		return false
+	}
+	contract := u.ContractAt(contracts, day)
+
+	return contract.WeekSchedule().Hours(day) > 0
+}
+
+// ScheduledDays drops the days on which the contract in effect schedules no
+// work, such as the free weekdays of part time users.
+func (u *User) ScheduledDays(contracts []UserContract, days []AbsenceDay) []AbsenceDay {
+	scheduled := make([]AbsenceDay, 0, len(days))
+	for _, d := range days {
+		contract := u.ContractAt(contracts, d.Date)
+		if contract.WeekSchedule().Hours(d.Date) > 0 {
+			scheduled = append(scheduled, d)
+		}
+	}
+
+	return scheduled
+}
+
+// ExpectedWorkHours computes the work hours in [start, end] from the schedule
+// of the contract in effect on each day. Holidays are not expected, half day
+// holidays only for half of the day and holidays that consume vacation count
+// as vacation. Accepted events reduce the scheduled hours of their day by
+// their weight if their type consumes vacation or counts as worked, events
+// of types that consume overtime move them to the time off in lieu.
+func (u *User) ExpectedWorkHours(
+	contracts []UserContract,
+	start, end time.Time,
+	holidays []Holiday,
+	events []Event,
+	types EventTypes,
+) WorkHours {
+	holiday := make(map[time.Time]Holiday, len(holidays))
+	for _, h := range holidays {
+		day := truncateDay(h.Date)
+		if h.Weight > holiday[day].Weight {
+			holiday[day] = h
+		}
+	}
+
+	vacation := map[time.Time]float64{}
+	credited := map[time.Time]float64{}
+	timeOff := map[time.Time]float64{}
+	for _, e := range events {
+		if !e.IsAccepted() || e.ScheduledAt.Before(start) || e.ScheduledAt.After(end) {
+			continue
+		}
+
+		typ := types.Get(e.Name)
+		day := truncateDay(e.ScheduledAt)
+		if typ.ConsumesVacation {
+			vacation[day] += e.Days(typ)
+		}
+		if typ.CountsAsWorked {
+			credited[day] += e.Days(typ)
+		}
+		if typ.ConsumesOvertime {
+			timeOff[day] += e.Days(typ)
+		}
+	}
+
+	hours := WorkHours{}
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		day := truncateDay(d)
+		contract := u.ContractAt(contracts, day)
+		dayHours := contract.WeekSchedule().Hours(day)
+		if dayHours == 0 {
+			continue
+		}
+
+		h := holiday[day]
+		if h.Weight >= 1 && !h.ConsumesVacation {
+			hours.Holidays += dayHours
+			continue
+		}
+
+		off := h.Weight
+		if h.ConsumesVacation {
+			// vacation that was taken on the day already covers the closure
+			off = max(0, min(off, 1-vacation[day]))
+			hours.Vacation += off * dayHours
+		} else {
+			hours.Holidays += off * dayHours
+		}
+
+		// time off in lieu only covers what the holiday and vacation leave
+		lieu := max(0, min(timeOff[day], 1-off-vacation[day]-credited[day]))
+		hours.Expected += (1 - off - vacation[day] - credited[day] - lieu) * dayHours
+		hours.Vacation += vacation[day] * dayHours
+		hours.TimeOff += lieu * dayHours
+	}
+
+	return hours
+}
+
+type CreateUserContract struct {
+	ValidFrom    string  `form:"valid_from"`
+	ValidTo      string  `form:"valid_to"`
+	WorkdayHours float64 `form:"workday_hours"`
+	WorkdaysWeek float64 `form:"workdays_week"`
+	VacationDays int64   `form:"vacation_days"`
+	Schedule     string  `form:"schedule"`
+}
+
+type UserContractRepository interface {
+	// Save inserts the contracts without an id and updates the others in a
+	// single transaction.
+	Save(ctx context.Context, contracts []UserContract) ([]UserContract, error)
+	GetForUser(ctx context.Context, userId int64) ([]UserContract, error)
+	// GetAll returns the contracts of all users grouped by user id.
+	GetAll(ctx context.Context) (map[int64][]UserContract, error)
+}
@@ -0,0 +1,181 @@
+package domain_test
+
+import (
+	"chrono/internal/domain"
+	"testing"
+)
+
+// TestExpectedWorkHours checks that every weekday uses the hours of the
+// contract in effect and that holidays, closures, vacation, sick days and
+// time off in lieu are applied.
+func TestExpectedWorkHours(t *testing.T) {
+	june := date(2026, 6, 30)
+	contracts := []domain.UserContract{
+		{ValidFrom: date(1970, 1, 1), ValidTo: &june, WorkdayHours: 8},
+		{ValidFrom: date(2026, 7, 1), WorkdayHours: 6},
+	}
+	user := domain.User{WorkdayHours: 4}
+	// monday to wednesday and a short saturday
+	partTime := "8,8,8,0,0,6,0"
+	types := domain.EventTypes{
+		"urlaub": {Name: "urlaub", Weight: 1.0, ConsumesVacation: true},
+		"krank":  {Name: "krank", Weight: 1.0, CountsAsWorked: true},
+		"ueberstundenabbau": {
+			Name:             "ueberstundenabbau",
+			Weight:           1.0,
+			ConsumesOvertime: true,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		contracts []domain.UserContract
+		holidays  []domain.Holiday
+		events    []domain.Event
+		expected  domain.WorkHours
+	}{
+		{
+			name:      "contract change",
+			contracts: contracts,
+			expected:  domain.WorkHours{Expected: 2*8 + 3*6},
+		},
+		{
+			name:     "without contracts",
+			expected: domain.WorkHours{Expected: 5 * 4},
+		},
+		{
+			name:      "holiday",
+			contracts: contracts,
+			holidays:  []domain.Holiday{{Date: date(2026, 7, 1), Weight: 1}},
+			expected:  domain.WorkHours{Expected: 2*8 + 2*6, Holidays: 6},
+		},
+		{
+			name:      "half day holiday",
+			contracts: contracts,
+			holidays:  []domain.Holiday{{Date: date(2026, 7, 3), Weight: 0.5}},
+			expected:  domain.WorkHours{Expected: 2*8 + 2*6 + 3, Holidays: 3},
+		},
+		{
+			name:      "closure consuming vacation",
+			contracts: contracts,
+			holidays: []domain.Holiday{
+				{Date: date(2026, 6, 30), Weight: 1, ConsumesVacation: true},
+			},
+			expected: domain.WorkHours{Expected: 8 + 3*6, Vacation: 8},
+		},
+		{
+			name:      "closure on vacation day",
+			contracts: contracts,
+			holidays: []domain.Holiday{
+				{Date: date(2026, 6, 29), Weight: 1, ConsumesVacation: true},
+			},
+			events: []domain.Event{
+				{Name: "urlaub", ScheduledAt: date(2026, 6, 29), State: "accepted"},
+			},
+			expected: domain.WorkHours{Expected: 8 + 3*6, Vacation: 8},
+		},
+		{
+			name:      "vacation and sick day",
+			contracts: contracts,
+			events: []domain.Event{
+				{Name: "urlaub", ScheduledAt: date(2026, 6, 29), State: "accepted"},
+				{Name: "krank", ScheduledAt: date(2026, 7, 2), State: "accepted", HalfDay: true},
+				{Name: "urlaub", ScheduledAt: date(2026, 7, 1), State: "pending"},
+			},
+			expected: domain.WorkHours{Expected: 8 + 2*6 + 3, Vacation: 8},
+		},
+		{
+			name: "schedule",
+			contracts: []domain.UserContract{
+				{ValidFrom: date(1970, 1, 1), Schedule: &partTime},
+			},
+			events: []domain.Event{
+				{Name: "urlaub", ScheduledAt: date(2026, 6, 29), State: "accepted"},
+				{Name: "krank", ScheduledAt: date(2026, 7, 2), State: "accepted"},
+			},
+			expected: domain.WorkHours{Expected: 8 + 8 + 6, Vacation: 8},
+		},
+		{
+			name:      "time off in lieu",
+			contracts: contracts,
+			events: []domain.Event{
+				{Name: "ueberstundenabbau", ScheduledAt: date(2026, 6, 30), State: "accepted"},
+				{Name: "ueberstundenabbau", ScheduledAt: date(2026, 7, 2), State: "accepted", HalfDay: true},
+				{Name: "ueberstundenabbau", ScheduledAt: date(2026, 7, 3), State: "pending"},
+			},
+			expected: domain.WorkHours{Expected: 8 + 6 + 3 + 6, TimeOff: 8 + 3},
+		},
+		{
+			name:      "time off in lieu on holidays",
+			contracts: contracts,
+			holidays: []domain.Holiday{
+				{Date: date(2026, 7, 1), Weight: 1},
+				{Date: date(2026, 7, 3), Weight: 0.5},
+			},
+			events: []domain.Event{
+				{Name: "ueberstundenabbau", ScheduledAt: date(2026, 7, 1), State: "accepted"},
+				{Name: "ueberstundenabbau", ScheduledAt: date(2026, 7, 3), State: "accepted"},
+			},
+			expected: domain.WorkHours{Expected: 2*8 + 6, Holidays: 6 + 3, TimeOff: 3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := user.ExpectedWorkHours(
+				tc.contracts,
+				date(2026, 6, 27),
+				date(2026, 7, 3),
+				tc.holidays,
+				tc.events,
+				types,
+			)
+			if got != tc.expected {
+				t.Errorf("ExpectedWorkHours() = %+v, want %+v", got, tc.expected)
+			}
+		})
+	}
+}
+
+// TestParseWeekSchedule checks parsing and formatting of weekly schedules.
+func TestParseWeekSchedule(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		workdays float64
+		total    float64
+		err      bool
+	}{
+		{input: "8,8,8,8,8,0,0", expected: "8,8,8,8,8,0,0", workdays: 5, total: 40},
+		{input: "8, 8, 8, 8, 6.5, 0, 0", expected: "8,8,8,8,6.5,0,0", workdays: 5, total: 38.5},
+		{input: "0,0,0,0,0,4,4", expected: "0,0,0,0,0,4,4", workdays: 2, total: 8},
+		{input: "8,8,8,8,8", err: true},
+		{input: "8,8,8,8,8,0,x", err: true},
+		{input: "8,8,8,8,25,0,0", err: true},
+		{input: "8,8,-1,8,8,0,0", err: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			w, err := domain.ParseWeekSchedule(tc.input)
+			if tc.err {
+				if err == nil {
+					t.Errorf("ParseWeekSchedule(%q) expected an error", tc.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseWeekSchedule(%q) unexpected error: %v", tc.input, err)
+			}
+			if w.String() != tc.expected {
+				t.Errorf("String() = %q, want %q", w.String(), tc.expected)
+			}
+			if w.Workdays() != tc.workdays {
+				t.Errorf("Workdays() = %v, want %v", w.Workdays(), tc.workdays)
+			}
+			if w.Total() != tc.total {
+				t.Errorf("Total() = %v, want %v", w.Total(), tc.total)
+			}
+		})
+	}
+}
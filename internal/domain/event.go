@@ -3,12 +3,9 @@ package domain
 import (
 	"context"
 	"fmt"
-	"slices"
 	"time"
 )
 
-var vacationNames = []string{"urlaub", "urlaub halbtags"}
-
 type Event struct {
 	ID          int64     `json:"id"`
 	ScheduledAt time.Time `json:"scheduled_at"`
@@ -17,16 +14,23 @@ type Event struct {
 	CreatedAt   time.Time `json:"created_at"`
 	EditedAt    time.Time `json:"edited_at"`
 	UserID      int64     `json:"user_id"`
-}
-
-func (e *Event) IsVacation() bool {
-	return slices.Contains(vacationNames, e.Name)
+	HalfDay     bool      `json:"half_day"`
+	AbsenceID   *int64    `json:"absence_id"`
+	Region      *string   `json:"region"`
 }
 
 func (e *Event) IsAccepted() bool {
 	return e.State == "accepted"
 }
 
+// Days returns the number of days the event counts for with the given type.
+func (e *Event) Days(t EventType) float64 {
+	if e.HalfDay {
+		return t.Weight * 0.5
+	}
+	return t.Weight
+}
+
 func (e *Event) RequestMsg(username string) string {
 	return fmt.Sprintf("%v sent a new request for %v.", username, e.Name)
 }
@@ -44,7 +48,15 @@ func (e *Event) UpdateMsg(username string, state string) string {
 }
 
 type EventRepository interface {
-	Create(ctx context.Context, data YMDDate, eventType string, user *User) (*Event, error)
+	Create(
+		ctx context.Context,
+		data YMDDate,
+		eventType string,
+		state string,
+		user *User,
+	) (*Event, error)
+	// CreateHoliday stores an accepted holiday of the region for the bot user.
+	CreateHoliday(ctx context.Context, holiday Holiday, region Region, bot *User) (*Event, error)
 	Update(ctx context.Context, eventId int64, state string) (*Event, error)
 	Delete(ctx context.Context, id int64) error
 	GetForDay(ctx context.Context, data YMDDate) ([]Event, error)
@@ -52,16 +64,34 @@ type EventRepository interface {
 		ctx context.Context,
 		data YMDate,
 		botName string,
+		region Region,
 		userFiler *User,
 		eventFilter string,
 	) (Month, error)
-	GetForYear(ctx context.Context, year int) ([]EventUser, error)
+	// GetForYear returns the accepted absences that consume vacation and the
+	// holidays of the bot user in the year.
+	GetForYear(ctx context.Context, botName string, year int) ([]EventUser, error)
 	GetPendingForUser(ctx context.Context, userId int64, year int) (int, error)
 	GetUsedVacationForUser(ctx context.Context, userId int64, year int) (float64, error)
 	GetById(ctx context.Context, eventId int64) (*Event, error)
 	// GetInRange(ctx context.Context, userId int64, start, end time.Time) ([]Event, error)
-	UpdateInRange(ctx context.Context, userId int64, state string, start, end time.Time) error
 	GetAllByUserId(ctx context.Context, userId int64) ([]Event, error)
+	// GetAllInRange returns the events of all users in [start, end].
+	GetAllInRange(ctx context.Context, start, end time.Time) ([]Event, error)
+	// GetAbsencesInRange returns the pending and accepted absence days of all
+	// users in [start, end].
+	GetAbsencesInRange(ctx context.Context, start, end time.Time) ([]Event, error)
+	// GetHolidays returns the holidays of the region and the company holidays
+	// of all regions in [start, end], both are owned by the bot user.
+	GetHolidays(
+		ctx context.Context,
+		botName string,
+		region Region,
+		start, end time.Time,
+	) ([]Holiday, error)
+	// TagLegacyHolidays assigns the region to the holidays of the bot that
+	// were stored before holidays had a region.
+	TagLegacyHolidays(ctx context.Context, botName string, region Region) error
 }
 
 type EventUser struct {
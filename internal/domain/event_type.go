@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// SickEventName is the event type used for sick days in the sickness export.
+const SickEventName = "krank"
+
+type EventType struct {
+	ID               int64     `json:"id"`
+	Name             string    `json:"name"`
+	Label            string    `json:"label"`
+	Weight           float64   `json:"weight"`
+	ConsumesVacation bool      `json:"consumes_vacation"`
+	NeedsApproval    bool      `json:"needs_approval"`
+	CountsAsWorked   bool      `json:"counts_as_worked"`
+	Color            string    `json:"color"`
+	CreatedAt        time.Time `json:"created_at"`
+	EditedAt         time.Time `json:"edited_at"`
+	// ConsumesOvertime takes the scheduled hours of accepted days off the
+	// overtime account, like time off in lieu.
+	ConsumesOvertime bool `json:"consumes_overtime"`
+}
+
+// DefaultEventType is used for events whose name is not registered,
+// e.g. the holidays created by the bot user.
+func DefaultEventType(name string) EventType {
+	return EventType{Name: name, Label: name, Weight: 1.0, Color: "#000000"}
+}
+
+func IsValidEventWeight(weight float64) bool {
+	return weight == 1.0 || weight == 0.5
+}
+
+// EventTypes maps event names to their registered type.
+type EventTypes map[string]EventType
+
+func (t EventTypes) Get(name string) EventType {
+	if typ, ok := t[name]; ok {
+		return typ
+	}
+	return DefaultEventType(name)
+}
+
+type EventTypeForm struct {
+	Name             string  `form:"name"`
+	Label            string  `form:"label"`
+	Weight           float64 `form:"weight"`
+	ConsumesVacation bool    `form:"consumes_vacation"`
+	NeedsApproval    bool    `form:"needs_approval"`
+	CountsAsWorked   bool    `form:"counts_as_worked"`
+	ConsumesOvertime bool    `form:"consumes_overtime"`
+	Color            string  `form:"color"`
+}
+
+// PatchEventTypeForm only overwrites the fields that were sent, so the flags
+// are pointers to tell an omitted field from an explicit false.
+type PatchEventTypeForm struct {
+	Label            string  `form:"label"`
+	Weight           float64 `form:"weight"`
+	ConsumesVacation *bool   `form:"consumes_vacation"`
+	NeedsApproval    *bool   `form:"needs_approval"`
+	CountsAsWorked   *bool   `form:"counts_as_worked"`
+	ConsumesOvertime *bool   `form:"consumes_overtime"`
+	Color            string  `form:"color"`
+}
+
+type EventTypeRepository interface {
+	Create(ctx context.Context, t *EventType) (*EventType, error)
+	Update(ctx context.Context, t *EventType) (*EventType, error)
+	Delete(ctx context.Context, id int64) error
+	GetById(ctx context.Context, id int64) (*EventType, error)
+	GetByName(ctx context.Context, name string) (*EventType, error)
+	GetAll(ctx context.Context) ([]EventType, error)
+}
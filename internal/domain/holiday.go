@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Region is the code of a German state (Bundesland) with its own public
+// holidays.
+type Region string
+
+var (
+	RegionBW Region = "BW" // Baden-Württemberg
+	RegionBY Region = "BY" // Bayern
+	RegionBE Region = "BE" // Berlin
+	RegionBB Region = "BB" // Brandenburg
+	RegionHB Region = "HB" // Bremen
+	RegionHH Region = "HH" // Hamburg
+	RegionHE Region = "HE" // Hessen
+	RegionMV Region = "MV" // Mecklenburg-Vorpommern
+	RegionNI Region = "NI" // Niedersachsen
+	RegionNW Region = "NW" // Nordrhein-Westfalen
+	RegionRP Region = "RP" // Rheinland-Pfalz
+	RegionSL Region = "SL" // Saarland
+	RegionSN Region = "SN" // Sachsen
+	RegionST Region = "ST" // Sachsen-Anhalt
+	RegionSH Region = "SH" // Schleswig-Holstein
+	RegionTH Region = "TH" // Thüringen
+)
+
+func Regions() []Region {
+	return []Region{
+		RegionBW, RegionBY, RegionBE, RegionBB, RegionHB, RegionHH, RegionHE, RegionMV,
+		RegionNI, RegionNW, RegionRP, RegionSL, RegionSN, RegionST, RegionSH, RegionTH,
+	}
+}
+
+func IsValidRegion(region Region) bool {
+	return slices.Contains(Regions(), region)
+}
+
+// Holiday is a day off for everyone in a region. Weight 0.5 marks a half day,
+// holidays that consume vacation are booked from the vacation of every user.
+type Holiday struct {
+	Name             string    `json:"name"`
+	Date             time.Time `json:"date"`
+	Weight           float64   `json:"weight"`
+	ConsumesVacation bool      `json:"consumes_vacation"`
+}
+
+// Easter returns Easter Sunday of the year in the Gregorian calendar using the
+// anonymous Gregorian algorithm (Meeus/Jones/Butcher).
+func Easter(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// bussUndBettag is the Wednesday before November 23rd.
+func bussUndBettag(year int) time.Time {
+	d := time.Date(year, time.November, 22, 0, 0, 0, 0, time.UTC)
+	for d.Weekday() != time.Wednesday {
+		d = d.AddDate(0, 0, -1)
+	}
+
+	return d
+}
+
+// HolidaysFor returns the public holidays of the region in the year, sorted
+// by date. Holidays that only apply to single municipalities, e.g. Mariä
+// Himmelfahrt in parts of Bayern or Fronleichnam in parts of Sachsen and
+// Thüringen, are not included.
+func HolidaysFor(year int, region Region) []Holiday {
+	easter := Easter(year)
+	fixed := func(month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	in := func(regions ...Region) bool {
+		return slices.Contains(regions, region)
+	}
+
+	rules := []struct {
+		name  string
+		date  time.Time
+		apply bool
+	}{
+		{"Neujahrstag", fixed(time.January, 1), true},
+		{"Heilige Drei Könige", fixed(time.January, 6), in(RegionBW, RegionBY, RegionST)},
+		{
+			"Internationaler Frauentag",
+			fixed(time.March, 8),
+			(region == RegionBE && year >= 2019) || (region == RegionMV && year >= 2023),
+		},
+		{"Karfreitag", easter.AddDate(0, 0, -2), true},
+		{"Ostersonntag", easter, in(RegionBB)},
+		{"Ostermontag", easter.AddDate(0, 0, 1), true},
+		{"Tag der Arbeit", fixed(time.May, 1), true},
+		{
+			"Tag der Befreiung",
+			fixed(time.May, 8),
+			region == RegionBE && (year == 2020 || year == 2025),
+		},
+		{"Christi Himmelfahrt", easter.AddDate(0, 0, 39), true},
+		{"Pfingstsonntag", easter.AddDate(0, 0, 49), in(RegionBB)},
+		{"Pfingstmontag", easter.AddDate(0, 0, 50), true},
+		{
+			"Fronleichnam",
+			easter.AddDate(0, 0, 60),
+			in(RegionBW, RegionBY, RegionHE, RegionNW, RegionRP, RegionSL),
+		},
+		{"Mariä Himmelfahrt", fixed(time.August, 15), in(RegionSL)},
+		{"Weltkindertag", fixed(time.September, 20), region == RegionTH && year >= 2019},
+		{"Tag der Deutschen Einheit", fixed(time.October, 3), true},
+		{
+			"Reformationstag",
+			fixed(time.October, 31),
+			year == 2017 ||
+				in(RegionBB, RegionMV, RegionSN, RegionST, RegionTH) ||
+				(in(RegionHB, RegionHH, RegionNI, RegionSH) && year >= 2018),
+		},
+		{
+			"Allerheiligen",
+			fixed(time.November, 1),
+			in(RegionBW, RegionBY, RegionNW, RegionRP, RegionSL),
+		},
+		{"Buß- und Bettag", bussUndBettag(year), in(RegionSN)},
+		{"1. Weihnachtstag", fixed(time.December, 25), true},
+		{"2. Weihnachtstag", fixed(time.December, 26), true},
+	}
+
+	holidays := []Holiday{}
+	for _, r := range rules {
+		if r.apply {
+			holidays = append(holidays, Holiday{Name: r.name, Date: r.date, Weight: 1})
+		}
+	}
+
+	slices.SortStableFunc(holidays, func(a, b Holiday) int {
+		return a.Date.Compare(b.Date)
+	})
+
+	return holidays
+}
@@ -0,0 +1,136 @@
+package domain_test
+
+import (
+	"chrono/internal/domain"
+	"testing"
+	"time"
+)
+
+// TestEaster checks Easter Sunday against known dates.
+func TestEaster(t *testing.T) {
+	tests := []struct {
+		year     int
+		expected time.Time
+	}{
+		{year: 1961, expected: date(1961, 4, 2)},
+		{year: 2000, expected: date(2000, 4, 23)},
+		{year: 2019, expected: date(2019, 4, 21)},
+		{year: 2024, expected: date(2024, 3, 31)},
+		{year: 2025, expected: date(2025, 4, 20)},
+		{year: 2026, expected: date(2026, 4, 5)},
+		{year: 2038, expected: date(2038, 4, 25)},
+		{year: 2285, expected: date(2285, 3, 22)}, // earliest possible date
+	}
+
+	for _, tc := range tests {
+		t.Run(funcName(tc.year), func(t *testing.T) {
+			got := domain.Easter(tc.year)
+			if !got.Equal(tc.expected) {
+				t.Errorf("Easter(%d) = %v, want %v", tc.year, got, tc.expected)
+			}
+		})
+	}
+}
+
+// TestHolidaysForCount checks the number of holidays per region.
+func TestHolidaysForCount(t *testing.T) {
+	tests := []struct {
+		year     int
+		region   domain.Region
+		expected int
+	}{
+		{year: 2026, region: domain.RegionBW, expected: 12},
+		{year: 2026, region: domain.RegionBY, expected: 12},
+		{year: 2026, region: domain.RegionBE, expected: 10},
+		{year: 2025, region: domain.RegionBE, expected: 11}, // Tag der Befreiung
+		{year: 2026, region: domain.RegionBB, expected: 12},
+		{year: 2026, region: domain.RegionHB, expected: 10},
+		{year: 2026, region: domain.RegionHH, expected: 10},
+		{year: 2026, region: domain.RegionHE, expected: 10},
+		{year: 2026, region: domain.RegionMV, expected: 11},
+		{year: 2022, region: domain.RegionMV, expected: 10}, // before Frauentag
+		{year: 2026, region: domain.RegionNI, expected: 10},
+		{year: 2026, region: domain.RegionNW, expected: 11},
+		{year: 2026, region: domain.RegionRP, expected: 11},
+		{year: 2026, region: domain.RegionSL, expected: 12},
+		{year: 2026, region: domain.RegionSN, expected: 11},
+		{year: 2026, region: domain.RegionST, expected: 11},
+		{year: 2026, region: domain.RegionSH, expected: 10},
+		{year: 2026, region: domain.RegionTH, expected: 11},
+		{year: 2017, region: domain.RegionBW, expected: 13}, // Reformationstag everywhere
+	}
+
+	for _, tc := range tests {
+		t.Run(funcName(string(tc.region), tc.year), func(t *testing.T) {
+			got := domain.HolidaysFor(tc.year, tc.region)
+			if len(got) != tc.expected {
+				t.Errorf("len(HolidaysFor(%d, %s)) = %d, want %d", tc.year, tc.region, len(got), tc.expected)
+			}
+		})
+	}
+}
+
+// TestHolidaysForDates checks the dates of the movable and regional holidays.
+func TestHolidaysForDates(t *testing.T) {
+	tests := []struct {
+		year     int
+		region   domain.Region
+		name     string
+		expected time.Time
+	}{
+		{year: 2026, region: domain.RegionBW, name: "Karfreitag", expected: date(2026, 4, 3)},
+		{year: 2026, region: domain.RegionBW, name: "Ostermontag", expected: date(2026, 4, 6)},
+		{year: 2026, region: domain.RegionBW, name: "Christi Himmelfahrt", expected: date(2026, 5, 14)},
+		{year: 2026, region: domain.RegionBW, name: "Pfingstmontag", expected: date(2026, 5, 25)},
+		{year: 2026, region: domain.RegionBW, name: "Fronleichnam", expected: date(2026, 6, 4)},
+		{year: 2026, region: domain.RegionBW, name: "Heilige Drei Könige", expected: date(2026, 1, 6)},
+		{year: 2026, region: domain.RegionBB, name: "Pfingstsonntag", expected: date(2026, 5, 24)},
+		{year: 2023, region: domain.RegionSN, name: "Buß- und Bettag", expected: date(2023, 11, 22)},
+		{year: 2024, region: domain.RegionSN, name: "Buß- und Bettag", expected: date(2024, 11, 20)},
+		{year: 2026, region: domain.RegionSN, name: "Buß- und Bettag", expected: date(2026, 11, 18)},
+		{year: 2026, region: domain.RegionTH, name: "Weltkindertag", expected: date(2026, 9, 20)},
+		{year: 2026, region: domain.RegionBE, name: "Internationaler Frauentag", expected: date(2026, 3, 8)},
+		{year: 2026, region: domain.RegionNW, name: "Allerheiligen", expected: date(2026, 11, 1)},
+		{year: 2026, region: domain.RegionSL, name: "Mariä Himmelfahrt", expected: date(2026, 8, 15)},
+	}
+
+	for _, tc := range tests {
+		t.Run(funcName(string(tc.region), tc.name, tc.year), func(t *testing.T) {
+			for _, h := range domain.HolidaysFor(tc.year, tc.region) {
+				if h.Name == tc.name {
+					if !h.Date.Equal(tc.expected) {
+						t.Errorf("%s in %d = %v, want %v", tc.name, tc.year, h.Date, tc.expected)
+					}
+					return
+				}
+			}
+			t.Errorf("HolidaysFor(%d, %s) does not contain %s", tc.year, tc.region, tc.name)
+		})
+	}
+}
+
+// TestHolidaysForRegional checks that regional holidays are not applied to
+// other regions.
+func TestHolidaysForRegional(t *testing.T) {
+	tests := []struct {
+		region domain.Region
+		name   string
+	}{
+		{region: domain.RegionBW, name: "Reformationstag"},
+		{region: domain.RegionBW, name: "Buß- und Bettag"},
+		{region: domain.RegionNI, name: "Fronleichnam"},
+		{region: domain.RegionBY, name: "Mariä Himmelfahrt"},
+		{region: domain.RegionBW, name: "Ostersonntag"},
+		{region: domain.RegionHE, name: "Allerheiligen"},
+	}
+
+	for _, tc := range tests {
+		t.Run(funcName(string(tc.region), tc.name), func(t *testing.T) {
+			for _, h := range domain.HolidaysFor(2026, tc.region) {
+				if h.Name == tc.name {
+					t.Errorf("HolidaysFor(2026, %s) contains %s", tc.region, tc.name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Job is a recurring task of the scheduler that runs once per interval.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// JobRun records a single run of a job, Error is nil for successful runs.
+type JobRun struct {
+	ID         int64      `json:"id"`
+	Job        string     `json:"job"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at"`
+	Error      *string    `json:"error"`
+}
+
+// JobStatus describes a registered job with its last run, LastRun is nil if
+// the job never ran.
+type JobStatus struct {
+	Name     string  `json:"name"`
+	Interval string  `json:"interval"`
+	LastRun  *JobRun `json:"last_run"`
+}
+
+type JobRunRepository interface {
+	Start(ctx context.Context, job string) (*JobRun, error)
+	Finish(ctx context.Context, id int64, jobErr error) (*JobRun, error)
+	GetLast(ctx context.Context, job string) (*JobRun, error)
+	// GetLastForAll returns the last run of every job that ran before.
+	GetLastForAll(ctx context.Context) ([]JobRun, error)
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 func BatchUpdateMsg(username string, state string) string {
 	return fmt.Sprintf("%v %v your request.", username, state)
@@ -9,3 +12,49 @@ func BatchUpdateMsg(username string, state string) string {
 func BatchUpdateReasonMsg(username string, state string, reason string) string {
 	return fmt.Sprintf("%v %v your request: %v.", username, state, reason)
 }
+
+func LeadApprovedMsg(username string) string {
+	return fmt.Sprintf("%v approved your request, it now awaits the final approval.", username)
+}
+
+func CancellationDecisionMsg(username string, state string, reason string) string {
+	if reason != "" {
+		return fmt.Sprintf("%v %v your cancellation: %v.", username, state, reason)
+	}
+	return fmt.Sprintf("%v %v your cancellation.", username, state)
+}
+
+func CorrectionDecisionMsg(username string, state string, reason string) string {
+	if reason != "" {
+		return fmt.Sprintf("%v %v your timestamp correction: %v.", username, state, reason)
+	}
+	return fmt.Sprintf("%v %v your timestamp correction.", username, state)
+}
+
+func ReminderMsg(username string, days int) string {
+	return fmt.Sprintf("The request of %v has been waiting for your decision for %v days.", username, days)
+}
+
+func EscalationMsg(username string, days int) string {
+	return fmt.Sprintf(
+		"The request of %v has been waiting for %v days and was escalated to you.",
+		username,
+		days,
+	)
+}
+
+func RequestWithMessageMsg(msg string, message string) string {
+	return fmt.Sprintf("%v \"%v\"", msg, message)
+}
+
+func CommentMsg(username string, body string) string {
+	return fmt.Sprintf("%v commented on a request: %v", username, body)
+}
+
+func TimerAutoClosedMsg(start time.Time, end time.Time) string {
+	return fmt.Sprintf(
+		"Your timer started %v was still running and has been stopped at %v. Please file a correction if you worked a different time.",
+		start.Format("2006-01-02 15:04"),
+		end.Format("15:04"),
+	)
+}
@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+var (
+	// OvertimeOpening is the balance the account starts with on its date.
+	OvertimeOpening = "opening"
+	// OvertimeAdjustment corrects the balance by a signed number of hours.
+	OvertimeAdjustment = "adjustment"
+	// OvertimePayout pays out overtime.
+	OvertimePayout = "payout"
+	// OvertimeTimeOff takes overtime off in lieu.
+	OvertimeTimeOff = "time_off"
+)
+
+// OvertimeBooking changes the overtime account of a user by Hours on Date
+// besides the worked hours, payouts and time off in lieu are negative.
+type OvertimeBooking struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"user_id"`
+	Date      time.Time `json:"date"`
+	Kind      string    `json:"kind"`
+	Hours     float64   `json:"hours"`
+	Reason    *string   `json:"reason"`
+	CreatedBy *int64    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateOvertimeBooking struct {
+	Date   string  `form:"date"`
+	Kind   string  `form:"kind"`
+	Hours  float64 `form:"hours"`
+	Reason string  `form:"reason"`
+}
+
+// Booking validates the form and returns the booking for the user. Payouts
+// and time off in lieu are given as positive hours and taken off the
+// balance, adjustments need a reason.
+func (f CreateOvertimeBooking) Booking(userId int64, createdBy int64) (OvertimeBooking, error) {
+	date, err := time.Parse(time.DateOnly, f.Date)
+	if err != nil {
+		return OvertimeBooking{}, fmt.Errorf("invalid date %q", f.Date)
+	}
+
+	b := OvertimeBooking{
+		UserID:    userId,
+		Date:      date,
+		Kind:      f.Kind,
+		Hours:     f.Hours,
+		CreatedBy: &createdBy,
+	}
+	if f.Reason != "" {
+		b.Reason = &f.Reason
+	}
+
+	switch f.Kind {
+	case OvertimeOpening:
+	case OvertimeAdjustment:
+		if f.Hours == 0 {
+			return OvertimeBooking{}, fmt.Errorf("an adjustment needs hours")
+		}
+		if b.Reason == nil {
+			return OvertimeBooking{}, fmt.Errorf("an adjustment needs a reason")
+		}
+	case OvertimePayout, OvertimeTimeOff:
+		if f.Hours <= 0 {
+			return OvertimeBooking{}, fmt.Errorf("hours of a %v must be positive", f.Kind)
+		}
+		b.Hours = -f.Hours
+	default:
+		return OvertimeBooking{}, fmt.Errorf("unknown overtime booking %q", f.Kind)
+	}
+
+	return b, nil
+}
+
+// OvertimeSnapshot closes the overtime account of a user for the month
+// starting on Month. Worked, Expected, Booked and TimeOff are the hours of
+// the month, Balance is the balance at its end.
+type OvertimeSnapshot struct {
+	UserID    int64     `json:"user_id"`
+	Month     time.Time `json:"month"`
+	Worked    float64   `json:"worked"`
+	Expected  float64   `json:"expected"`
+	Booked    float64   `json:"booked"`
+	Balance   float64   `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+	TimeOff   float64   `json:"time_off"`
+}
+
+// OvertimeBalance is the overtime account of a user at the end of Date. It
+// carries the balance of the last snapshot before Since over and adds the
+// hours from Since on.
+type OvertimeBalance struct {
+	UserID   int64     `json:"user_id"`
+	Date     time.Time `json:"date"`
+	Since    time.Time `json:"since"`
+	Carried  float64   `json:"carried"`
+	Worked   float64   `json:"worked"`
+	Expected float64   `json:"expected"`
+	Booked   float64   `json:"booked"`
+	TimeOff  float64   `json:"time_off"`
+	Balance  float64   `json:"balance"`
+}
+
+// NewOvertimeBalance adds the overtime of the work hours and the bookings to
+// the carried balance.
+func NewOvertimeBalance(
+	userId int64,
+	since, date time.Time,
+	carried float64,
+	hours WorkHours,
+	bookings []OvertimeBooking,
+) OvertimeBalance {
+	b := OvertimeBalance{
+		UserID:   userId,
+		Date:     date,
+		Since:    since,
+		Carried:  carried,
+		Worked:   hours.Worked,
+		Expected: hours.Expected,
+		TimeOff:  hours.TimeOff,
+	}
+	for _, booking := range bookings {
+		b.Booked += booking.Hours
+	}
+	b.Balance = b.Carried + hours.Overtime() + b.Booked
+
+	return b
+}
+
+// Snapshot closes the month starting on month with the balance.
+func (b OvertimeBalance) Snapshot(month time.Time) OvertimeSnapshot {
+	return OvertimeSnapshot{
+		UserID:   b.UserID,
+		Month:    month,
+		Worked:   b.Worked,
+		Expected: b.Expected,
+		Booked:   b.Booked,
+		Balance:  b.Balance,
+		TimeOff:  b.TimeOff,
+	}
+}
+
+// MonthStart returns the first day of the month of t.
+func MonthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// MonthsToClose returns the first days of the months from the month of start
+// up to the last month that ended before now.
+func MonthsToClose(start, now time.Time) []time.Time {
+	months := []time.Time{}
+	for m := MonthStart(start); m.Before(MonthStart(now)); m = m.AddDate(0, 1, 0) {
+		months = append(months, m)
+	}
+	return months
+}
+
+type OvertimeRepository interface {
+	CreateBooking(ctx context.Context, b *OvertimeBooking) (*OvertimeBooking, error)
+	GetBookings(ctx context.Context, userId int64) ([]OvertimeBooking, error)
+	// GetBookingsInRange returns the bookings of the user dated in [start, end].
+	GetBookingsInRange(
+		ctx context.Context,
+		userId int64,
+		start time.Time,
+		end time.Time,
+	) ([]OvertimeBooking, error)
+	// GetOpening returns the opening balance of the user, nil if there is none.
+	GetOpening(ctx context.Context, userId int64) (*OvertimeBooking, error)
+	CreateSnapshot(ctx context.Context, s *OvertimeSnapshot) (*OvertimeSnapshot, error)
+	GetSnapshots(ctx context.Context, userId int64) ([]OvertimeSnapshot, error)
+	// GetLastSnapshotBefore returns the last snapshot of the user for a month
+	// before the given one, nil if there is none.
+	GetLastSnapshotBefore(
+		ctx context.Context,
+		userId int64,
+		month time.Time,
+	) (*OvertimeSnapshot, error)
+}
@@ -0,0 +1,108 @@
+package domain_test
+
+import (
+	"chrono/internal/domain"
+	"testing"
+	"time"
+)
+
+// TestCreateOvertimeBookingBooking checks the validation and the sign of the
+// booked hours.
+func TestCreateOvertimeBookingBooking(t *testing.T) {
+	tests := []struct {
+		name  string
+		form  domain.CreateOvertimeBooking
+		hours float64
+		valid bool
+	}{
+		{
+			name:  "opening",
+			form:  domain.CreateOvertimeBooking{Date: "2027-01-01", Kind: domain.OvertimeOpening, Hours: -3},
+			hours: -3,
+			valid: true,
+		},
+		{
+			name: "adjustment",
+			form: domain.CreateOvertimeBooking{
+				Date:   "2027-02-10",
+				Kind:   domain.OvertimeAdjustment,
+				Hours:  2.5,
+				Reason: "missed timestamp",
+			},
+			hours: 2.5,
+			valid: true,
+		},
+		{
+			name: "adjustment without reason",
+			form: domain.CreateOvertimeBooking{Date: "2027-02-10", Kind: domain.OvertimeAdjustment, Hours: 2},
+		},
+		{
+			name: "adjustment without hours",
+			form: domain.CreateOvertimeBooking{
+				Date:   "2027-02-10",
+				Kind:   domain.OvertimeAdjustment,
+				Reason: "nothing",
+			},
+		},
+		{
+			name:  "payout",
+			form:  domain.CreateOvertimeBooking{Date: "2027-02-28", Kind: domain.OvertimePayout, Hours: 8},
+			hours: -8,
+			valid: true,
+		},
+		{
+			name: "negative time off",
+			form: domain.CreateOvertimeBooking{Date: "2027-02-28", Kind: domain.OvertimeTimeOff, Hours: -8},
+		},
+		{
+			name: "invalid date",
+			form: domain.CreateOvertimeBooking{Date: "28.02.2027", Kind: domain.OvertimePayout, Hours: 8},
+		},
+		{
+			name: "unknown kind",
+			form: domain.CreateOvertimeBooking{Date: "2027-02-28", Kind: "bonus", Hours: 8},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := tc.form.Booking(1, 2)
+			if (err == nil) != tc.valid {
+				t.Fatalf("Booking() = %v, want valid %v", err, tc.valid)
+			}
+			if tc.valid && b.Hours != tc.hours {
+				t.Errorf("Booking().Hours = %v, want %v", b.Hours, tc.hours)
+			}
+		})
+	}
+}
+
+func TestMonthsToClose(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		start time.Time
+		now   time.Time
+		want  int
+	}{
+		{name: "current month", start: day(2027, 3, 5), now: day(2027, 3, 20), want: 0},
+		{name: "last month", start: day(2027, 2, 15), now: day(2027, 3, 1), want: 1},
+		{name: "over the year", start: day(2026, 11, 1), now: day(2027, 2, 10), want: 3},
+		{name: "start after now", start: day(2027, 5, 1), now: day(2027, 3, 1), want: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			months := domain.MonthsToClose(tc.start, tc.now)
+			if len(months) != tc.want {
+				t.Fatalf("MonthsToClose() = %v, want %v months", months, tc.want)
+			}
+			if tc.want > 0 && !months[0].Equal(domain.MonthStart(tc.start)) {
+				t.Errorf("MonthsToClose()[0] = %v, want %v", months[0], domain.MonthStart(tc.start))
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	CommentKindComment   = "comment"
+	CommentKindRejection = "rejection"
+)
+
+// MaxMessageLength limits request messages and comments.
+const MaxMessageLength = 2000
+
+// RequestComment is a message of the requester or an approver on a request,
+// the reason of a rejection is a comment of kind rejection.
+type RequestComment struct {
+	ID        int64     `json:"id"`
+	RequestID int64     `json:"request_id"`
+	UserID    int64     `json:"user_id"`
+	Kind      string    `json:"kind"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+	Username  string    `json:"username"`
+}
+
+// CleanMessage trims a user supplied message and rejects overly long ones.
+func CleanMessage(msg string) (string, error) {
+	msg = strings.TrimSpace(msg)
+	if len(msg) > MaxMessageLength {
+		return "", fmt.Errorf("message must not be longer than %v characters", MaxMessageLength)
+	}
+
+	return msg, nil
+}
+
+type CreateRequestComment struct {
+	Body string `form:"body"`
+}
+
+type RequestCommentRepository interface {
+	Create(ctx context.Context, c *RequestComment) (*RequestComment, error)
+	GetForRequest(ctx context.Context, requestId int64) ([]RequestComment, error)
+}
@@ -14,74 +14,112 @@ type Request struct {
 	UserID    int64     `json:"user_id"`
 	EditedBy  *int64    `json:"edited_by"`
 	EventID   int64     `json:"event_id"`
+	AbsenceID *int64    `json:"absence_id"`
+	// ReminderCount, LastRemindedAt and EscalatedAt track the reminders of
+	// the current approval stage.
+	ReminderCount  int64      `json:"reminder_count"`
+	LastRemindedAt *time.Time `json:"last_reminded_at"`
+	EscalatedAt    *time.Time `json:"escalated_at"`
 }
 
-// ID           int64     `json:"id"`
-// Message      *string   `json:"message"`
-// State        string    `json:"state"`
-// CreatedAt    time.Time `json:"created_at"`
-// EditedAt     time.Time `json:"edited_at"`
-// UserID       int64     `json:"user_id"`
-// EditedBy     *int64    `json:"edited_by"`
-// EventID      int64     `json:"event_id"`
-// ID_2         int64     `json:"id_2"`
-// Username     string    `json:"username"`
-// Email        string    `json:"email"`
-// Password     string    `json:"password"`
-// VacationDays int64     `json:"vacation_days"`
-// IsSuperuser  bool      `json:"is_superuser"`
-// CreatedAt_2  time.Time `json:"created_at_2"`
-// EditedAt_2   time.Time `json:"edited_at_2"`
-// Color        string    `json:"color"`
-// Role         string    `json:"role"`
-// Enabled      bool      `json:"enabled"`
-// AworkID      *string   `json:"awork_id"`
-// ID_3         int64     `json:"id_3"`
-// ScheduledAt  time.Time `json:"scheduled_at"`
-// Name         string    `json:"name"`
-// State_2      string    `json:"state_2"`
-// CreatedAt_3  time.Time `json:"created_at_3"`
-// EditedAt_3   time.Time `json:"edited_at_3"`
-// UserID_2     int64     `json:"user_id_2"`
+type RequestAbsenceUser struct {
+	ID             int64      `json:"request_id"`
+	Message        *string    `json:"message"`
+	State          string     `json:"request_state"`
+	CreatedAt      time.Time  `json:"created_at"`
+	EditedAt       time.Time  `json:"edited_at"`
+	UserID         int64      `json:"-"`
+	EditedBy       *int64     `json:"edited_by"`
+	EventID        int64      `json:"-"`
+	AbsenceID      *int64     `json:"-"`
+	ReminderCount  int64      `json:"reminder_count"`
+	LastRemindedAt *time.Time `json:"last_reminded_at"`
+	EscalatedAt    *time.Time `json:"escalated_at"`
+	ID_2           int64      `json:"user_id"`
+	Username       string     `json:"username"`
+	Email          string     `json:"email"`
+	Password       string     `json:"-"`
+	VacationDays   int64      `json:"vacation_days"`
+	IsSuperuser    bool       `json:"is_superuser"`
+	CreatedAt_2    time.Time  `json:"user_created_at"`
+	EditedAt_2     time.Time  `json:"user_edited_at"`
+	Color          string     `json:"color"`
+	Role           string     `json:"role"`
+	Enabled        bool       `json:"enabled"`
+	AworkID        *string    `json:"awork_id"`
+	WorkdayHours   float64    `json:"workday_hours"`
+	WorkdaysWeek   float64    `json:"workdays_week"`
+	EntryDate      *time.Time `json:"entry_date"`
+	ExitDate       *time.Time `json:"exit_date"`
+	Schedule       *string    `json:"schedule"`
+	Region         *string    `json:"region"`
+	ID_3           int64      `json:"absence_id"`
+	Name           string     `json:"name"`
+	StartDate      time.Time  `json:"start_date"`
+	EndDate        time.Time  `json:"end_date"`
+	HalfDayStart   bool       `json:"half_day_start"`
+	HalfDayEnd     bool       `json:"half_day_end"`
+	State_2        string     `json:"absence_state"`
+	CreatedAt_3    time.Time  `json:"absence_created_at"`
+	EditedAt_3     time.Time  `json:"absence_edited_at"`
+	UserID_2       int64      `json:"-"`
+	EventCount     int64      `json:"event_count"`
+}
+
+// Stage returns the approval stage the request waits for.
+func (r *RequestAbsenceUser) Stage() int64 {
+	if r.State == "approved_by_lead" {
+		return StageFinal
+	}
+	return StageLead
+}
+
+// ReminderDue reports whether the approvers are reminded at now. Reminders
+// are sent every interval while the request waits in its current stage, which
+// started when the request was last edited.
+func (r *RequestAbsenceUser) ReminderDue(now time.Time, every time.Duration) bool {
+	return reminderDue(r.EditedAt, r.LastRemindedAt, now, every)
+}
+
+// EscalationDue reports whether the request is escalated to the fallback
+// approver at now, requests are escalated once per stage.
+func (r *RequestAbsenceUser) EscalationDue(now time.Time, after time.Duration) bool {
+	if after <= 0 || r.EscalatedAt != nil {
+		return false
+	}
 
-type RequestEventUser struct {
-	ID           int64     `json:"request_id"`
-	Message      *string   `json:"message"`
-	State        string    `json:"request_state"`
-	CreatedAt    time.Time `json:"created_at"`
-	EditedAt     time.Time `json:"edited_at"`
-	UserID       int64     `json:"-"`
-	EditedBy     *int64    `json:"edited_by"`
-	EventID      int64     `json:"-"`
-	ID_2         int64     `json:"user_id"`
-	Username     string    `json:"username"`
-	Email        string    `json:"email"`
-	Password     string    `json:"-"`
-	VacationDays int64     `json:"vacation_days"`
-	IsSuperuser  bool      `json:"is_superuser"`
-	CreatedAt_2  time.Time `json:"user_created_at"`
-	EditedAt_2   time.Time `json:"user_edited_at"`
-	Color        string    `json:"color"`
-	Role         string    `json:"role"`
-	Enabled      bool      `json:"enabled"`
-	AworkID      *string   `json:"awork_id"`
-	WorkdayHours float64   `json:"workday_hours"`
-	WorkdaysWeek float64   `json:"workdays_week"`
-	ID_3         int64     `json:"event_id"`
-	ScheduledAt  time.Time `json:"scheduled_at"`
-	Name         string    `json:"name"`
-	State_2      string    `json:"event_state"`
-	CreatedAt_3  time.Time `json:"event_created_at"`
-	EditedAt_3   time.Time `json:"event_edited_at"`
-	UserID_2     int64     `json:"-"`
+	return !now.Before(r.EditedAt.Add(after))
+}
+
+// reminderDue reports whether a decision that has been waiting since waiting
+// and was last reminded at reminded is reminded at now.
+func reminderDue(
+	waiting time.Time,
+	reminded *time.Time,
+	now time.Time,
+	every time.Duration,
+) bool {
+	if every <= 0 {
+		return false
+	}
+
+	since := waiting
+	if reminded != nil {
+		since = *reminded
+	}
+
+	return !now.Before(since.Add(every))
 }
 
 type BatchRequest struct {
-	StartDate  time.Time         `json:"start_date"`
-	EndDate    time.Time         `json:"end_date"`
-	EventCount int               `json:"event_count"`
-	Request    *RequestEventUser `json:"request"`
-	Conflicts  *[]User           `json:"conflicts"`
+	StartDate  time.Time           `json:"start_date"`
+	EndDate    time.Time           `json:"end_date"`
+	EventCount int                 `json:"event_count"`
+	Request    *RequestAbsenceUser `json:"request"`
+	Conflicts  *[]User             `json:"conflicts"`
+	Violations []RuleViolation     `json:"violations"`
+	Reminders  int64               `json:"reminder_count"`
+	Escalated  bool                `json:"escalated"`
 }
 
 type RejectModalForm struct {
@@ -91,15 +129,8 @@ type RejectModalForm struct {
 	RequestID int64 `query:"request_id"`
 }
 
-type PatchRequestForm struct {
-	UserID    int64  `form:"user_id"`
-	State     string `form:"state"`
-	Reason    string `form:"reason"`
-	StartDate int64  `form:"start_date"`
-	EndDate   int64  `form:"end_date"`
-}
-
 type ApiPatchRequestForm struct {
+	RequestID int64     `form:"request_id"`
 	UserID    int64     `form:"user_id"`
 	State     string    `form:"state"`
 	Reason    string    `form:"reason"`
@@ -108,15 +139,12 @@ type ApiPatchRequestForm struct {
 }
 
 type RequestRepository interface {
-	Create(ctx context.Context, msg string, user *User, event *Event) (*Request, error)
 	Update(ctx context.Context, editor *User, req *Request) (*Request, error)
-	GetPending(ctx context.Context) ([]RequestEventUser, error)
+	GetPending(ctx context.Context) ([]RequestAbsenceUser, error)
 	GetEventNameFrom(ctx context.Context, reqId int64) (string, error)
 	GetInRange(ctx context.Context, userId int64, start, end time.Time) ([]Request, error)
-	UpdateInRange(
-		ctx context.Context,
-		state string,
-		editor, reqUserId int64,
-		start, end time.Time,
-	) (int64, error)
+	GetById(ctx context.Context, id int64) (*Request, error)
+	GetByAbsenceId(ctx context.Context, absenceId int64) (*Request, error)
+	MarkReminded(ctx context.Context, id int64, at time.Time) error
+	MarkEscalated(ctx context.Context, id int64, at time.Time) error
 }
@@ -0,0 +1,49 @@
+package domain_test
+
+import (
+	"chrono/internal/domain"
+	"testing"
+	"time"
+)
+
+// TestRequestReminders checks that reminders repeat every interval from the
+// last reminder and that a request is escalated only once.
+func TestRequestReminders(t *testing.T) {
+	day := 24 * time.Hour
+	edited := date(2027, 3, 1)
+	now := date(2027, 3, 8)
+
+	tests := []struct {
+		name     string
+		reminded *time.Time
+		escal    *time.Time
+		remind   bool
+		escalate bool
+	}{
+		{name: "never reminded", remind: true, escalate: true},
+		{name: "reminded recently", reminded: ptr(date(2027, 3, 6)), remind: false, escalate: true},
+		{name: "reminder due again", reminded: ptr(date(2027, 3, 5)), remind: true, escalate: true},
+		{name: "already escalated", escal: ptr(date(2027, 3, 8)), remind: true, escalate: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := domain.RequestAbsenceUser{
+				EditedAt:       edited,
+				LastRemindedAt: tc.reminded,
+				EscalatedAt:    tc.escal,
+			}
+			if got := r.ReminderDue(now, 3*day); got != tc.remind {
+				t.Errorf("ReminderDue() = %v, want %v", got, tc.remind)
+			}
+			if got := r.EscalationDue(now, 7*day); got != tc.escalate {
+				t.Errorf("EscalationDue() = %v, want %v", got, tc.escalate)
+			}
+		})
+	}
+
+	r := domain.RequestAbsenceUser{EditedAt: edited}
+	if r.ReminderDue(now, 0) || r.EscalationDue(now, 0) {
+		t.Errorf("disabled reminders are due")
+	}
+}
@@ -17,6 +17,9 @@ type SessionRepository interface {
 	Create(ctx context.Context, userId int64, secureRand string, duration time.Duration) (*Session, error)
 	Delete(ctx context.Context, cookie string) error
 	DeleteAll(ctx context.Context) error
+	// DeleteExpired removes the sessions that ended before the given time and
+	// returns how many were removed.
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
 	GetSessionUser(ctx context.Context, cookie string) (*User, error)
 	GetById(ctx context.Context, cookie string) (*Session, error)
 }
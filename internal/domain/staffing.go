@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// SeverityWarning violations are shown to the requester and the approvers
+	// and make the absence need approval.
+	SeverityWarning = "warning"
+	// SeverityError violations reject the absence.
+	SeverityError = "error"
+)
+
+func IsValidSeverity(severity string) bool {
+	return severity == SeverityWarning || severity == SeverityError
+}
+
+// StaffingRule requires at least MinPresent people of the team, or of the
+// whole company without a team, to be present on every working day.
+type StaffingRule struct {
+	ID         int64     `json:"id"`
+	TeamID     *int64    `json:"team_id"`
+	MinPresent int64     `json:"min_present"`
+	Severity   string    `json:"severity"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// AppliesTo reports whether the rule covers the members of the team, a nil
+// team is a user without a team.
+func (r *StaffingRule) AppliesTo(teamId *int64) bool {
+	return r.TeamID == nil || (teamId != nil && *r.TeamID == *teamId)
+}
+
+// Violations returns a violation for every day of the absence of the user on
+// which fewer than MinPresent people of the staff would be present. Staff only
+// counts on days their contract schedules work, contracts holds the contracts
+// of the staff by user id and absent their absence events on the days.
+func (r *StaffingRule) Violations(
+	userId int64,
+	days []AbsenceDay,
+	staff []User,
+	contracts map[int64][]UserContract,
+	absent []Event,
+) []RuleViolation {
+	away := map[time.Time]map[int64]bool{}
+	for _, e := range absent {
+		day := truncateDay(e.ScheduledAt)
+		if away[day] == nil {
+			away[day] = map[int64]bool{}
+		}
+		away[day][e.UserID] = true
+	}
+
+	violations := []RuleViolation{}
+	for _, d := range days {
+		day := truncateDay(d.Date)
+
+		present := int64(0)
+		for _, u := range staff {
+			if u.ID != userId && u.WorksOn(contracts[u.ID], day) && !away[day][u.ID] {
+				present++
+			}
+		}
+
+		if present < r.MinPresent {
+			violations = append(violations, RuleViolation{
+				Rule:     RuleStaffing,
+				RuleID:   r.ID,
+				Date:     day,
+				Severity: r.Severity,
+				Message: fmt.Sprintf(
+					"only %v of at least %v people would be present on %v",
+					present,
+					r.MinPresent,
+					day.Format(time.DateOnly),
+				),
+			})
+		}
+	}
+
+	return violations
+}
+
+// BlackoutPeriod is a range such as a release week or an inventory day in
+// which vacation is not allowed (error) or needs approval (warning), for
+// the members of a team or everyone without a team.
+type BlackoutPeriod struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	TeamID    *int64    `json:"team_id"`
+	Severity  string    `json:"severity"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (b *BlackoutPeriod) AppliesTo(teamId *int64) bool {
+	return b.TeamID == nil || (teamId != nil && *b.TeamID == *teamId)
+}
+
+// Violation returns the violation for the first of the days in the period,
+// or nil if none of them is.
+func (b *BlackoutPeriod) Violation(days []AbsenceDay) *RuleViolation {
+	for _, d := range days {
+		day := truncateDay(d.Date)
+		if day.Before(truncateDay(b.StartDate)) || day.After(truncateDay(b.EndDate)) {
+			continue
+		}
+
+		return &RuleViolation{
+			Rule:     RuleBlackout,
+			RuleID:   b.ID,
+			Date:     day,
+			Severity: b.Severity,
+			Message: fmt.Sprintf(
+				"%v from %v to %v is a blackout period",
+				b.Name,
+				b.StartDate.Format(time.DateOnly),
+				b.EndDate.Format(time.DateOnly),
+			),
+		}
+	}
+
+	return nil
+}
+
+var (
+	RuleStaffing = "staffing"
+	RuleBlackout = "blackout"
+)
+
+// RuleViolation is a day of an absence that breaks a staffing rule or falls
+// into a blackout period.
+type RuleViolation struct {
+	Rule     string    `json:"rule"`
+	RuleID   int64     `json:"rule_id"`
+	Date     time.Time `json:"date"`
+	Severity string    `json:"severity"`
+	Message  string    `json:"message"`
+}
+
+// SplitViolations separates the violations that reject an absence from the
+// warnings.
+func SplitViolations(violations []RuleViolation) ([]RuleViolation, []RuleViolation) {
+	errs := []RuleViolation{}
+	warnings := []RuleViolation{}
+	for _, v := range violations {
+		if v.Severity == SeverityError {
+			errs = append(errs, v)
+		} else {
+			warnings = append(warnings, v)
+		}
+	}
+
+	return errs, warnings
+}
+
+// RuleError rejects an absence that breaks rules with the error severity.
+type RuleError struct {
+	Violations []RuleViolation
+}
+
+func (e *RuleError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.Message
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+type CreateStaffingRule struct {
+	TeamID     int64  `form:"team_id"`
+	MinPresent int64  `form:"min_present"`
+	Severity   string `form:"severity"`
+}
+
+type CreateBlackoutPeriod struct {
+	Name      string `form:"name"`
+	StartDate string `form:"start_date"`
+	EndDate   string `form:"end_date"`
+	TeamID    int64  `form:"team_id"`
+	Severity  string `form:"severity"`
+}
+
+type StaffingRepository interface {
+	CreateRule(ctx context.Context, r *StaffingRule) (*StaffingRule, error)
+	DeleteRule(ctx context.Context, id int64) error
+	GetRules(ctx context.Context) ([]StaffingRule, error)
+	CreateBlackout(ctx context.Context, b *BlackoutPeriod) (*BlackoutPeriod, error)
+	DeleteBlackout(ctx context.Context, id int64) error
+	GetBlackouts(ctx context.Context) ([]BlackoutPeriod, error)
+	// GetBlackoutsInRange returns the blackout periods that overlap
+	// [start, end].
+	GetBlackoutsInRange(ctx context.Context, start, end time.Time) ([]BlackoutPeriod, error)
+}
@@ -0,0 +1,121 @@
+package domain_test
+
+import (
+	"chrono/internal/domain"
+	"testing"
+	"time"
+)
+
+// TestStaffingRuleViolations checks that the requester, absent or not yet
+// employed staff and staff on unscheduled days are not counted as present.
+func TestStaffingRuleViolations(t *testing.T) {
+	entry := date(2027, 3, 3)
+	staff := []domain.User{
+		{ID: 1, WorkdayHours: 8},
+		{ID: 2, WorkdayHours: 8},
+		{ID: 3, WorkdayHours: 8},
+		{ID: 4, WorkdayHours: 8, EntryDate: &entry},
+	}
+	mondays := "8,0,0,0,0,0,0"
+	days := domain.AbsenceDays(date(2027, 3, 1), date(2027, 3, 3), false, false, nil)
+	absent := func(userId int64, day time.Time) domain.Event {
+		return domain.Event{UserID: userId, ScheduledAt: day}
+	}
+
+	tests := []struct {
+		name      string
+		min       int64
+		absent    []domain.Event
+		contracts map[int64][]domain.UserContract
+		expected  []time.Time
+	}{
+		{
+			name:     "enough present",
+			min:      2,
+			absent:   nil,
+			expected: []time.Time{},
+		},
+		{
+			name:     "others absent",
+			min:      2,
+			absent:   []domain.Event{absent(2, date(2027, 3, 2))},
+			expected: []time.Time{date(2027, 3, 2)},
+		},
+		{
+			name:     "joiner counts from entry",
+			min:      3,
+			absent:   nil,
+			expected: []time.Time{date(2027, 3, 1), date(2027, 3, 2)},
+		},
+		{
+			name:     "own absence ignored",
+			min:      2,
+			absent:   []domain.Event{absent(1, date(2027, 3, 1))},
+			expected: []time.Time{},
+		},
+		{
+			name:   "part time free day",
+			min:    2,
+			absent: nil,
+			contracts: map[int64][]domain.UserContract{
+				3: {{ValidFrom: date(2027, 1, 1), WorkdayHours: 8, Schedule: &mondays}},
+			},
+			expected: []time.Time{date(2027, 3, 2)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := domain.StaffingRule{MinPresent: tc.min, Severity: domain.SeverityError}
+			got := rule.Violations(1, days, staff, tc.contracts, tc.absent)
+			if len(got) != len(tc.expected) {
+				t.Fatalf("Violations() = %v, want days %v", got, tc.expected)
+			}
+			for i := range got {
+				if !got[i].Date.Equal(tc.expected[i]) || got[i].Severity != domain.SeverityError {
+					t.Errorf("Violations()[%d] = %v, want %v", i, got[i], tc.expected[i])
+				}
+			}
+		})
+	}
+}
+
+// TestBlackoutPeriodViolation checks that only absences with a day inside the
+// period violate it.
+func TestBlackoutPeriodViolation(t *testing.T) {
+	period := domain.BlackoutPeriod{
+		Name:      "Release",
+		StartDate: date(2027, 3, 3),
+		EndDate:   date(2027, 3, 4),
+		Severity:  domain.SeverityWarning,
+	}
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		expected *time.Time
+	}{
+		{name: "before", start: date(2027, 3, 1), end: date(2027, 3, 2), expected: nil},
+		{name: "overlaps start", start: date(2027, 3, 1), end: date(2027, 3, 3), expected: ptr(date(2027, 3, 3))},
+		{name: "inside", start: date(2027, 3, 4), end: date(2027, 3, 4), expected: ptr(date(2027, 3, 4))},
+		{name: "after", start: date(2027, 3, 5), end: date(2027, 3, 8), expected: nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			days := domain.AbsenceDays(tc.start, tc.end, false, false, nil)
+			got := period.Violation(days)
+			if (got == nil) != (tc.expected == nil) {
+				t.Fatalf("Violation() = %v, want %v", got, tc.expected)
+			}
+			if got != nil && !got.Date.Equal(*tc.expected) {
+				t.Errorf("Violation().Date = %v, want %v", got.Date, *tc.expected)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Approval stages of a request, the final stage is optional.
+const (
+	StageLead  int64 = 1
+	StageFinal int64 = 2
+)
+
+type Team struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TeamMember struct {
+	UserID int64 `json:"user_id"`
+	TeamID int64 `json:"team_id"`
+}
+
+// Approver lets ApproverID decide a stage of the requests of a single user or
+// of every member of a team, exactly one of UserID and TeamID is set.
+type Approver struct {
+	ID         int64     `json:"id"`
+	Stage      int64     `json:"stage"`
+	ApproverID int64     `json:"approver_id"`
+	UserID     *int64    `json:"user_id"`
+	TeamID     *int64    `json:"team_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ApproversFor returns the ids of the users that decide the stage of the
+// requests of the user. Approvers configured for the user replace those of
+// the team and nobody approves their own requests.
+func ApproversFor(userId int64, teamId *int64, stage int64, approvers []Approver) []int64 {
+	own := []int64{}
+	team := []int64{}
+	for _, a := range approvers {
+		if a.Stage != stage || a.ApproverID == userId {
+			continue
+		}
+		switch {
+		case a.UserID != nil && *a.UserID == userId:
+			own = append(own, a.ApproverID)
+		case a.TeamID != nil && teamId != nil && *a.TeamID == *teamId:
+			team = append(team, a.ApproverID)
+		}
+	}
+
+	ids := team
+	if len(own) > 0 {
+		ids = own
+	}
+	slices.Sort(ids)
+
+	return slices.Compact(ids)
+}
+
+// TeamOf returns the id of the team of the user, or nil if the user is not in
+// a team.
+func TeamOf(members []TeamMember, userId int64) *int64 {
+	for _, m := range members {
+		if m.UserID == userId {
+			return &m.TeamID
+		}
+	}
+
+	return nil
+}
+
+// Approvals holds the approvers, team members and users that decide who
+// approves which requests, so many requests are evaluated with a single load.
+type Approvals struct {
+	Approvers []Approver
+	Members   []TeamMember
+	Users     []User
+	// EscalationApprover is the name of the user that stale requests are
+	// escalated to, empty to escalate to the admins.
+	EscalationApprover string
+}
+
+// For returns the ids of the users that decide the stage of the requests of
+// the user. The lead stage falls back to the admins if no approver is
+// configured, the final stage is skipped then.
+func (a *Approvals) For(userId int64, stage int64) []int64 {
+	ids := ApproversFor(userId, TeamOf(a.Members, userId), stage, a.Approvers)
+	if len(ids) > 0 || stage != StageLead {
+		return ids
+	}
+
+	for _, u := range a.Users {
+		if u.IsSuperuser && u.ID != userId {
+			ids = append(ids, u.ID)
+		}
+	}
+
+	return ids
+}
+
+// Fallback returns the ids of the users that stale requests of the user are
+// escalated to, the escalation approver or else the admins.
+func (a *Approvals) Fallback(userId int64) ([]int64, error) {
+	ids := []int64{}
+	if a.EscalationApprover != "" {
+		i := slices.IndexFunc(a.Users, func(u User) bool {
+			return u.Username == a.EscalationApprover
+		})
+		if i < 0 {
+			return nil, fmt.Errorf("escalation approver %q not found", a.EscalationApprover)
+		}
+		if a.Users[i].ID != userId {
+			ids = append(ids, a.Users[i].ID)
+		}
+		return ids, nil
+	}
+
+	for _, u := range a.Users {
+		if u.IsSuperuser && u.ID != userId {
+			ids = append(ids, u.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+// CanDecide returns an error unless the user decides the stage of the
+// requests of the requester.
+func (a *Approvals) CanDecide(requesterId int64, stage int64, user *User) error {
+	if requesterId == user.ID {
+		return fmt.Errorf("users can not approve their own requests")
+	}
+
+	if !slices.Contains(a.For(requesterId, stage), user.ID) {
+		return fmt.Errorf(
+			"user %v is not an approver of stage %v for this request",
+			user.Username,
+			stage,
+		)
+	}
+
+	return nil
+}
+
+// CanDecideRequest is CanDecide for a request that the fallback approvers can
+// decide as well once it is escalated.
+func (a *Approvals) CanDecideRequest(
+	requesterId int64,
+	stage int64,
+	escalated bool,
+	user *User,
+) error {
+	err := a.CanDecide(requesterId, stage, user)
+	if err == nil || !escalated || requesterId == user.ID {
+		return err
+	}
+
+	ids, ferr := a.Fallback(requesterId)
+	if ferr != nil {
+		return ferr
+	}
+	if slices.Contains(ids, user.ID) {
+		return nil
+	}
+
+	return err
+}
+
+type CreateTeam struct {
+	Name string `form:"name"`
+}
+
+type CreateApprover struct {
+	Stage      int64 `form:"stage"`
+	ApproverID int64 `form:"approver_id"`
+	UserID     int64 `form:"user_id"`
+	TeamID     int64 `form:"team_id"`
+}
+
+type TeamRepository interface {
+	Create(ctx context.Context, name string) (*Team, error)
+	Update(ctx context.Context, team *Team) (*Team, error)
+	Delete(ctx context.Context, id int64) error
+	GetById(ctx context.Context, id int64) (*Team, error)
+	GetAll(ctx context.Context) ([]Team, error)
+	// SetMember moves the user into the team, users belong to one team only.
+	SetMember(ctx context.Context, userId, teamId int64) error
+	DeleteMember(ctx context.Context, userId int64) error
+	GetAllMembers(ctx context.Context) ([]TeamMember, error)
+}
+
+type ApproverRepository interface {
+	Create(ctx context.Context, approver *Approver) (*Approver, error)
+	Delete(ctx context.Context, id int64) error
+	GetAll(ctx context.Context) ([]Approver, error)
+}
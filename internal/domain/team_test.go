@@ -0,0 +1,89 @@
+package domain_test
+
+import (
+	"chrono/internal/domain"
+	"slices"
+	"testing"
+)
+
+// TestApproversFor checks that approvers of the user replace those of the
+// team and that nobody approves their own requests.
+func TestApproversFor(t *testing.T) {
+	id := func(v int64) *int64 { return &v }
+	approvers := []domain.Approver{
+		{Stage: domain.StageLead, ApproverID: 10, TeamID: id(1)},
+		{Stage: domain.StageLead, ApproverID: 11, TeamID: id(1)},
+		{Stage: domain.StageFinal, ApproverID: 20, TeamID: id(1)},
+		{Stage: domain.StageLead, ApproverID: 12, UserID: id(3)},
+		{Stage: domain.StageLead, ApproverID: 13, TeamID: id(2)},
+	}
+
+	tests := []struct {
+		name     string
+		userId   int64
+		teamId   *int64
+		stage    int64
+		expected []int64
+	}{
+		{name: "team lead", userId: 2, teamId: id(1), stage: domain.StageLead, expected: []int64{10, 11}},
+		{name: "team final", userId: 2, teamId: id(1), stage: domain.StageFinal, expected: []int64{20}},
+		{name: "user overrides team", userId: 3, teamId: id(1), stage: domain.StageLead, expected: []int64{12}},
+		{name: "user keeps team final", userId: 3, teamId: id(1), stage: domain.StageFinal, expected: []int64{20}},
+		{name: "lead of own team", userId: 10, teamId: id(1), stage: domain.StageLead, expected: []int64{11}},
+		{name: "no team", userId: 4, teamId: nil, stage: domain.StageLead, expected: []int64{}},
+		{name: "other team", userId: 5, teamId: id(2), stage: domain.StageFinal, expected: []int64{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := domain.ApproversFor(tc.userId, tc.teamId, tc.stage, approvers)
+			if !slices.Equal(got, tc.expected) {
+				t.Errorf("ApproversFor() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+// TestApprovals checks the admin fallback of the lead stage and that escalated
+// requests can be decided by the escalation approver.
+func TestApprovals(t *testing.T) {
+	id := func(v int64) *int64 { return &v }
+	approvals := domain.Approvals{
+		Approvers: []domain.Approver{
+			{Stage: domain.StageLead, ApproverID: 10, TeamID: id(1)},
+		},
+		Members: []domain.TeamMember{{UserID: 2, TeamID: 1}},
+		Users: []domain.User{
+			{ID: 2, Username: "member"},
+			{ID: 3, Username: "loner"},
+			{ID: 10, Username: "lead"},
+			{ID: 20, Username: "admin", IsSuperuser: true},
+			{ID: 30, Username: "escalation"},
+		},
+	}
+
+	if got := approvals.For(2, domain.StageLead); !slices.Equal(got, []int64{10}) {
+		t.Errorf("For(team member) = %v, want [10]", got)
+	}
+	if got := approvals.For(3, domain.StageLead); !slices.Equal(got, []int64{20}) {
+		t.Errorf("For(no team) = %v, want admins [20]", got)
+	}
+	if got := approvals.For(3, domain.StageFinal); len(got) != 0 {
+		t.Errorf("For(final stage) = %v, want none", got)
+	}
+
+	escalation := &domain.User{ID: 30, Username: "escalation"}
+	if err := approvals.CanDecideRequest(2, domain.StageLead, false, escalation); err == nil {
+		t.Errorf("CanDecideRequest() = nil before escalation, want error")
+	}
+
+	approvals.EscalationApprover = "escalation"
+	if err := approvals.CanDecideRequest(2, domain.StageLead, true, escalation); err != nil {
+		t.Errorf("CanDecideRequest() = %v after escalation, want nil", err)
+	}
+
+	approvals.EscalationApprover = "missing"
+	if _, err := approvals.Fallback(2); err == nil {
+		t.Errorf("Fallback() = nil for an unknown escalation approver, want error")
+	}
+}
@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+var (
+	// CorrectionChange moves the start or the end of a timestamp.
+	CorrectionChange = "change"
+	// CorrectionAdd adds a timestamp the user forgot to record.
+	CorrectionAdd = "add"
+	// CorrectionDelete removes a timestamp.
+	CorrectionDelete = "delete"
+)
+
+// TimestampCorrection asks to change, add or delete a timestamp of the user.
+// StartTime and EndTime are the proposed values, a change leaves the values
+// that are nil as they are. The timestamp is only touched once an admin
+// accepts the correction. Corrections are not stored as requests, which
+// belong to an absence and its events, but their admins are reminded like
+// the approvers of requests.
+type TimestampCorrection struct {
+	ID          int64      `json:"id"`
+	UserID      int64      `json:"user_id"`
+	TimestampID *int64     `json:"timestamp_id"`
+	Kind        string     `json:"kind"`
+	StartTime   *time.Time `json:"start_time"`
+	EndTime     *time.Time `json:"end_time"`
+	Reason      *string    `json:"reason"`
+	State       string     `json:"state"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DecidedBy   *int64     `json:"decided_by"`
+	DecidedAt   *time.Time `json:"decided_at"`
+	// ReminderCount and LastRemindedAt track the reminders of the admins.
+	ReminderCount  int64      `json:"reminder_count"`
+	LastRemindedAt *time.Time `json:"last_reminded_at"`
+}
+
+func (c *TimestampCorrection) IsPending() bool {
+	return c.State == "pending"
+}
+
+// ReminderDue reports whether the admins are reminded at now, every interval
+// since the correction was created.
+func (c *TimestampCorrection) ReminderDue(now time.Time, every time.Duration) bool {
+	return reminderDue(c.CreatedAt, c.LastRemindedAt, now, every)
+}
+
+// Corrected returns the timestamp with the proposed values of a change.
+func (c *TimestampCorrection) Corrected(t Timestamp) Timestamp {
+	if c.StartTime != nil {
+		t.StartTime = *c.StartTime
+	}
+	if c.EndTime != nil {
+		end := *c.EndTime
+		t.EndTime = &end
+	}
+	return t
+}
+
+// Validate checks the correction against the finished timestamp it corrects,
+// nil for an added one, and the breaks of that timestamp, which must still lie
+// within it after a change. Corrections may not reach into the future.
+func (c *TimestampCorrection) Validate(
+	t *Timestamp,
+	breaks []TimestampBreak,
+	now time.Time,
+) error {
+	switch c.Kind {
+	case CorrectionAdd:
+		if c.StartTime == nil || c.EndTime == nil {
+			return fmt.Errorf("an added timestamp needs a start and an end")
+		}
+		return validRange(*c.StartTime, *c.EndTime, now)
+	case CorrectionChange, CorrectionDelete:
+	default:
+		return fmt.Errorf("unknown correction %q", c.Kind)
+	}
+
+	if t == nil {
+		return fmt.Errorf("a %v needs a timestamp", c.Kind)
+	}
+	if t.EndTime == nil {
+		return fmt.Errorf("stop the timer before correcting it")
+	}
+	if c.Kind == CorrectionDelete {
+		return nil
+	}
+
+	if c.StartTime == nil && c.EndTime == nil {
+		return fmt.Errorf("a change needs a new start or end")
+	}
+	corrected := c.Corrected(*t)
+	if err := validRange(corrected.StartTime, *corrected.EndTime, now); err != nil {
+		return err
+	}
+	for _, b := range breaks {
+		if b.StartTime.Before(corrected.StartTime) ||
+			(b.EndTime != nil && b.EndTime.After(*corrected.EndTime)) {
+			return fmt.Errorf(
+				"the break from %v would lie outside the timestamp",
+				b.StartTime.Format(time.TimeOnly),
+			)
+		}
+	}
+
+	return nil
+}
+
+func validRange(start, end, now time.Time) error {
+	if !end.After(start) {
+		return fmt.Errorf("timestamp must end after it starts")
+	}
+	if end.After(now) {
+		return fmt.Errorf("timestamp must not end in the future")
+	}
+	return nil
+}
+
+// RequestMsg describes the correction of t, nil for an added timestamp, to
+// the admins.
+func (c *TimestampCorrection) RequestMsg(username string, t *Timestamp) string {
+	const layout = "2006-01-02 15:04"
+	switch c.Kind {
+	case CorrectionAdd:
+		return fmt.Sprintf(
+			"%v asked to add a timestamp from %v to %v.",
+			username,
+			c.StartTime.Format(layout),
+			c.EndTime.Format(layout),
+		)
+	case CorrectionDelete:
+		return fmt.Sprintf(
+			"%v asked to delete the timestamp started %v.",
+			username,
+			t.StartTime.Format(layout),
+		)
+	}
+	return fmt.Sprintf(
+		"%v asked to correct the timestamp started %v.",
+		username,
+		t.StartTime.Format(layout),
+	)
+}
+
+type CreateTimestampCorrection struct {
+	TimestampID *int64    `json:"timestamp_id" form:"timestamp_id"`
+	Kind        string    `json:"kind"         form:"kind"`
+	StartTime   time.Time `json:"start_time"   form:"start_time"`
+	EndTime     time.Time `json:"end_time"     form:"end_time"`
+	Reason      string    `json:"reason"       form:"reason"`
+}
+
+type UpdateTimestampCorrection struct {
+	State  string `form:"state"`
+	Reason string `form:"reason"`
+}
+
+// TimestampHistory keeps the values of a timestamp before and after a
+// correction. The old values of an added timestamp and the new values of a
+// deleted one are nil, CorrectionID is nil for the edits of admins.
+type TimestampHistory struct {
+	ID           int64      `json:"id"`
+	TimestampID  int64      `json:"timestamp_id"`
+	UserID       int64      `json:"user_id"`
+	CorrectionID *int64     `json:"correction_id"`
+	Kind         string     `json:"kind"`
+	OldStartTime *time.Time `json:"old_start_time"`
+	OldEndTime   *time.Time `json:"old_end_time"`
+	NewStartTime *time.Time `json:"new_start_time"`
+	NewEndTime   *time.Time `json:"new_end_time"`
+	ChangedBy    *int64     `json:"changed_by"`
+	ChangedAt    time.Time  `json:"changed_at"`
+}
+
+type TimestampCorrectionRepository interface {
+	Create(ctx context.Context, c *TimestampCorrection) (*TimestampCorrection, error)
+	// Decide stores the state and the timestamp of the correction.
+	Decide(
+		ctx context.Context,
+		c *TimestampCorrection,
+		editorId int64,
+	) (*TimestampCorrection, error)
+	GetById(ctx context.Context, id int64) (*TimestampCorrection, error)
+	GetForUser(ctx context.Context, userId int64) ([]TimestampCorrection, error)
+	GetPending(ctx context.Context) ([]TimestampCorrection, error)
+	MarkReminded(ctx context.Context, id int64, at time.Time) error
+}
+
+type TimestampHistoryRepository interface {
+	Create(ctx context.Context, h *TimestampHistory) (*TimestampHistory, error)
+	GetForTimestamp(ctx context.Context, timestampId int64) ([]TimestampHistory, error)
+}
@@ -0,0 +1,99 @@
+package domain_test
+
+import (
+	"chrono/internal/domain"
+	"testing"
+	"time"
+)
+
+// TestTimestampCorrectionValidate checks the proposed values against the
+// timestamp and its breaks.
+func TestTimestampCorrectionValidate(t *testing.T) {
+	at := func(hour int) *time.Time {
+		v := time.Date(2027, 3, 1, hour, 0, 0, 0, time.UTC)
+		return &v
+	}
+	now := *at(20)
+	stamp := &domain.Timestamp{ID: 1, UserID: 1, StartTime: *at(8), EndTime: at(16)}
+	running := &domain.Timestamp{ID: 2, UserID: 1, StartTime: *at(8)}
+	breaks := []domain.TimestampBreak{{TimestampID: 1, StartTime: *at(12), EndTime: at(13)}}
+
+	tests := []struct {
+		name       string
+		correction domain.TimestampCorrection
+		timestamp  *domain.Timestamp
+		valid      bool
+	}{
+		{
+			name:       "change start",
+			correction: domain.TimestampCorrection{Kind: domain.CorrectionChange, StartTime: at(7)},
+			timestamp:  stamp,
+			valid:      true,
+		},
+		{
+			name:       "change without values",
+			correction: domain.TimestampCorrection{Kind: domain.CorrectionChange},
+			timestamp:  stamp,
+		},
+		{
+			name:       "end before start",
+			correction: domain.TimestampCorrection{Kind: domain.CorrectionChange, EndTime: at(7)},
+			timestamp:  stamp,
+		},
+		{
+			name:       "break outside",
+			correction: domain.TimestampCorrection{Kind: domain.CorrectionChange, EndTime: at(12)},
+			timestamp:  stamp,
+		},
+		{
+			name:       "running timer",
+			correction: domain.TimestampCorrection{Kind: domain.CorrectionChange, StartTime: at(7)},
+			timestamp:  running,
+		},
+		{
+			name:       "delete",
+			correction: domain.TimestampCorrection{Kind: domain.CorrectionDelete},
+			timestamp:  stamp,
+			valid:      true,
+		},
+		{
+			name:       "delete without timestamp",
+			correction: domain.TimestampCorrection{Kind: domain.CorrectionDelete},
+		},
+		{
+			name: "add",
+			correction: domain.TimestampCorrection{
+				Kind:      domain.CorrectionAdd,
+				StartTime: at(8),
+				EndTime:   at(12),
+			},
+			valid: true,
+		},
+		{
+			name:       "add without end",
+			correction: domain.TimestampCorrection{Kind: domain.CorrectionAdd, StartTime: at(8)},
+		},
+		{
+			name: "add in the future",
+			correction: domain.TimestampCorrection{
+				Kind:      domain.CorrectionAdd,
+				StartTime: at(18),
+				EndTime:   at(22),
+			},
+		},
+		{
+			name:       "unknown kind",
+			correction: domain.TimestampCorrection{Kind: "move"},
+			timestamp:  stamp,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.correction.Validate(tc.timestamp, breaks, now)
+			if (err == nil) != tc.valid {
+				t.Errorf("Validate() = %v, want valid %v", err, tc.valid)
+			}
+		})
+	}
+}
@@ -2,19 +2,197 @@ package domain
 
 import (
 	"context"
+	"fmt"
 	"time"
 )
 
+// Timestamp is a span of work, AutoClosed marks a timer that was left
+// running and stopped by the timers job.
 type Timestamp struct {
-	ID        int64      `json:"id"         form:"id"`
-	StartTime time.Time  `json:"start_time" form:"start_time"`
-	EndTime   *time.Time `json:"end_time"   form:"end_time"`
-	UserID    int64      `json:"user_id"    form:"user_id"`
+	ID         int64      `json:"id"          form:"id"`
+	StartTime  time.Time  `json:"start_time"  form:"start_time"`
+	EndTime    *time.Time `json:"end_time"    form:"end_time"`
+	UserID     int64      `json:"user_id"     form:"user_id"`
+	AutoClosed bool       `json:"auto_closed"`
+}
+
+var (
+	// TimerCloseEndOfDay stops forgotten timers at the end of their day.
+	TimerCloseEndOfDay = "end_of_day"
+	// TimerCloseFixedTime stops forgotten timers at a time of their day.
+	TimerCloseFixedTime = "fixed_time"
+	// TimerCloseMaxHours stops forgotten timers after a number of hours.
+	TimerCloseMaxHours = "max_hours"
+	// TimerCloseSchedule caps forgotten timers at the hours the contract
+	// schedules on their day.
+	TimerCloseSchedule = "schedule"
+)
+
+// TimerClosePolicy decides when a timer that was left running is stopped.
+type TimerClosePolicy struct {
+	Mode string
+	// At is the time of day of TimerCloseFixedTime.
+	At time.Duration
+	// MaxHours is the length of TimerCloseMaxHours.
+	MaxHours float64
+}
+
+// ParseTimerClosePolicy validates the policy, at is a time of day like 18:00.
+func ParseTimerClosePolicy(mode string, at string, maxHours float64) (TimerClosePolicy, error) {
+	p := TimerClosePolicy{Mode: mode, MaxHours: maxHours}
+
+	switch mode {
+	case TimerCloseEndOfDay, TimerCloseSchedule:
+	case TimerCloseFixedTime:
+		t, err := time.Parse("15:04", at)
+		if err != nil {
+			return p, fmt.Errorf("invalid timer close time %q", at)
+		}
+		p.At = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	case TimerCloseMaxHours:
+		if maxHours <= 0 || maxHours > 24 {
+			return p, fmt.Errorf("invalid timer max hours %v", maxHours)
+		}
+	default:
+		return p, fmt.Errorf("unknown timer close policy %q", mode)
+	}
+
+	return p, nil
+}
+
+// CloseAt returns when the policy stops a timer that started at start,
+// scheduled is the hours the contract schedules on that day. Days and times
+// of day are those of loc. Timers are stopped at the end of their day when
+// the policy ends them before they started or nothing is scheduled, and
+// never later than that.
+func (p TimerClosePolicy) CloseAt(
+	start time.Time,
+	scheduled float64,
+	loc *time.Location,
+) time.Time {
+	local := start.In(loc)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	endOfDay := day.AddDate(0, 0, 1)
+
+	end := endOfDay
+	switch p.Mode {
+	case TimerCloseFixedTime:
+		end = day.Add(p.At)
+	case TimerCloseMaxHours:
+		end = start.Add(time.Duration(p.MaxHours * float64(time.Hour)))
+	case TimerCloseSchedule:
+		if scheduled > 0 {
+			end = start.Add(time.Duration(scheduled * float64(time.Hour)))
+		}
+	}
+
+	if !end.After(start) {
+		return endOfDay
+	}
+	return minTime(end, endOfDay)
+}
+
+// TimestampBreak interrupts a timestamp, a break without an end pauses a
+// running timer.
+type TimestampBreak struct {
+	ID          int64      `json:"id"`
+	TimestampID int64      `json:"timestamp_id"`
+	StartTime   time.Time  `json:"start_time"`
+	EndTime     *time.Time `json:"end_time"`
+}
+
+// Duration returns the length of the break, zero while it is running.
+func (b TimestampBreak) Duration() time.Duration {
+	if b.EndTime == nil {
+		return 0
+	}
+	return b.EndTime.Sub(b.StartTime)
+}
+
+type CreateTimestampBreak struct {
+	StartTime time.Time `json:"start_time" form:"start_time"`
+	EndTime   time.Time `json:"end_time"   form:"end_time"`
+}
+
+// ValidBreak checks that the break lies within the timestamp, a running
+// timestamp is considered to end at now, and does not overlap the existing
+// breaks.
+func (t Timestamp) ValidBreak(
+	b CreateTimestampBreak,
+	existing []TimestampBreak,
+	now time.Time,
+) error {
+	end := now
+	if t.EndTime != nil {
+		end = *t.EndTime
+	}
+
+	if !b.EndTime.After(b.StartTime) {
+		return fmt.Errorf("break must end after it starts")
+	}
+	if b.StartTime.Before(t.StartTime) || b.EndTime.After(end) {
+		return fmt.Errorf("break must lie within the timestamp")
+	}
+
+	for _, e := range existing {
+		eEnd := end
+		if e.EndTime != nil {
+			eEnd = *e.EndTime
+		}
+		if b.StartTime.Before(eEnd) && e.StartTime.Before(b.EndTime) {
+			return fmt.Errorf(
+				"break overlaps the break from %v",
+				e.StartTime.Format(time.TimeOnly),
+			)
+		}
+	}
+
+	return nil
+}
+
+var (
+	IdempotencyStart = "start"
+	IdempotencyStop  = "stop"
+)
+
+// IdempotencyKeyTTL is how long a retried clock in or out is recognized by
+// its key.
+const IdempotencyKeyTTL = 24 * time.Hour
+
+// IdempotencyKey remembers the timestamp a clock in or out sent with the key
+// was applied to, TimestampID is nil once the timestamp is deleted.
+type IdempotencyKey struct {
+	ID          int64     `json:"id"`
+	UserID      int64     `json:"user_id"`
+	Key         string    `json:"key"`
+	Action      string    `json:"action"`
+	TimestampID *int64    `json:"timestamp_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Matches reports whether the key was used for the action on the timestamp,
+// a nil timestamp matches any.
+func (k *IdempotencyKey) Matches(action string, timestampId *int64) bool {
+	if k.Action != action {
+		return false
+	}
+	return timestampId == nil || (k.TimestampID != nil && *k.TimestampID == *timestampId)
+}
+
+type IdempotencyKeyRepository interface {
+	// Get returns the key of the user if it was used since the given time,
+	// nil otherwise.
+	Get(ctx context.Context, userId int64, key string, since time.Time) (*IdempotencyKey, error)
+	Save(ctx context.Context, k *IdempotencyKey) (*IdempotencyKey, error)
 }
 
 type TimestampsRepository interface {
 	GetById(ctx context.Context, id int64) (Timestamp, error)
+	// Start starts a timer for the user, or returns the running one as a user
+	// has at most one.
 	Start(ctx context.Context, userId int64) (Timestamp, error)
+	// Create stores a finished timestamp.
+	Create(ctx context.Context, ts *Timestamp) (Timestamp, error)
 	Stop(ctx context.Context, id int64) (Timestamp, error)
 	Delete(ctx context.Context, id int64) error
 	Update(ctx context.Context, ts *Timestamp) (Timestamp, error)
@@ -36,5 +214,48 @@ type TimestampsRepository interface {
 		stop time.Time,
 	) (float64, error)
 	GetLatest(ctx context.Context, userId int64) (Timestamp, error)
+	// GetFirst returns the earliest timestamp of the user, nil if there is
+	// none.
+	GetFirst(ctx context.Context, userId int64) (*Timestamp, error)
 	GetAllForUser(ctx context.Context, userId int64) ([]Timestamp, error)
+	// GetOpenBefore returns the running timestamps that started before the
+	// given time.
+	GetOpenBefore(ctx context.Context, before time.Time) ([]Timestamp, error)
+	// AutoClose stops the running timestamp at end and flags it as auto
+	// closed, it returns nil if the timestamp was stopped in the meantime.
+	AutoClose(ctx context.Context, id int64, end time.Time) (*Timestamp, error)
+}
+
+type TimestampBreakRepository interface {
+	Create(ctx context.Context, b *TimestampBreak) (TimestampBreak, error)
+	// Start pauses the running timestamp with an open break.
+	Start(ctx context.Context, timestampId int64) (TimestampBreak, error)
+	// Stop ends the open break of the timestamp.
+	Stop(ctx context.Context, timestampId int64) (TimestampBreak, error)
+	// EndOpen ends the open breaks of the timestamp at the given time and
+	// removes the breaks that start at or after it, it is a no-op if the
+	// timestamp is not paused.
+	EndOpen(ctx context.Context, timestampId int64, end time.Time) error
+	Delete(ctx context.Context, id int64) error
+	GetById(ctx context.Context, id int64) (TimestampBreak, error)
+	GetForTimestamp(ctx context.Context, timestampId int64) ([]TimestampBreak, error)
+	// GetInRange returns the breaks of the timestamps GetInRange of the
+	// TimestampsRepository returns.
+	GetInRange(
+		ctx context.Context,
+		userId int64,
+		start time.Time,
+		stop time.Time,
+	) ([]TimestampBreak, error)
+	GetAllInRange(
+		ctx context.Context,
+		start time.Time,
+		stop time.Time,
+	) ([]TimestampBreak, error)
+	GetTotalSecondsInRange(
+		ctx context.Context,
+		userId int64,
+		start time.Time,
+		stop time.Time,
+	) (float64, error)
 }
@@ -0,0 +1,100 @@
+package domain_test
+
+import (
+	"chrono/internal/domain"
+	"testing"
+	"time"
+)
+
+// TestTimerCloseAt checks when forgotten timers are stopped, never after the
+// end of the local day they started.
+func TestTimerCloseAt(t *testing.T) {
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2027, 3, day, hour, min, 0, 0, time.UTC)
+	}
+	cet := time.FixedZone("CET", 60*60)
+
+	tests := []struct {
+		name      string
+		mode      string
+		start     time.Time
+		scheduled float64
+		loc       *time.Location
+		expected  time.Time
+	}{
+		{
+			name:     "end of day",
+			mode:     domain.TimerCloseEndOfDay,
+			start:    at(1, 8, 0),
+			expected: at(2, 0, 0),
+		},
+		{
+			name:     "fixed time",
+			mode:     domain.TimerCloseFixedTime,
+			start:    at(1, 8, 0),
+			expected: at(1, 18, 30),
+		},
+		{
+			name:     "started after fixed time",
+			mode:     domain.TimerCloseFixedTime,
+			start:    at(1, 19, 0),
+			expected: at(2, 0, 0),
+		},
+		{
+			name:     "max hours",
+			mode:     domain.TimerCloseMaxHours,
+			start:    at(1, 8, 0),
+			expected: at(1, 18, 0),
+		},
+		{
+			name:     "max hours capped at end of day",
+			mode:     domain.TimerCloseMaxHours,
+			start:    at(1, 20, 0),
+			expected: at(2, 0, 0),
+		},
+		{
+			name:      "scheduled day length",
+			mode:      domain.TimerCloseSchedule,
+			start:     at(1, 9, 15),
+			scheduled: 7.5,
+			expected:  at(1, 16, 45),
+		},
+		{
+			name:     "nothing scheduled",
+			mode:     domain.TimerCloseSchedule,
+			start:    at(1, 9, 15),
+			expected: at(2, 0, 0),
+		},
+		{
+			name:     "local end of day",
+			mode:     domain.TimerCloseEndOfDay,
+			start:    at(1, 8, 0),
+			loc:      cet,
+			expected: at(1, 23, 0),
+		},
+		{
+			name:     "local fixed time",
+			mode:     domain.TimerCloseFixedTime,
+			start:    at(1, 8, 0),
+			loc:      cet,
+			expected: at(1, 17, 30),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			policy, err := domain.ParseTimerClosePolicy(tc.mode, "18:30", 10)
+			if err != nil {
+				t.Fatalf("ParseTimerClosePolicy() error = %v", err)
+			}
+
+			if tc.loc == nil {
+				tc.loc = time.UTC
+			}
+			got := policy.CloseAt(tc.start, tc.scheduled, tc.loc)
+			if !got.Equal(tc.expected) {
+				t.Errorf("CloseAt() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
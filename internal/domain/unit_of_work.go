@@ -0,0 +1,11 @@
+package domain
+
+import "context"
+
+// UnitOfWork runs service operations that span several repositories
+// atomically. The repositories called with the context passed to fn share a
+// single transaction that is committed when fn returns nil and rolled back
+// otherwise. Nested calls join the transaction of the outer one.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"math"
 	"slices"
 	"time"
 )
@@ -23,31 +24,124 @@ func IsValidRole(role Role) bool {
 }
 
 type User struct {
-	ID           int64     `json:"id"`
-	Username     string    `json:"username"`
-	Email        string    `json:"email"`
-	Password     string    `json:"-"`
-	VacationDays int64     `json:"vacation_days"`
-	IsSuperuser  bool      `json:"is_superuser"`
-	CreatedAt    time.Time `json:"created_at"`
-	EditedAt     time.Time `json:"edited_at"`
-	Color        string    `json:"color"`
-	Role         string    `json:"role"`
-	Enabled      bool      `json:"enabled"`
-	AworkID      *string   `json:"awork_id"`
-	WorkdayHours float64   `json:"workday_hours"`
-	WorkdaysWeek float64   `json:"workdays_week"`
+	ID           int64      `json:"id"`
+	Username     string     `json:"username"`
+	Email        string     `json:"email"`
+	Password     string     `json:"-"`
+	VacationDays int64      `json:"vacation_days"`
+	IsSuperuser  bool       `json:"is_superuser"`
+	CreatedAt    time.Time  `json:"created_at"`
+	EditedAt     time.Time  `json:"edited_at"`
+	Color        string     `json:"color"`
+	Role         string     `json:"role"`
+	Enabled      bool       `json:"enabled"`
+	AworkID      *string    `json:"awork_id"`
+	WorkdayHours float64    `json:"workday_hours"`
+	WorkdaysWeek float64    `json:"workdays_week"`
+	EntryDate    *time.Time `json:"entry_date"`
+	ExitDate     *time.Time `json:"exit_date"`
+	Schedule     *string    `json:"schedule"`
+	Region       *string    `json:"region"`
 }
 
 func (u *User) IsAdmin() bool {
 	return u.IsSuperuser
 }
 
+// IsStaff reports whether the user is an enabled employee, which excludes the
+// bot user that owns the holidays.
+func (u *User) IsStaff(botName string) bool {
+	return u.Enabled && u.Username != botName
+}
+
+// HolidayRegion returns the region whose public holidays apply to the user,
+// users without a valid region use the fallback.
+func (u *User) HolidayRegion(fallback Region) Region {
+	if u.Region != nil && IsValidRegion(Region(*u.Region)) {
+		return Region(*u.Region)
+	}
+
+	return fallback
+}
+
+// MonthsEmployed counts the calendar months of the year that the user was
+// employed for in full, entry and exit date are inclusive.
+func (u *User) MonthsEmployed(year int) int {
+	months := 0
+	for m := time.January; m <= time.December; m++ {
+		first := time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+		if u.employedFor(first, first.AddDate(0, 1, -1)) {
+			months++
+		}
+	}
+
+	return months
+}
+
+// EmployedOn reports whether the user is employed on the given day.
+func (u *User) EmployedOn(day time.Time) bool {
+	day = truncateDay(day)
+	return u.employedFor(day, day)
+}
+
+// EmployedInYear reports whether the user is employed on any day of the year.
+func (u *User) EmployedInYear(year int) bool {
+	first := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(1, 0, -1)
+	if u.EntryDate != nil && truncateDay(*u.EntryDate).After(last) {
+		return false
+	}
+
+	return u.ExitDate == nil || !truncateDay(*u.ExitDate).Before(first)
+}
+
+// employedFor reports whether the user was employed on every day of [first, last].
+func (u *User) employedFor(first, last time.Time) bool {
+	if u.EntryDate != nil && truncateDay(*u.EntryDate).After(first) {
+		return false
+	}
+
+	return u.ExitDate == nil || !truncateDay(*u.ExitDate).Before(last)
+}
+
+// VacationEntitlement returns the vacation days the user is entitled to in the
+// year. Every month employed in full earns a twelfth of the yearly vacation
+// days, scaled by the workdays per week relative to the standard week, using
+// the contract in effect on each day. The result is rounded to half days.
+func (u *User) VacationEntitlement(
+	year int,
+	standardWeek float64,
+	contracts []UserContract,
+) float64 {
+	days := 0.0
+	for m := time.January; m <= time.December; m++ {
+		first := time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+		last := first.AddDate(0, 1, -1)
+		if !u.employedFor(first, last) {
+			continue
+		}
+
+		monthDays := float64(last.Day())
+		for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+			c := u.ContractAt(contracts, d)
+			share := float64(c.VacationDays) / 12 / monthDays
+			if c.WorkdaysWeek > 0 && standardWeek > 0 {
+				share = share * c.WorkdaysWeek / standardWeek
+			}
+			days += share
+		}
+	}
+
+	return math.Round(days*2) / 2
+}
+
 type UserWithVacation struct {
 	User
-	VacationRemaining float64 `json:"vacation_remaining"`
-	VacationUsed      float64 `json:"vacation_used"`
-	PendingEvents     int     `json:"pending_events"`
+	VacationEntitlement float64 `json:"vacation_entitlement"`
+	MonthsEmployed      int     `json:"months_employed"`
+	VacationRemaining   float64 `json:"vacation_remaining"`
+	VacationUsed        float64 `json:"vacation_used"`
+	PendingEvents       int     `json:"pending_events"`
 }
 
 type PatchUser struct {
@@ -70,6 +164,10 @@ type ApiPatchUser struct {
 	AworkID      *string `form:"awork_id"`
 	WorkdayHours float64 `form:"workday_hours"`
 	WorkdaysWeek float64 `form:"workdays_week"`
+	EntryDate    *string `form:"entry_date"`
+	ExitDate     *string `form:"exit_date"`
+	Schedule     *string `form:"schedule"`
+	Region       *string `form:"region"`
 }
 
 type CreateUser struct {
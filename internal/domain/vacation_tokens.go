@@ -2,22 +2,276 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"time"
 )
 
+// VacationReason records why a vacation token was created.
+type VacationReason string
+
+var (
+	ReasonYearlyGrant     VacationReason = "yearly_grant"
+	ReasonRequestAccepted VacationReason = "request_accepted"
+	ReasonAdminAdjustment VacationReason = "admin_adjustment"
+	ReasonCancellation    VacationReason = "cancellation"
+	ReasonCarryOver       VacationReason = "carry_over"
+	ReasonExpiry          VacationReason = "expiry"
+	ReasonCompanyClosure  VacationReason = "company_closure"
+)
+
 type VacationToken struct {
-	ID        int64     `json:"id"`
-	StartDate time.Time `json:"start_date"`
-	EndDate   time.Time `json:"end_date"`
-	Value     float64   `json:"value"`
-	UserID    int64     `json:"user_id"`
+	ID        int64          `json:"id"`
+	StartDate time.Time      `json:"start_date"`
+	EndDate   time.Time      `json:"end_date"`
+	Value     float64        `json:"value"`
+	UserID    int64          `json:"user_id"`
+	Reason    VacationReason `json:"reason"`
+	RequestID *int64         `json:"request_id"`
+	EventID   *int64         `json:"event_id"`
+	CreatedBy *int64         `json:"created_by"`
+	CreatedAt time.Time      `json:"created_at"`
+	AbsenceID *int64         `json:"absence_id"`
 }
 
 type CreateVacationToken struct {
+	StartDate time.Time      `json:"start_date"`
+	EndDate   time.Time      `json:"end_date"`
+	Value     float64        `json:"value"`
+	UserID    int64          `json:"user_id"`
+	Reason    VacationReason `json:"reason"`
+	RequestID *int64         `json:"request_id"`
+	EventID   *int64         `json:"event_id"`
+	CreatedBy *int64         `json:"created_by"`
+	AbsenceID *int64         `json:"absence_id"`
+}
+
+// VacationYear returns the validity window of the regular tokens of a year.
+// Vacation that is left at the end of the year is moved into the next year
+// by the year close as a carry-over.
+func VacationYear(year int) (time.Time, time.Time) {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(1, 0, -1)
+}
+
+// NewVacationToken returns a token that is valid for the given year.
+func NewVacationToken(
+	value float64,
+	year int,
+	userId int64,
+	reason VacationReason,
+) CreateVacationToken {
+	start, end := VacationYear(year)
+	return CreateVacationToken{
+		StartDate: start,
+		EndDate:   end,
+		UserID:    userId,
+		Value:     value,
+		Reason:    reason,
+	}
+}
+
+// VacationPot is the balance of all tokens of a user that share the same
+// validity window.
+type VacationPot struct {
 	StartDate time.Time `json:"start_date"`
 	EndDate   time.Time `json:"end_date"`
 	Value     float64   `json:"value"`
-	UserID    int64     `json:"user_id"`
+}
+
+func (p *VacationPot) Contains(t time.Time) bool {
+	return !t.Before(p.StartDate) && !t.After(p.EndDate)
+}
+
+func (p *VacationPot) sameWindow(start, end time.Time) bool {
+	return p.StartDate.Equal(start) && p.EndDate.Equal(end)
+}
+
+// AllocateVacation books the days onto the pots, first in first out: every
+// day is taken from the pot that is valid on that day and expires first while
+// it has days left. Days no pot can cover are booked onto the regular window
+// of their year. It returns the booked days per window.
+func AllocateVacation(days []AbsenceDay, t EventType, pots []VacationPot) []VacationPot {
+	booked, _ := allocate(days, t, pots)
+	return booked
+}
+
+// VacationShortfall returns how many of the days the pots cannot cover, zero
+// if the balance is sufficient.
+func VacationShortfall(days []AbsenceDay, t EventType, pots []VacationPot) float64 {
+	_, missing := allocate(days, t, pots)
+	return missing
+}
+
+func allocate(days []AbsenceDay, t EventType, pots []VacationPot) ([]VacationPot, float64) {
+	left := slices.Clone(pots)
+	slices.SortStableFunc(left, func(a, b VacationPot) int {
+		return a.EndDate.Compare(b.EndDate)
+	})
+
+	booked := []VacationPot{}
+	missing := 0.0
+	book := func(start, end time.Time, value float64) {
+		for i := range booked {
+			if booked[i].sameWindow(start, end) {
+				booked[i].Value += value
+				return
+			}
+		}
+		booked = append(booked, VacationPot{StartDate: start, EndDate: end, Value: value})
+	}
+
+	for _, d := range days {
+		e := Event{HalfDay: d.HalfDay}
+		amount := e.Days(t)
+
+		for i := range left {
+			if amount <= 0 {
+				break
+			}
+			if left[i].Value <= 0 || !left[i].Contains(d.Date) {
+				continue
+			}
+
+			take := min(amount, left[i].Value)
+			left[i].Value -= take
+			amount -= take
+			book(left[i].StartDate, left[i].EndDate, take)
+		}
+
+		if amount > 0 {
+			start, end := VacationYear(d.Date.Year())
+			book(start, end, amount)
+			missing += amount
+		}
+	}
+
+	return booked, missing
+}
+
+// YearlyGranted sums the yearly grants within the tokens. Positive tokens from
+// before the ledger had reasons are migrated as admin adjustments without an
+// author or link, they are the old yearly grants and count as granted as well.
+func YearlyGranted(tokens []VacationToken) float64 {
+	granted := 0.0
+	for _, t := range tokens {
+		if t.Reason == ReasonYearlyGrant || t.isLegacy() {
+			granted += t.Value
+		}
+	}
+
+	return granted
+}
+
+// isLegacy reports whether the token is an old grant migrated from before the
+// ledger, adjustments made since then always record the admin who made them.
+// Negative legacy tokens are deductions and never count as granted.
+func (t *VacationToken) isLegacy() bool {
+	return t.Value > 0 &&
+		t.Reason == ReasonAdminAdjustment &&
+		t.CreatedBy == nil &&
+		t.RequestID == nil &&
+		t.EventID == nil &&
+		t.AbsenceID == nil
+}
+
+// RefundVacation returns the tokens that bring the balance of the given tokens
+// back to zero per user and validity window.
+func RefundVacation(tokens []VacationToken, reason VacationReason) []CreateVacationToken {
+	refunds := []CreateVacationToken{}
+	for _, t := range tokens {
+		i := slices.IndexFunc(refunds, func(r CreateVacationToken) bool {
+			return r.UserID == t.UserID && r.StartDate.Equal(t.StartDate) && r.EndDate.Equal(t.EndDate)
+		})
+		if i < 0 {
+			refunds = append(refunds, CreateVacationToken{
+				StartDate: t.StartDate,
+				EndDate:   t.EndDate,
+				UserID:    t.UserID,
+				Reason:    reason,
+				EventID:   t.EventID,
+				AbsenceID: t.AbsenceID,
+			})
+			i = len(refunds) - 1
+		}
+		refunds[i].Value -= t.Value
+	}
+
+	return slices.DeleteFunc(refunds, func(r CreateVacationToken) bool {
+		return r.Value == 0
+	})
+}
+
+// PartialRefund limits the refunds to amount days in total. The days are
+// given back into the windows that expire last, which the booking used last.
+func PartialRefund(refunds []CreateVacationToken, amount float64) []CreateVacationToken {
+	sorted := slices.Clone(refunds)
+	slices.SortStableFunc(sorted, func(a, b CreateVacationToken) int {
+		return b.EndDate.Compare(a.EndDate)
+	})
+
+	partial := []CreateVacationToken{}
+	for _, r := range sorted {
+		if amount <= 0 {
+			break
+		}
+		if r.Value <= 0 {
+			continue
+		}
+
+		r.Value = min(r.Value, amount)
+		amount -= r.Value
+		partial = append(partial, r)
+	}
+
+	return partial
+}
+
+// CarryOver returns how many of the remaining days are carried over into the
+// next year, a negative limit disables the cap.
+func CarryOver(remaining float64, limit float64) float64 {
+	if remaining <= 0 {
+		return 0
+	}
+	if limit >= 0 && remaining > limit {
+		return limit
+	}
+	return remaining
+}
+
+// CarryOverExpiry returns the date in year until which carried over vacation
+// can be taken, monthDay is formatted as MM-DD.
+func CarryOverExpiry(year int, monthDay string) (time.Time, error) {
+	return time.Parse(time.DateOnly, fmt.Sprintf("%04d-%v", year, monthDay))
+}
+
+type VacationLedgerEntry struct {
+	VacationToken
+	Balance float64 `json:"balance"`
+}
+
+type VacationLedger struct {
+	UserID  int64                 `json:"user_id"`
+	Year    int                   `json:"year"`
+	Balance float64               `json:"balance"`
+	Entries []VacationLedgerEntry `json:"entries"`
+}
+
+// NewVacationLedger adds up the tokens in the given order and records the
+// running balance after each of them.
+func NewVacationLedger(userId int64, year int, tokens []VacationToken) VacationLedger {
+	ledger := VacationLedger{
+		UserID:  userId,
+		Year:    year,
+		Entries: make([]VacationLedgerEntry, len(tokens)),
+	}
+
+	for i, t := range tokens {
+		ledger.Balance += t.Value
+		ledger.Entries[i] = VacationLedgerEntry{VacationToken: t, Balance: ledger.Balance}
+	}
+
+	return ledger
 }
 
 type VacationTokenRepository interface {
@@ -30,4 +284,32 @@ type VacationTokenRepository interface {
 		start time.Time,
 		end time.Time,
 	) (float64, error)
+	GetForUser(ctx context.Context, userId int64, year int) ([]VacationToken, error)
+	// GetForAbsence returns the tokens that were booked and refunded for the
+	// absence.
+	GetForAbsence(ctx context.Context, absenceId int64) ([]VacationToken, error)
+	// GetForEvent returns all tokens that are linked to the event.
+	GetForEvent(ctx context.Context, eventId int64) ([]VacationToken, error)
+	// GetPots returns the balances per validity window of all tokens that
+	// overlap [start, end], ordered by their end date.
+	GetPots(ctx context.Context, userId int64, start, end time.Time) ([]VacationPot, error)
+}
+
+type YearClosing struct {
+	Year      int       `json:"year"`
+	CreatedAt time.Time `json:"created_at"`
+	ClosedBy  *int64    `json:"closed_by"`
+}
+
+type YearClosingRepository interface {
+	// Close stores the tokens of the year close together with the closing
+	// itself in a single transaction.
+	Close(
+		ctx context.Context,
+		year int,
+		closedBy *int64,
+		tokens []CreateVacationToken,
+	) (*YearClosing, error)
+	// GetByYear returns nil if the year was not closed yet.
+	GetByYear(ctx context.Context, year int) (*YearClosing, error)
 }
@@ -0,0 +1,339 @@
+package domain_test
+
+import (
+	"chrono/internal/domain"
+	"testing"
+	"time"
+)
+
+// TestAllocateVacation checks that days are taken from the pot that expires
+// first and that uncovered days fall back to the regular window.
+func TestAllocateVacation(t *testing.T) {
+	typ := domain.EventType{Name: "urlaub", Weight: 1.0, ConsumesVacation: true}
+	yearStart, yearEnd := domain.VacationYear(2027)
+	carry := domain.VacationPot{
+		StartDate: yearStart,
+		EndDate:   date(2027, 3, 31),
+		Value:     2,
+	}
+	regular := domain.VacationPot{StartDate: yearStart, EndDate: yearEnd, Value: 30}
+
+	tests := []struct {
+		name     string
+		days     []domain.AbsenceDay
+		pots     []domain.VacationPot
+		expected []domain.VacationPot
+	}{
+		{
+			name: "carry over first",
+			days: domain.AbsenceDays(date(2027, 3, 1), date(2027, 3, 3), false, false, nil),
+			pots: []domain.VacationPot{regular, carry},
+			expected: []domain.VacationPot{
+				{StartDate: carry.StartDate, EndDate: carry.EndDate, Value: 2},
+				{StartDate: regular.StartDate, EndDate: regular.EndDate, Value: 1},
+			},
+		},
+		{
+			name: "carry over expired",
+			days: domain.AbsenceDays(date(2027, 4, 1), date(2027, 4, 2), false, false, nil),
+			pots: []domain.VacationPot{regular, carry},
+			expected: []domain.VacationPot{
+				{StartDate: regular.StartDate, EndDate: regular.EndDate, Value: 2},
+			},
+		},
+		{
+			name: "half day split",
+			days: domain.AbsenceDays(date(2027, 3, 1), date(2027, 3, 3), false, true, nil),
+			pots: []domain.VacationPot{carry, regular},
+			expected: []domain.VacationPot{
+				{StartDate: carry.StartDate, EndDate: carry.EndDate, Value: 2},
+				{StartDate: regular.StartDate, EndDate: regular.EndDate, Value: 0.5},
+			},
+		},
+		{
+			name: "no pots",
+			days: domain.AbsenceDays(date(2026, 12, 31), date(2027, 1, 1), false, false, nil),
+			pots: nil,
+			expected: []domain.VacationPot{
+				{StartDate: date(2026, 1, 1), EndDate: date(2026, 12, 31), Value: 1},
+				{StartDate: regular.StartDate, EndDate: regular.EndDate, Value: 1},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := domain.AllocateVacation(tc.days, typ, tc.pots)
+			if len(got) != len(tc.expected) {
+				t.Fatalf("AllocateVacation() = %v, want %v", got, tc.expected)
+			}
+			for i := range got {
+				if !got[i].StartDate.Equal(tc.expected[i].StartDate) ||
+					!got[i].EndDate.Equal(tc.expected[i].EndDate) ||
+					got[i].Value != tc.expected[i].Value {
+					t.Errorf("AllocateVacation()[%d] = %v, want %v", i, got[i], tc.expected[i])
+				}
+			}
+		})
+	}
+}
+
+// TestCarryOver checks the optional cap of carried over days.
+func TestCarryOver(t *testing.T) {
+	tests := []struct {
+		remaining float64
+		limit     float64
+		expected  float64
+	}{
+		{remaining: 7, limit: -1, expected: 7},
+		{remaining: 7, limit: 5, expected: 5},
+		{remaining: 3, limit: 5, expected: 3},
+		{remaining: -2, limit: 5, expected: 0},
+		{remaining: 4, limit: 0, expected: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(funcName(tc.remaining, tc.limit), func(t *testing.T) {
+			got := domain.CarryOver(tc.remaining, tc.limit)
+			if got != tc.expected {
+				t.Errorf("CarryOver(%v, %v) = %v, want %v", tc.remaining, tc.limit, got, tc.expected)
+			}
+		})
+	}
+}
+
+// TestYearlyGranted checks that migrated grants count as granted while
+// migrated deductions, attributed adjustments and bookings don't.
+func TestYearlyGranted(t *testing.T) {
+	admin := int64(1)
+	request := int64(7)
+	tokens := []domain.VacationToken{
+		{Value: 30, Reason: domain.ReasonYearlyGrant},
+		{Value: -2, Reason: domain.ReasonYearlyGrant},
+		{Value: 28, Reason: domain.ReasonAdminAdjustment},
+		// deductions migrated before negative tokens were labeled
+		{Value: -4, Reason: domain.ReasonAdminAdjustment},
+		{Value: -3, Reason: domain.ReasonRequestAccepted},
+		{Value: 3, Reason: domain.ReasonAdminAdjustment, CreatedBy: &admin},
+		{Value: -1, Reason: domain.ReasonRequestAccepted, RequestID: &request},
+		{Value: 5, Reason: domain.ReasonCarryOver},
+	}
+
+	got := domain.YearlyGranted(tokens)
+	if got != 56 {
+		t.Errorf("YearlyGranted() = %v, want 56", got)
+	}
+}
+
+// TestRefundVacation checks that booked tokens are netted per user and window.
+func TestRefundVacation(t *testing.T) {
+	yearStart, yearEnd := domain.VacationYear(2027)
+	token := func(userId int64, end time.Time, value float64) domain.VacationToken {
+		return domain.VacationToken{StartDate: yearStart, EndDate: end, Value: value, UserID: userId}
+	}
+
+	tests := []struct {
+		name     string
+		tokens   []domain.VacationToken
+		expected []domain.CreateVacationToken
+	}{
+		{
+			name:     "nothing booked",
+			tokens:   nil,
+			expected: []domain.CreateVacationToken{},
+		},
+		{
+			name:   "per user",
+			tokens: []domain.VacationToken{token(2, yearEnd, -1), token(3, yearEnd, -0.5)},
+			expected: []domain.CreateVacationToken{
+				{StartDate: yearStart, EndDate: yearEnd, Value: 1, UserID: 2},
+				{StartDate: yearStart, EndDate: yearEnd, Value: 0.5, UserID: 3},
+			},
+		},
+		{
+			name: "per window",
+			tokens: []domain.VacationToken{
+				token(2, date(2027, 3, 31), -0.5),
+				token(2, yearEnd, -0.5),
+			},
+			expected: []domain.CreateVacationToken{
+				{StartDate: yearStart, EndDate: date(2027, 3, 31), Value: 0.5, UserID: 2},
+				{StartDate: yearStart, EndDate: yearEnd, Value: 0.5, UserID: 2},
+			},
+		},
+		{
+			name: "already refunded",
+			tokens: []domain.VacationToken{
+				token(2, yearEnd, -1),
+				token(2, yearEnd, 1),
+				token(3, yearEnd, -1),
+			},
+			expected: []domain.CreateVacationToken{
+				{StartDate: yearStart, EndDate: yearEnd, Value: 1, UserID: 3},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := domain.RefundVacation(tc.tokens, domain.ReasonCancellation)
+			if len(got) != len(tc.expected) {
+				t.Fatalf("RefundVacation() = %v, want %v", got, tc.expected)
+			}
+			for i := range got {
+				if got[i].UserID != tc.expected[i].UserID ||
+					!got[i].EndDate.Equal(tc.expected[i].EndDate) ||
+					got[i].Value != tc.expected[i].Value ||
+					got[i].Reason != domain.ReasonCancellation {
+					t.Errorf("RefundVacation()[%d] = %v, want %v", i, got[i], tc.expected[i])
+				}
+			}
+		})
+	}
+}
+
+// TestPartialRefund checks that partial refunds go into the windows that
+// expire last first.
+func TestPartialRefund(t *testing.T) {
+	yearStart, yearEnd := domain.VacationYear(2027)
+	carry := domain.CreateVacationToken{StartDate: yearStart, EndDate: date(2027, 3, 31), Value: 2}
+	regular := domain.CreateVacationToken{StartDate: yearStart, EndDate: yearEnd, Value: 1.5}
+
+	tests := []struct {
+		name     string
+		refunds  []domain.CreateVacationToken
+		amount   float64
+		expected []domain.CreateVacationToken
+	}{
+		{
+			name:     "nothing to refund",
+			refunds:  []domain.CreateVacationToken{carry, regular},
+			amount:   0,
+			expected: []domain.CreateVacationToken{},
+		},
+		{
+			name:    "latest window first",
+			refunds: []domain.CreateVacationToken{carry, regular},
+			amount:  1,
+			expected: []domain.CreateVacationToken{
+				{StartDate: yearStart, EndDate: yearEnd, Value: 1},
+			},
+		},
+		{
+			name:    "spills into earlier window",
+			refunds: []domain.CreateVacationToken{carry, regular},
+			amount:  2.5,
+			expected: []domain.CreateVacationToken{
+				{StartDate: yearStart, EndDate: yearEnd, Value: 1.5},
+				{StartDate: yearStart, EndDate: date(2027, 3, 31), Value: 1},
+			},
+		},
+		{
+			name:    "capped at booked",
+			refunds: []domain.CreateVacationToken{regular},
+			amount:  3,
+			expected: []domain.CreateVacationToken{
+				{StartDate: yearStart, EndDate: yearEnd, Value: 1.5},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := domain.PartialRefund(tc.refunds, tc.amount)
+			if len(got) != len(tc.expected) {
+				t.Fatalf("PartialRefund() = %v, want %v", got, tc.expected)
+			}
+			for i := range got {
+				if !got[i].EndDate.Equal(tc.expected[i].EndDate) || got[i].Value != tc.expected[i].Value {
+					t.Errorf("PartialRefund()[%d] = %v, want %v", i, got[i], tc.expected[i])
+				}
+			}
+		})
+	}
+}
+
+// TestVacationEntitlement checks the pro rata entitlement for joiners,
+// leavers and part time users.
+func TestVacationEntitlement(t *testing.T) {
+	entry := func(y int, m time.Month, d int) *time.Time {
+		t := date(y, m, d)
+		return &t
+	}
+
+	tests := []struct {
+		name      string
+		user      domain.User
+		contracts []domain.UserContract
+		months    int
+		expected  float64
+	}{
+		{
+			name:     "full year",
+			user:     domain.User{VacationDays: 30, WorkdaysWeek: 5},
+			months:   12,
+			expected: 30,
+		},
+		{
+			name:     "joined in july",
+			user:     domain.User{VacationDays: 30, WorkdaysWeek: 5, EntryDate: entry(2026, 7, 1)},
+			months:   6,
+			expected: 15,
+		},
+		{
+			name:     "joined mid month",
+			user:     domain.User{VacationDays: 30, WorkdaysWeek: 5, EntryDate: entry(2026, 7, 15)},
+			months:   5,
+			expected: 12.5,
+		},
+		{
+			name:     "left in march",
+			user:     domain.User{VacationDays: 30, WorkdaysWeek: 5, ExitDate: entry(2026, 3, 31)},
+			months:   3,
+			expected: 7.5,
+		},
+		{
+			name:     "part time",
+			user:     domain.User{VacationDays: 30, WorkdaysWeek: 3},
+			months:   12,
+			expected: 18,
+		},
+		{
+			name: "part time joiner rounded",
+			user: domain.User{
+				VacationDays: 28,
+				WorkdaysWeek: 4,
+				EntryDate:    entry(2026, 4, 1),
+			},
+			months:   9,
+			expected: 17,
+		},
+		{
+			name: "reduced hours from july",
+			user: domain.User{VacationDays: 30, WorkdaysWeek: 5},
+			contracts: []domain.UserContract{
+				{ValidFrom: date(1970, 1, 1), ValidTo: entry(2026, 6, 30), VacationDays: 30, WorkdaysWeek: 5},
+				{ValidFrom: date(2026, 7, 1), VacationDays: 30, WorkdaysWeek: 4},
+			},
+			months:   12,
+			expected: 27,
+		},
+		{
+			name:     "joined next year",
+			user:     domain.User{VacationDays: 30, WorkdaysWeek: 5, EntryDate: entry(2027, 1, 1)},
+			months:   0,
+			expected: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.user.MonthsEmployed(2026); got != tc.months {
+				t.Errorf("MonthsEmployed() = %v, want %v", got, tc.months)
+			}
+			if got := tc.user.VacationEntitlement(2026, 5, tc.contracts); got != tc.expected {
+				t.Errorf("VacationEntitlement() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
@@ -23,33 +23,63 @@ import (
 )
 
 type repos struct {
+	absence    domain.AbsenceRepository
+	approver   domain.ApproverRepository
+	autoApp    domain.AutoApprovePolicyRepository
 	apiCache   domain.ApiCacheRepository
+	cancel     domain.CancellationRepository
+	company    domain.CompanyHolidayRepository
 	event      domain.EventRepository
+	eventType  domain.EventTypeRepository
+	jobRun     domain.JobRunRepository
 	notif      domain.NotificationRepository
 	notifUser  domain.NotificationUserRepository
 	refresh    domain.RefreshTokenRepository
 	request    domain.RequestRepository
+	comment    domain.RequestCommentRepository
 	session    domain.SessionRepository
 	settings   domain.SettingsRepository
+	staffing   domain.StaffingRepository
+	team       domain.TeamRepository
 	user       domain.UserRepository
+	contract   domain.UserContractRepository
 	vac        domain.VacationTokenRepository
 	timestamps domain.TimestampsRepository
+	breaks     domain.TimestampBreakRepository
+	correction domain.TimestampCorrectionRepository
+	history    domain.TimestampHistoryRepository
+	idemKey    domain.IdempotencyKeyRepository
+	overtime   domain.OvertimeRepository
+	yearClose  domain.YearClosingRepository
+	uow        domain.UnitOfWork
 }
 
 type services struct {
+	absence    *service.AbsenceService
 	apiBot     *service.APIBot
+	approval   *service.ApprovalService
 	auth       *service.AuthService
+	autoApp    *service.AutoApproveService
+	cancel     *service.CancellationService
+	company    *service.CompanyHolidayService
 	event      *service.EventService
+	eventType  *service.EventTypeService
 	holiday    *service.HolidayService
+	scheduler  *service.Scheduler
 	notif      *service.NotificationService
 	request    *service.RequestService
 	settings   *service.SettingsService
+	staffing   *service.StaffingService
 	token      *service.TokenService
 	user       *service.UserService
+	contract   *service.UserContractService
 	pwHasher   auth.PasswordHasher
 	krank      *service.KrankheitsExport
 	awork      *service.AworkService
 	timestamps *service.TimestampsService
+	correction *service.TimestampCorrectionService
+	overtime   *service.OvertimeService
+	yearClose  *service.YearCloseService
 }
 
 type Server struct {
@@ -62,11 +92,11 @@ type Server struct {
 	services services
 }
 
-func NewServer(router *echo.Echo, db *sql.DB, cfg *config.Config, log *slog.Logger) *Server {
+func NewServer(router *echo.Echo, conn *sql.DB, cfg *config.Config, log *slog.Logger) *Server {
 	return &Server{
 		Router: router,
-		Db:     db,
-		Repo:   repo.New(db),
+		Db:     conn,
+		Repo:   repo.New(db.NewDBTX(conn)),
 		log:    log,
 		cfg:    cfg,
 	}
@@ -133,6 +163,7 @@ func (s *Server) InitRepos() {
 	notificationUserRepo := db.NewSQLUserNotificationRepo(s.Repo, s.log)
 	notificationRepo := db.NewSQLNotificationRepo(s.Repo, s.log)
 	eventRepo := db.NewSQLEventUserRepo(s.Repo, s.log)
+	eventTypeRepo := db.NewSQLEventTypeRepo(s.Repo, s.log)
 	requestRepo := db.NewSQLRequestRepo(s.Repo, s.log)
 	sessionRepo := db.NewSQLSessionRepo(s.Repo, s.log)
 	refreshTokenRepo := db.NewSQLRefreshTokenRepo(s.Repo, s.log)
@@ -140,12 +171,30 @@ func (s *Server) InitRepos() {
 	apiCacheRepo := db.NewSQLAPICacheRepo(s.Repo, s.log)
 	settingsRepo := db.NewSQLSettingsRepo(s.Repo, s.log)
 	timestampsRepo := db.NewSQLTimestampsRepo(s.Repo, s.log)
+	breakRepo := db.NewSQLTimestampBreakRepo(s.Repo, s.log)
+	correctionRepo := db.NewSQLTimestampCorrectionRepo(s.Repo, s.log)
+	historyRepo := db.NewSQLTimestampHistoryRepo(s.Repo, s.log)
+	idemKeyRepo := db.NewSQLIdempotencyKeyRepo(s.Repo, s.log)
+	overtimeRepo := db.NewSQLOvertimeRepo(s.Repo, s.log)
+	absenceRepo := db.NewSQLAbsenceRepo(s.Db, s.log)
+	yearCloseRepo := db.NewSQLYearClosingRepo(s.Db, s.log)
+	contractRepo := db.NewSQLUserContractRepo(s.Db, s.log)
+	companyRepo := db.NewSQLCompanyHolidayRepo(s.Db, s.log)
+	jobRunRepo := db.NewSQLJobRunRepo(s.Repo, s.log)
+	teamRepo := db.NewSQLTeamRepo(s.Repo, s.log)
+	approverRepo := db.NewSQLApproverRepo(s.Repo, s.log)
+	cancelRepo := db.NewSQLCancellationRepo(s.Db, s.log)
+	staffingRepo := db.NewSQLStaffingRepo(s.Repo, s.log)
+	autoAppRepo := db.NewSQLAutoApproveRepo(s.Repo, s.log)
+	commentRepo := db.NewSQLRequestCommentRepo(s.Repo, s.log)
+	uow := db.NewSQLUnitOfWork(s.Db, s.log)
 
 	s.repos = repos{
 		user:       userRepo,
 		notifUser:  notificationUserRepo,
 		notif:      notificationRepo,
 		event:      eventRepo,
+		eventType:  eventTypeRepo,
 		request:    requestRepo,
 		session:    sessionRepo,
 		refresh:    refreshTokenRepo,
@@ -153,25 +202,110 @@ func (s *Server) InitRepos() {
 		apiCache:   apiCacheRepo,
 		settings:   settingsRepo,
 		timestamps: timestampsRepo,
+		breaks:     breakRepo,
+		correction: correctionRepo,
+		history:    historyRepo,
+		idemKey:    idemKeyRepo,
+		overtime:   overtimeRepo,
+		absence:    absenceRepo,
+		yearClose:  yearCloseRepo,
+		contract:   contractRepo,
+		company:    companyRepo,
+		jobRun:     jobRunRepo,
+		team:       teamRepo,
+		approver:   approverRepo,
+		cancel:     cancelRepo,
+		staffing:   staffingRepo,
+		autoApp:    autoAppRepo,
+		comment:    commentRepo,
+		uow:        uow,
 	}
 
 	s.log.Info("Initialized repositories.")
 }
 
 func (s *Server) InitServices() {
-	tokenSvc := service.NewTokenService(s.repos.refresh, s.repos.vac, s.log)
+	tokenSvc := service.NewTokenService(
+		s.repos.refresh,
+		s.repos.vac,
+		s.repos.contract,
+		s.repos.uow,
+		s.log,
+	)
 	notificationSvc := service.NewNotificationService(
 		s.repos.notif,
 		s.repos.notifUser,
 		s.log,
 	)
 	userSvc := service.NewUserService(s.repos.user, notificationSvc, tokenSvc, s.log)
-	requestSvc := service.NewRequestService(s.repos.request, s.repos.user, notificationSvc, s.log)
+	contractSvc := service.NewUserContractService(
+		s.repos.contract,
+		s.repos.user,
+		tokenSvc,
+		s.repos.uow,
+		s.log,
+	)
+	approvalSvc := service.NewApprovalService(
+		s.repos.team,
+		s.repos.approver,
+		s.repos.user,
+		s.log,
+	)
+	eventTypeSvc := service.NewEventTypeService(s.repos.eventType, s.log)
+	staffingSvc := service.NewStaffingService(
+		s.repos.staffing,
+		s.repos.event,
+		eventTypeSvc,
+		s.repos.user,
+		s.repos.contract,
+		approvalSvc,
+		s.log,
+	)
+	autoAppSvc := service.NewAutoApproveService(s.repos.autoApp, eventTypeSvc, s.log)
+	requestSvc := service.NewRequestService(
+		s.repos.request,
+		s.repos.comment,
+		s.repos.event,
+		s.repos.user,
+		approvalSvc,
+		staffingSvc,
+		notificationSvc,
+		s.repos.uow,
+		s.log,
+	)
+	absenceSvc := service.NewAbsenceService(
+		s.repos.absence,
+		s.repos.request,
+		s.repos.comment,
+		s.repos.event,
+		eventTypeSvc,
+		tokenSvc,
+		s.repos.user,
+		s.repos.contract,
+		notificationSvc,
+		approvalSvc,
+		staffingSvc,
+		autoAppSvc,
+		s.repos.uow,
+		s.log,
+	)
+	cancelSvc := service.NewCancellationService(
+		s.repos.cancel,
+		absenceSvc,
+		eventTypeSvc,
+		tokenSvc,
+		s.repos.user,
+		notificationSvc,
+		approvalSvc,
+		s.log,
+	)
 	eventSvc := service.NewEventService(
 		s.repos.event,
-		requestSvc,
+		eventTypeSvc,
+		absenceSvc,
 		userSvc,
 		tokenSvc,
+		s.repos.uow,
 		s.log,
 	)
 	passwordHasher := auth.NewBcryptHasher(10)
@@ -187,8 +321,50 @@ func (s *Server) InitServices() {
 	holidaySvc := service.NewHolidayService(userSvc, eventSvc, s.repos.apiCache, s.log)
 	settingSvc := service.NewSettingsService(s.repos.settings, s.log)
 	krankSvc := service.NewKrankheitsExportService(eventSvc, userSvc)
-	aworkSvc := service.NewAworkService(eventSvc, userSvc, s.log)
-	timestampSvc := service.NewTimestampsService(s.repos.timestamps, eventSvc, s.log)
+	aworkSvc := service.NewAworkService(eventSvc, userSvc, contractSvc, s.log)
+	timestampSvc := service.NewTimestampsService(
+		s.repos.timestamps,
+		s.repos.breaks,
+		s.repos.history,
+		s.repos.idemKey,
+		eventSvc,
+		contractSvc,
+		s.repos.user,
+		notificationSvc,
+		s.repos.uow,
+		s.log,
+	)
+	correctionSvc := service.NewTimestampCorrectionService(
+		s.repos.correction,
+		timestampSvc,
+		s.repos.breaks,
+		s.repos.user,
+		notificationSvc,
+		s.repos.uow,
+		s.log,
+	)
+	overtimeSvc := service.NewOvertimeService(
+		s.repos.overtime,
+		timestampSvc,
+		s.repos.user,
+		s.log,
+	)
+	yearCloseSvc := service.NewYearCloseService(
+		s.repos.yearClose,
+		s.repos.vac,
+		s.repos.user,
+		s.log,
+	)
+	schedulerSvc := service.NewScheduler(s.repos.jobRun, time.Minute, s.log)
+	companySvc := service.NewCompanyHolidayService(
+		s.repos.company,
+		userSvc,
+		eventSvc,
+		contractSvc,
+		tokenSvc,
+		eventTypeSvc,
+		s.log,
+	)
 
 	s.services = services{
 		token:      tokenSvc,
@@ -196,6 +372,7 @@ func (s *Server) InitServices() {
 		user:       userSvc,
 		request:    requestSvc,
 		event:      eventSvc,
+		eventType:  eventTypeSvc,
 		pwHasher:   passwordHasher,
 		auth:       authSvc,
 		holiday:    holidaySvc,
@@ -203,6 +380,17 @@ func (s *Server) InitServices() {
 		krank:      krankSvc,
 		awork:      aworkSvc,
 		timestamps: timestampSvc,
+		correction: correctionSvc,
+		overtime:   overtimeSvc,
+		absence:    absenceSvc,
+		yearClose:  yearCloseSvc,
+		contract:   contractSvc,
+		company:    companySvc,
+		scheduler:  schedulerSvc,
+		approval:   approvalSvc,
+		cancel:     cancelSvc,
+		staffing:   staffingSvc,
+		autoApp:    autoAppSvc,
 	}
 
 	s.log.Info("Initialized services.")
@@ -220,6 +408,8 @@ func (s *Server) InitAPIRoutes() {
 		s.services.event,
 		s.services.auth,
 		s.services.token,
+		s.services.contract,
+		s.services.holiday,
 		s.log,
 	)
 	eventHandler := api.NewAPIEventHandler(
@@ -230,12 +420,12 @@ func (s *Server) InitAPIRoutes() {
 	)
 	requestHandler := api.NewAPIRequestsHandler(
 		s.services.request,
-		s.services.event,
-		s.services.token,
+		s.services.absence,
 		s.log,
 	)
 	tokenHandler := api.NewAPITokenHandler(
 		s.services.token,
+		s.services.yearClose,
 		s.services.user,
 		s.services.notif,
 		s.log,
@@ -250,6 +440,20 @@ func (s *Server) InitAPIRoutes() {
 	)
 	notificationHandler := api.NewAPINotificationHandler(s.services.notif, s.log)
 	timestampsHandler := api.NewAPITimestampsHandler(s.services.timestamps, s.services.user)
+	correctionHandler := api.NewAPITimestampCorrectionHandler(s.services.correction, s.log)
+	overtimeHandler := api.NewAPIOvertimeHandler(s.services.overtime, s.services.user, s.log)
+	eventTypeHandler := api.NewAPIEventTypeHandler(s.services.eventType, s.log)
+	absenceHandler := api.NewAPIAbsenceHandler(s.services.absence, s.log)
+	companyHandler := api.NewAPICompanyHolidayHandler(s.services.company, s.log)
+	jobHandler := api.NewAPIJobHandler(s.services.scheduler)
+	teamHandler := api.NewAPITeamHandler(s.services.approval, s.log)
+	staffingHandler := api.NewAPIStaffingHandler(s.services.staffing, s.log)
+	autoAppHandler := api.NewAPIAutoApproveHandler(s.services.autoApp, s.log)
+	cancelHandler := api.NewAPICancellationHandler(
+		s.services.cancel,
+		s.services.absence,
+		s.log,
+	)
 
 	apiGrp := s.Router.Group("/api/v1")
 	authGrp := apiGrp.Group(
@@ -264,14 +468,24 @@ func (s *Server) InitAPIRoutes() {
 
 	userHandler.RegisterRoutes(authGrp)
 	eventHandler.RegisterRoutes(authGrp)
+	absenceHandler.RegisterRoutes(authGrp)
 	aworkHandler.RegisterRoutes(authGrp)
 	notificationHandler.RegisterRoutes(authGrp)
 	timestampsHandler.RegisterRoutes(authGrp, adminGrp)
+	correctionHandler.RegisterRoutes(authGrp, adminGrp)
+	overtimeHandler.RegisterRoutes(authGrp, adminGrp)
+	eventTypeHandler.RegisterRoutes(authGrp, adminGrp)
+	companyHandler.RegisterRoutes(authGrp, adminGrp)
+	staffingHandler.RegisterRoutes(authGrp, adminGrp)
+	requestHandler.RegisterRoutes(authGrp)
+	cancelHandler.RegisterRoutes(authGrp)
 
-	requestHandler.RegisterRoutes(adminGrp)
 	tokenHandler.RegisterRoutes(adminGrp)
 	settingsHandler.RegisterRoutes(adminGrp)
 	exportHander.RegisterRoutes(adminGrp)
+	jobHandler.RegisterRoutes(adminGrp)
+	teamHandler.RegisterRoutes(adminGrp)
+	autoAppHandler.RegisterRoutes(adminGrp)
 
 	apiGrp.GET(
 		"/health",
@@ -306,5 +520,46 @@ func (s *Server) PreStart() error {
 	bot := service.NewAPIBotFromEnv(s.log)
 	bot.Register(context.Background(), s.services.user, s.services.pwHasher)
 
+	err = s.services.holiday.TagLegacyHolidays(context.Background())
+	if err != nil {
+		s.log.Error("Failed to tag legacy holidays.")
+		return err
+	}
+
+	s.InitJobs()
+	s.services.scheduler.Start(context.Background())
+
 	return nil
 }
+
+// InitJobs registers the recurring background jobs with the scheduler.
+func (s *Server) InitJobs() {
+	jobs := []domain.Job{
+		{Name: "holidays", Interval: time.Hour * 24, Run: s.services.holiday.UpdateUpcoming},
+		{
+			Name:     "yearly-tokens",
+			Interval: time.Hour * 24,
+			Run: func(ctx context.Context) error {
+				return s.services.user.InitYearlyTokens(ctx, domain.CurrentYear())
+			},
+		},
+		{Name: "year-close", Interval: time.Hour * 24, Run: s.services.yearClose.CloseLastYear},
+		{Name: "sessions", Interval: time.Hour, Run: s.services.auth.DeleteExpiredSessions},
+		{Name: "timers", Interval: time.Hour, Run: s.services.timestamps.CloseForgotten},
+		{Name: "request-reminders", Interval: time.Hour, Run: s.services.request.Remind},
+		{Name: "correction-reminders", Interval: time.Hour, Run: s.services.correction.Remind},
+		{Name: "overtime-close", Interval: time.Hour * 24, Run: s.services.overtime.CloseMonths},
+	}
+	for _, job := range jobs {
+		s.services.scheduler.Register(job)
+	}
+
+	s.log.Info("Initialized jobs.")
+}
+
+// Stop stops the background jobs, the router is shut down separately.
+func (s *Server) Stop() {
+	if s.services.scheduler != nil {
+		s.services.scheduler.Stop()
+	}
+}
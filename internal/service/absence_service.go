@@ -0,0 +1,647 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"chrono/config"
+	"chrono/internal/domain"
+)
+
+type AbsenceService struct {
+	absence   domain.AbsenceRepository
+	request   domain.RequestRepository
+	comment   domain.RequestCommentRepository
+	event     domain.EventRepository
+	eventType *EventTypeService
+	token     *TokenService
+	user      domain.UserRepository
+	contract  domain.UserContractRepository
+	notif     *NotificationService
+	approval  *ApprovalService
+	staffing  *StaffingService
+	policy    *AutoApproveService
+	uow       domain.UnitOfWork
+	log       *slog.Logger
+}
+
+func NewAbsenceService(
+	a domain.AbsenceRepository,
+	r domain.RequestRepository,
+	c domain.RequestCommentRepository,
+	e domain.EventRepository,
+	et *EventTypeService,
+	t *TokenService,
+	u domain.UserRepository,
+	uc domain.UserContractRepository,
+	n *NotificationService,
+	ap *ApprovalService,
+	st *StaffingService,
+	p *AutoApproveService,
+	w domain.UnitOfWork,
+	log *slog.Logger,
+) *AbsenceService {
+	return &AbsenceService{
+		absence:   a,
+		request:   r,
+		comment:   c,
+		event:     e,
+		eventType: et,
+		token:     t,
+		user:      u,
+		contract:  uc,
+		notif:     n,
+		approval:  ap,
+		staffing:  st,
+		policy:    p,
+		uow:       w,
+		log:       log,
+	}
+}
+
+// Create stores an absence for the user together with its days. Types that
+// need approval create a pending request unless the user is a superuser, so
+// do absences that break staffing rules or blackout periods with the warning
+// severity. Breaking a rule with the error severity returns a RuleError, the
+// warnings are returned with the absence. Requests without warnings that an
+// auto approve policy matches are accepted by the bot user right away if the
+// vacation balance covers them. The message of the requester is stored with
+// the request instead of the generated text.
+func (svc *AbsenceService) Create(
+	ctx context.Context,
+	form domain.CreateAbsence,
+	user *domain.User,
+) (*domain.Absence, []domain.RuleViolation, error) {
+	message, err := domain.CleanMessage(form.Message)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	absence, typ, days, err := svc.plan(ctx, form, user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	violations, err := svc.staffing.Check(ctx, user.ID, typ, days)
+	if err != nil {
+		return nil, nil, err
+	}
+	errs, warnings := domain.SplitViolations(violations)
+	if len(errs) > 0 {
+		return nil, nil, &domain.RuleError{Violations: errs}
+	}
+
+	absence.State = "accepted"
+
+	var msg *string
+	var notification string
+	var tokens []domain.CreateVacationToken
+	if (typ.NeedsApproval || len(warnings) > 0) && !user.IsSuperuser {
+		absence.State = "pending"
+		m := absence.RequestMsg(user.Username)
+		notification = m
+		if message != "" {
+			m = message
+			notification = domain.RequestWithMessageMsg(notification, message)
+		}
+		msg = &m
+	} else if typ.ConsumesVacation {
+		tokens, err = svc.deduct(ctx, user.ID, days, typ, user.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var policy *domain.AutoApprovePolicy
+	var bot *domain.User
+	if msg != nil && len(warnings) == 0 {
+		policy, err = svc.autoApprove(ctx, user.ID, typ, days)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if policy != nil {
+		// without the bot the request is left to the approvers
+		bot, err = svc.user.GetByName(ctx, config.GetConfig().BotName)
+		if err != nil {
+			svc.log.Error(
+				"Failed to get bot for auto approval",
+				slog.Int64("policy", policy.ID),
+				slog.String("error", err.Error()),
+			)
+			policy = nil
+		}
+	}
+
+	absence, err = svc.absence.Create(ctx, absence, days, msg, tokens)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if policy != nil {
+		svc.log.Info(
+			"Auto approved absence",
+			slog.Int64("id", absence.ID),
+			slog.Int64("policy", policy.ID),
+		)
+		absence, err = svc.decide(ctx, absence, "accepted", policy.Name, bot)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else if msg != nil {
+		err = svc.notifyApprovers(ctx, notification, user.ID, domain.StageLead)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return absence, warnings, nil
+}
+
+// Quote returns the chargeable days of a planned absence, what it would cost
+// from the vacation of the user and the staffing rules and blackout periods
+// it would break, derived the same way Create does.
+func (svc *AbsenceService) Quote(
+	ctx context.Context,
+	form domain.AbsenceQuoteForm,
+	user *domain.User,
+) (*domain.AbsenceQuote, error) {
+	_, typ, days, err := svc.plan(ctx, domain.CreateAbsence{
+		EventName:    form.EventName,
+		StartDate:    form.StartDate,
+		EndDate:      form.EndDate,
+		HalfDayStart: form.HalfDayStart,
+		HalfDayEnd:   form.HalfDayEnd,
+	}, user)
+	if err != nil {
+		return nil, err
+	}
+
+	pots, err := svc.token.GetPots(ctx, user.ID, days[0].Date, days[len(days)-1].Date)
+	if err != nil {
+		return nil, err
+	}
+
+	violations, err := svc.staffing.Check(ctx, user.ID, typ, days)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := domain.QuoteAbsence(days, typ, pots)
+	_, warnings := domain.SplitViolations(violations)
+	quote.NeedsApproval = (typ.NeedsApproval || len(warnings) > 0) && !user.IsSuperuser
+	quote.Violations = violations
+
+	return &quote, nil
+}
+
+// plan validates the dates and the type of the form and derives the days of
+// the absence: weekends, holidays and the weekdays without scheduled work in
+// the contract of the user are not part of it.
+func (svc *AbsenceService) plan(
+	ctx context.Context,
+	form domain.CreateAbsence,
+	user *domain.User,
+) (*domain.Absence, domain.EventType, []domain.AbsenceDay, error) {
+	start, err := time.Parse(time.DateOnly, form.StartDate)
+	if err != nil {
+		return nil, domain.EventType{}, nil, fmt.Errorf("invalid start date %q", form.StartDate)
+	}
+	end, err := time.Parse(time.DateOnly, form.EndDate)
+	if err != nil {
+		return nil, domain.EventType{}, nil, fmt.Errorf("invalid end date %q", form.EndDate)
+	}
+	if end.Before(start) {
+		return nil, domain.EventType{}, nil, fmt.Errorf("end date must not be before start date")
+	}
+
+	typ, err := svc.eventType.Resolve(ctx, strings.ToLower(form.EventName))
+	if err != nil {
+		return nil, domain.EventType{}, nil, err
+	}
+
+	holidays, err := svc.holidays(ctx, holidayRegion(user), start, end)
+	if err != nil {
+		return nil, domain.EventType{}, nil, err
+	}
+
+	contracts, err := svc.contract.GetForUser(ctx, user.ID)
+	if err != nil {
+		return nil, domain.EventType{}, nil, err
+	}
+
+	days := domain.AbsenceDays(start, end, form.HalfDayStart, form.HalfDayEnd, holidays)
+	days = user.ScheduledDays(contracts, days)
+	if len(days) == 0 {
+		return nil, domain.EventType{}, nil, fmt.Errorf("absence does not contain any working days")
+	}
+
+	absence := &domain.Absence{
+		Name:         typ.Name,
+		StartDate:    start,
+		EndDate:      end,
+		HalfDayStart: form.HalfDayStart,
+		HalfDayEnd:   form.HalfDayEnd,
+		UserID:       user.ID,
+	}
+
+	return absence, typ, days, nil
+}
+
+// UpdateState accepts or rejects the current approval stage of an absence as
+// a whole and notifies the requesting user. Only the approvers of the stage
+// can decide it, and the fallback approvers once the request is escalated.
+// Accepting the lead stage moves the absence on to approved_by_lead if the
+// requester has final approvers, declining any stage declines the absence.
+func (svc *AbsenceService) UpdateState(
+	ctx context.Context,
+	id int64,
+	state string,
+	reason string,
+	editor *domain.User,
+) (*domain.Absence, error) {
+	if state != "accepted" && state != "declined" {
+		return nil, fmt.Errorf("invalid state %q", state)
+	}
+
+	reason, err := domain.CleanMessage(reason)
+	if err != nil {
+		return nil, err
+	}
+
+	absence, err := svc.absence.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !absence.IsAwaitingDecision() {
+		return nil, fmt.Errorf("absence %v is not pending", id)
+	}
+
+	req, err := svc.request.GetByAbsenceId(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	stage := absence.Stage()
+	err = svc.approval.CanDecideRequest(ctx, absence.UserID, stage, req.EscalatedAt != nil, editor)
+	if err != nil {
+		return nil, err
+	}
+
+	if state == "accepted" && stage == domain.StageLead {
+		final, err := svc.approval.Approvers(ctx, absence.UserID, domain.StageFinal)
+		if err != nil {
+			return nil, err
+		}
+		if len(final) > 0 {
+			return svc.approveByLead(ctx, absence, editor)
+		}
+	}
+
+	return svc.decide(ctx, absence, state, reason, editor)
+}
+
+// decide accepts or declines the absence as a whole, books its vacation and
+// notifies the requesting user. The reason of a rejection is kept as a
+// comment on the request, stored together with the decision.
+func (svc *AbsenceService) decide(
+	ctx context.Context,
+	absence *domain.Absence,
+	state string,
+	reason string,
+	editor *domain.User,
+) (*domain.Absence, error) {
+	var tokens []domain.CreateVacationToken
+	var err error
+	if state == "accepted" {
+		tokens, err = svc.acceptTokens(ctx, absence, editor.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = svc.uow.Do(ctx, func(ctx context.Context) error {
+		absence, err = svc.absence.UpdateState(ctx, absence.ID, state, editor.ID, tokens)
+		if err != nil {
+			return err
+		}
+		if state != "declined" || reason == "" {
+			return nil
+		}
+
+		req, err := svc.request.GetByAbsenceId(ctx, absence.ID)
+		if err != nil {
+			return err
+		}
+		_, err = svc.comment.Create(ctx, &domain.RequestComment{
+			RequestID: req.ID,
+			UserID:    editor.ID,
+			Kind:      domain.CommentKindRejection,
+			Body:      reason,
+			Username:  editor.Username,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	msg := domain.BatchUpdateMsg(editor.Username, state)
+	if reason != "" {
+		msg = domain.BatchUpdateReasonMsg(editor.Username, state, reason)
+	}
+
+	err = svc.notif.CreateAndNotify(ctx, msg, []domain.User{{ID: absence.UserID}})
+	if err != nil {
+		return nil, err
+	}
+
+	return absence, nil
+}
+
+// autoApprove returns the policy that accepts the absence of the user, or nil
+// if none matches or the vacation balance does not cover it.
+func (svc *AbsenceService) autoApprove(
+	ctx context.Context,
+	userId int64,
+	typ domain.EventType,
+	days []domain.AbsenceDay,
+) (*domain.AutoApprovePolicy, error) {
+	policy, err := svc.policy.Match(ctx, typ, days)
+	if err != nil || policy == nil {
+		return nil, err
+	}
+	if !typ.ConsumesVacation {
+		return policy, nil
+	}
+
+	pots, err := svc.token.GetPots(ctx, userId, days[0].Date, days[len(days)-1].Date)
+	if err != nil {
+		return nil, err
+	}
+	if domain.VacationShortfall(days, typ, pots) > 0 {
+		return nil, nil
+	}
+
+	return policy, nil
+}
+
+// approveByLead moves the absence on to the final stage and notifies its
+// approvers.
+func (svc *AbsenceService) approveByLead(
+	ctx context.Context,
+	absence *domain.Absence,
+	editor *domain.User,
+) (*domain.Absence, error) {
+	absence, err := svc.absence.UpdateState(ctx, absence.ID, "approved_by_lead", editor.ID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := svc.user.GetById(ctx, absence.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.notifyApprovers(ctx, absence.RequestMsg(user.Username), user.ID, domain.StageFinal)
+	if err != nil {
+		return nil, err
+	}
+
+	err = svc.notif.CreateAndNotify(
+		ctx,
+		domain.LeadApprovedMsg(editor.Username),
+		[]domain.User{{ID: absence.UserID}},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return absence, nil
+}
+
+// notifyApprovers sends the message to the approvers of the stage of the
+// requests of the user.
+func (svc *AbsenceService) notifyApprovers(
+	ctx context.Context,
+	msg string,
+	userId int64,
+	stage int64,
+) error {
+	ids, err := svc.approval.Approvers(ctx, userId, stage)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	approvers := make([]domain.User, len(ids))
+	for i, id := range ids {
+		approvers[i] = domain.User{ID: id}
+	}
+
+	return svc.notif.CreateAndNotify(ctx, msg, approvers)
+}
+
+// Delete removes the absence with all of its days and refunds the vacation of
+// accepted absences. Users withdraw their own accepted absences that needed
+// approval with a cancellation instead.
+func (svc *AbsenceService) Delete(
+	ctx context.Context,
+	id int64,
+	currUser *domain.User,
+) (*domain.Absence, error) {
+	absence, err := svc.absence.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !currUser.IsAdmin() && currUser.ID != absence.UserID {
+		return nil, fmt.Errorf(
+			"User: %v has no permission to delete the absence.",
+			currUser.Username,
+		)
+	}
+
+	var tokens []domain.CreateVacationToken
+	if absence.IsAccepted() {
+		typ, err := svc.eventType.Resolve(ctx, absence.Name)
+		if err != nil {
+			return nil, err
+		}
+		if typ.NeedsApproval && !currUser.IsAdmin() {
+			return nil, fmt.Errorf("accepted absences can only be withdrawn with a cancellation")
+		}
+
+		tokens, err = svc.refundTokens(ctx, absence, currUser.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = svc.absence.Delete(ctx, id, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	return absence, nil
+}
+
+func (svc *AbsenceService) GetById(ctx context.Context, id int64) (*domain.Absence, error) {
+	return svc.absence.GetById(ctx, id)
+}
+
+func (svc *AbsenceService) GetByRequestId(
+	ctx context.Context,
+	requestId int64,
+) (*domain.Absence, error) {
+	return svc.absence.GetByRequestId(ctx, requestId)
+}
+
+func (svc *AbsenceService) GetForUser(
+	ctx context.Context,
+	userId int64,
+	start, end time.Time,
+) ([]domain.Absence, error) {
+	return svc.absence.GetForUser(ctx, userId, start, end)
+}
+
+func (svc *AbsenceService) GetEvents(ctx context.Context, id int64) ([]domain.Event, error) {
+	return svc.absence.GetEvents(ctx, id)
+}
+
+// acceptTokens returns the tokens that book the days of an absence whose type
+// consumes vacation.
+func (svc *AbsenceService) acceptTokens(
+	ctx context.Context,
+	absence *domain.Absence,
+	editorId int64,
+) ([]domain.CreateVacationToken, error) {
+	typ, err := svc.eventType.Resolve(ctx, absence.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !typ.ConsumesVacation {
+		return nil, nil
+	}
+
+	days, err := svc.days(ctx, absence.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.deduct(ctx, absence.UserID, days, typ, editorId)
+}
+
+// deduct books the days onto the vacation pots of the user, the pot that
+// expires first is used first so carried over vacation is consumed before
+// the vacation of the current year.
+func (svc *AbsenceService) deduct(
+	ctx context.Context,
+	userId int64,
+	days []domain.AbsenceDay,
+	typ domain.EventType,
+	editorId int64,
+) ([]domain.CreateVacationToken, error) {
+	if len(days) == 0 {
+		return nil, nil
+	}
+
+	pots, err := svc.token.GetPots(ctx, userId, days[0].Date, days[len(days)-1].Date)
+	if err != nil {
+		return nil, err
+	}
+
+	booked := domain.AllocateVacation(days, typ, pots)
+	tokens := make([]domain.CreateVacationToken, len(booked))
+	for i, b := range booked {
+		tokens[i] = domain.CreateVacationToken{
+			StartDate: b.StartDate,
+			EndDate:   b.EndDate,
+			Value:     -b.Value,
+			UserID:    userId,
+			Reason:    domain.ReasonRequestAccepted,
+			CreatedBy: &editorId,
+		}
+	}
+
+	return tokens, nil
+}
+
+// refundTokens returns the tokens that give back the vacation an accepted
+// absence booked, into the same pots it was taken from.
+func (svc *AbsenceService) refundTokens(
+	ctx context.Context,
+	absence *domain.Absence,
+	editorId int64,
+) ([]domain.CreateVacationToken, error) {
+	typ, err := svc.eventType.Resolve(ctx, absence.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !typ.ConsumesVacation {
+		return nil, nil
+	}
+
+	booked, err := svc.token.GetForAbsence(ctx, absence.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(booked) > 0 {
+		// the booked tokens include earlier partial refunds of cancellations
+		tokens := domain.RefundVacation(booked, domain.ReasonCancellation)
+		for i := range tokens {
+			tokens[i].CreatedBy = &editorId
+		}
+		return tokens, nil
+	}
+
+	// absences from before the ledger have no linked tokens, refund them
+	// into the regular pot of each year
+	days, err := svc.days(ctx, absence.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	cost := domain.AbsenceCost(days, typ)
+	tokens := make([]domain.CreateVacationToken, 0, len(cost))
+	for _, year := range slices.Sorted(maps.Keys(cost)) {
+		t := domain.NewVacationToken(cost[year], year, absence.UserID, domain.ReasonCancellation)
+		t.CreatedBy = &editorId
+		tokens = append(tokens, t)
+	}
+
+	return tokens, nil
+}
+
+// days returns the stored days of an absence.
+func (svc *AbsenceService) days(ctx context.Context, id int64) ([]domain.AbsenceDay, error) {
+	events, err := svc.absence.GetEvents(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]domain.AbsenceDay, len(events))
+	for i, e := range events {
+		days[i] = domain.AbsenceDay{Date: e.ScheduledAt, HalfDay: e.HalfDay}
+	}
+
+	return days, nil
+}
+
+// holidays returns the holidays and company closures of the region in
+// [start, end].
+func (svc *AbsenceService) holidays(
+	ctx context.Context,
+	region domain.Region,
+	start, end time.Time,
+) ([]domain.Holiday, error) {
+	return svc.event.GetHolidays(ctx, config.GetConfig().BotName, region, start, end)
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"chrono/config"
+	"chrono/internal/domain"
+)
+
+type ApprovalService struct {
+	team     domain.TeamRepository
+	approver domain.ApproverRepository
+	user     domain.UserRepository
+	log      *slog.Logger
+}
+
+func NewApprovalService(
+	t domain.TeamRepository,
+	a domain.ApproverRepository,
+	u domain.UserRepository,
+	log *slog.Logger,
+) *ApprovalService {
+	return &ApprovalService{team: t, approver: a, user: u, log: log}
+}
+
+func (svc *ApprovalService) CreateTeam(ctx context.Context, name string) (*domain.Team, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("team name must not be empty")
+	}
+
+	return svc.team.Create(ctx, name)
+}
+
+func (svc *ApprovalService) UpdateTeam(ctx context.Context, team *domain.Team) (*domain.Team, error) {
+	team.Name = strings.TrimSpace(team.Name)
+	if team.Name == "" {
+		return nil, fmt.Errorf("team name must not be empty")
+	}
+
+	return svc.team.Update(ctx, team)
+}
+
+func (svc *ApprovalService) DeleteTeam(ctx context.Context, id int64) error {
+	return svc.team.Delete(ctx, id)
+}
+
+func (svc *ApprovalService) GetTeamById(ctx context.Context, id int64) (*domain.Team, error) {
+	return svc.team.GetById(ctx, id)
+}
+
+func (svc *ApprovalService) GetTeams(ctx context.Context) ([]domain.Team, error) {
+	return svc.team.GetAll(ctx)
+}
+
+func (svc *ApprovalService) GetTeamMembers(ctx context.Context) ([]domain.TeamMember, error) {
+	return svc.team.GetAllMembers(ctx)
+}
+
+// TeamOf returns the id of the team of the user, or nil if the user is not in
+// a team.
+func (svc *ApprovalService) TeamOf(ctx context.Context, userId int64) (*int64, error) {
+	members, err := svc.team.GetAllMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.TeamOf(members, userId), nil
+}
+
+func (svc *ApprovalService) SetTeamMember(ctx context.Context, userId, teamId int64) error {
+	if _, err := svc.user.GetById(ctx, userId); err != nil {
+		return fmt.Errorf("user %v not found", userId)
+	}
+
+	return svc.team.SetMember(ctx, userId, teamId)
+}
+
+func (svc *ApprovalService) DeleteTeamMember(ctx context.Context, userId int64) error {
+	return svc.team.DeleteMember(ctx, userId)
+}
+
+// CreateApprover lets a user decide a stage of the requests of a user or of a
+// team.
+func (svc *ApprovalService) CreateApprover(
+	ctx context.Context,
+	form domain.CreateApprover,
+) (*domain.Approver, error) {
+	if form.Stage == 0 {
+		form.Stage = domain.StageLead
+	}
+	if form.Stage != domain.StageLead && form.Stage != domain.StageFinal {
+		return nil, fmt.Errorf("invalid approval stage %v, must be 1 or 2", form.Stage)
+	}
+	if (form.UserID == 0) == (form.TeamID == 0) {
+		return nil, fmt.Errorf("approver needs either a user or a team")
+	}
+	if form.UserID == form.ApproverID {
+		return nil, fmt.Errorf("users can not approve their own requests")
+	}
+	if _, err := svc.user.GetById(ctx, form.ApproverID); err != nil {
+		return nil, fmt.Errorf("approver %v not found", form.ApproverID)
+	}
+
+	approver := &domain.Approver{Stage: form.Stage, ApproverID: form.ApproverID}
+	if form.UserID != 0 {
+		approver.UserID = &form.UserID
+	} else {
+		approver.TeamID = &form.TeamID
+	}
+
+	return svc.approver.Create(ctx, approver)
+}
+
+func (svc *ApprovalService) DeleteApprover(ctx context.Context, id int64) error {
+	return svc.approver.Delete(ctx, id)
+}
+
+func (svc *ApprovalService) GetApprovers(ctx context.Context) ([]domain.Approver, error) {
+	return svc.approver.GetAll(ctx)
+}
+
+// Load returns the approvers, team members and users to evaluate the
+// approvals of many requests in memory.
+func (svc *ApprovalService) Load(ctx context.Context) (*domain.Approvals, error) {
+	approvers, err := svc.approver.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := svc.team.GetAllMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := svc.user.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Approvals{
+		Approvers:          approvers,
+		Members:            members,
+		Users:              users,
+		EscalationApprover: config.GetConfig().EscalationApprover,
+	}, nil
+}
+
+// Approvers returns the ids of the users that decide the stage of the requests
+// of the user. The lead stage falls back to the admins if no approver is
+// configured, the final stage is skipped then.
+func (svc *ApprovalService) Approvers(
+	ctx context.Context,
+	userId int64,
+	stage int64,
+) ([]int64, error) {
+	approvals, err := svc.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return approvals.For(userId, stage), nil
+}
+
+// CanDecide returns an error unless the user decides the stage of the
+// requests of the requester.
+func (svc *ApprovalService) CanDecide(
+	ctx context.Context,
+	requesterId int64,
+	stage int64,
+	user *domain.User,
+) error {
+	approvals, err := svc.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	return approvals.CanDecide(requesterId, stage, user)
+}
+
+// CanDecideRequest is CanDecide for a request that the fallback approvers can
+// decide as well once it is escalated.
+func (svc *ApprovalService) CanDecideRequest(
+	ctx context.Context,
+	requesterId int64,
+	stage int64,
+	escalated bool,
+	user *domain.User,
+) error {
+	approvals, err := svc.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	return approvals.CanDecideRequest(requesterId, stage, escalated, user)
+}
+
+// Fallback returns the ids of the users that stale requests of the user are
+// escalated to, the configured escalation approver or else the admins.
+func (svc *ApprovalService) Fallback(ctx context.Context, userId int64) ([]int64, error) {
+	approvals, err := svc.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return approvals.Fallback(userId)
+}
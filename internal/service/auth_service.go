@@ -172,6 +172,18 @@ func (svc *AuthService) DeleteAllSessions(ctx context.Context) error {
 	return svc.session.DeleteAll(ctx)
 }
 
+// DeleteExpiredSessions removes the sessions that are no longer valid.
+func (svc *AuthService) DeleteExpiredSessions(ctx context.Context) error {
+	n, err := svc.session.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	svc.log.Info("Deleted expired sessions", slog.Int64("count", n))
+
+	return nil
+}
+
 func (svc *AuthService) IsValidSession(
 	ctx context.Context,
 	cookie string,
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"chrono/internal/domain"
+)
+
+type AutoApproveService struct {
+	policy    domain.AutoApprovePolicyRepository
+	eventType *EventTypeService
+	log       *slog.Logger
+}
+
+func NewAutoApproveService(
+	p domain.AutoApprovePolicyRepository,
+	et *EventTypeService,
+	log *slog.Logger,
+) *AutoApproveService {
+	return &AutoApproveService{policy: p, eventType: et, log: log}
+}
+
+func (svc *AutoApproveService) Create(
+	ctx context.Context,
+	form domain.CreateAutoApprovePolicy,
+) (*domain.AutoApprovePolicy, error) {
+	if form.Name == "" {
+		return nil, fmt.Errorf("policy needs a name")
+	}
+	if form.MaxDays != nil && *form.MaxDays <= 0 {
+		return nil, fmt.Errorf("maximum days must be positive, got %v", *form.MaxDays)
+	}
+
+	var eventName *string
+	if form.EventName != "" {
+		types, err := svc.eventType.GetMap(ctx)
+		if err != nil {
+			return nil, err
+		}
+		typ, ok := types[strings.ToLower(form.EventName)]
+		if !ok {
+			return nil, fmt.Errorf("unknown event type %q", form.EventName)
+		}
+		eventName = &typ.Name
+	}
+
+	return svc.policy.Create(ctx, &domain.AutoApprovePolicy{
+		Name:      form.Name,
+		EventName: eventName,
+		MaxDays:   form.MaxDays,
+	})
+}
+
+func (svc *AutoApproveService) Delete(ctx context.Context, id int64) error {
+	return svc.policy.Delete(ctx, id)
+}
+
+func (svc *AutoApproveService) GetAll(ctx context.Context) ([]domain.AutoApprovePolicy, error) {
+	return svc.policy.GetAll(ctx)
+}
+
+// Match returns the first policy that accepts an absence of the type on the
+// given days, or nil if the absence needs an approver.
+func (svc *AutoApproveService) Match(
+	ctx context.Context,
+	typ domain.EventType,
+	days []domain.AbsenceDay,
+) (*domain.AutoApprovePolicy, error) {
+	policies, err := svc.policy.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.MatchingPolicy(policies, typ.Name, domain.AbsenceLength(days)), nil
+}
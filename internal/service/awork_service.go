@@ -19,14 +19,20 @@ import (
 const AWORK_API_URL = "https://api.awork.com/api/v1"
 
 type AworkService struct {
-	client http.Client
-	log    *slog.Logger
-	event  *EventService
-	user   *UserService
+	client   http.Client
+	log      *slog.Logger
+	event    *EventService
+	user     *UserService
+	contract *UserContractService
 }
 
-func NewAworkService(e *EventService, u *UserService, s *slog.Logger) *AworkService {
-	return &AworkService{client: http.Client{}, event: e, user: u, log: s}
+func NewAworkService(
+	e *EventService,
+	u *UserService,
+	c *UserContractService,
+	s *slog.Logger,
+) *AworkService {
+	return &AworkService{client: http.Client{}, event: e, user: u, contract: c, log: s}
 }
 
 func (a *AworkService) GetUsers() ([]domain.AworkUser, error) {
@@ -237,34 +243,6 @@ func (a *AworkService) GetWorkHoursForYear(
 		}
 	}
 
-	// ---- HOLIDAYS / VACATION / SICKNESS ----
-	// NOTE: these must use the same period (yearStart..periodEnd) to be fully consistent.
-	holidays, err := a.event.GetNonWeekendCountHolidays(ctx, yearStart, periodEnd)
-	if err != nil {
-		return domain.WorkHours{}, err
-	}
-
-	vacation, err := a.event.GetUsedVacation(ctx, user.ID, yearStart, periodEnd)
-	if err != nil {
-		return domain.WorkHours{}, err
-	}
-
-	sickDays := 0
-	allEvents, err := a.event.GetAllByUserId(ctx, user.ID)
-	if err != nil {
-		return domain.WorkHours{}, err
-	}
-
-	for _, e := range allEvents {
-		if e.Name != "krank" {
-			continue
-		}
-		// only count sick days in [yearStart, periodEnd]
-		if !e.ScheduledAt.Before(yearStart) && !e.ScheduledAt.After(periodEnd) {
-			sickDays++
-		}
-	}
-
 	// ---- WORKED HOURS ----
 
 	workSecs := 0
@@ -280,25 +258,19 @@ func (a *AworkService) GetWorkHoursForYear(
 
 	workedHours := float64(workSecs) / 3600.0
 
-	// ---- EXPECTED HOURS (weekdays between yearStart and periodEnd) ----
+	// ---- EXPECTED HOURS / HOLIDAYS / VACATION / SICKNESS ----
 
-	expectedDays := 0
-	for d := yearStart; !d.After(periodEnd); d = d.AddDate(0, 0, 1) {
-		wd := d.Weekday()
-		if wd != time.Saturday && wd != time.Sunday {
-			expectedDays++
-		}
+	contracts, err := a.contract.GetForUser(ctx, user.ID)
+	if err != nil {
+		return domain.WorkHours{}, err
+	}
+
+	hours, err := a.event.GetExpectedWorkHours(ctx, user, contracts, yearStart, periodEnd)
+	if err != nil {
+		return domain.WorkHours{}, err
 	}
 
-	// final numbers (assuming 8h per working day)
-	expectedHours := (float64(expectedDays-holidays) - vacation - float64(sickDays)) * user.WorkdayHours
-	holidayHours := float64(holidays) * user.WorkdayHours
-	vacationHours := vacation * user.WorkdayHours
-
-	return domain.WorkHours{
-		Worked:   workedHours,
-		Expected: expectedHours,
-		Holidays: holidayHours,
-		Vacation: vacationHours,
-	}, nil
+	hours.Worked = workedHours
+
+	return hours, nil
 }
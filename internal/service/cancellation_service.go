@@ -0,0 +1,316 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"chrono/internal/domain"
+)
+
+type CancellationService struct {
+	cancellation domain.CancellationRepository
+	absence      *AbsenceService
+	eventType    *EventTypeService
+	token        *TokenService
+	user         domain.UserRepository
+	notif        *NotificationService
+	approval     *ApprovalService
+	log          *slog.Logger
+}
+
+func NewCancellationService(
+	c domain.CancellationRepository,
+	a *AbsenceService,
+	et *EventTypeService,
+	t *TokenService,
+	u domain.UserRepository,
+	n *NotificationService,
+	ap *ApprovalService,
+	log *slog.Logger,
+) *CancellationService {
+	return &CancellationService{
+		cancellation: c,
+		absence:      a,
+		eventType:    et,
+		token:        t,
+		user:         u,
+		notif:        n,
+		approval:     ap,
+		log:          log,
+	}
+}
+
+// Create asks to withdraw the days of an accepted absence of the user in the
+// range of the form and notifies the approvers of the user.
+func (svc *CancellationService) Create(
+	ctx context.Context,
+	absenceId int64,
+	form domain.CreateCancellation,
+	user *domain.User,
+) (*domain.Cancellation, error) {
+	start, err := time.Parse(time.DateOnly, form.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date %q", form.StartDate)
+	}
+	end, err := time.Parse(time.DateOnly, form.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date %q", form.EndDate)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("end date must not be before start date")
+	}
+
+	absence, err := svc.absence.GetById(ctx, absenceId)
+	if err != nil || absence.UserID != user.ID {
+		return nil, fmt.Errorf("absence %v not found", absenceId)
+	}
+	if !absence.IsAccepted() {
+		return nil, fmt.Errorf("only accepted absences can be cancelled")
+	}
+	if start.Before(absence.StartDate) || end.After(absence.EndDate) {
+		return nil, fmt.Errorf("cancellation must be within the absence")
+	}
+
+	events, err := svc.absence.GetEvents(ctx, absenceId)
+	if err != nil {
+		return nil, err
+	}
+	removed, _ := splitEvents(events, start, end)
+	if len(removed) == 0 {
+		return nil, fmt.Errorf("cancellation does not contain any days of the absence")
+	}
+
+	existing, err := svc.cancellation.GetForAbsence(ctx, absenceId)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range existing {
+		if c.IsPending() && c.Overlaps(start, end) {
+			return nil, fmt.Errorf("a cancellation for these days is already pending")
+		}
+	}
+
+	var reason *string
+	if form.Reason != "" {
+		reason = &form.Reason
+	}
+
+	cancellation, err := svc.cancellation.Create(ctx, &domain.Cancellation{
+		AbsenceID: &absenceId,
+		UserID:    user.ID,
+		StartDate: start,
+		EndDate:   end,
+		Reason:    reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	msg := cancellation.RequestMsg(user.Username, absence.Name)
+	err = svc.absence.notifyApprovers(ctx, msg, user.ID, domain.StageLead)
+	if err != nil {
+		return nil, err
+	}
+
+	svc.log.Info(
+		"Created cancellation",
+		slog.Int64("id", cancellation.ID),
+		slog.Int64("absenceId", absenceId),
+	)
+
+	return cancellation, nil
+}
+
+func (svc *CancellationService) GetById(
+	ctx context.Context,
+	id int64,
+) (*domain.Cancellation, error) {
+	return svc.cancellation.GetById(ctx, id)
+}
+
+func (svc *CancellationService) GetForAbsence(
+	ctx context.Context,
+	absenceId int64,
+) ([]domain.Cancellation, error) {
+	return svc.cancellation.GetForAbsence(ctx, absenceId)
+}
+
+// GetPending returns the cancellations that wait for the decision of the
+// user.
+func (svc *CancellationService) GetPending(
+	ctx context.Context,
+	user *domain.User,
+) ([]domain.Cancellation, error) {
+	pending, err := svc.cancellation.GetPending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	approvals, err := svc.approval.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(pending, func(c domain.Cancellation) bool {
+		return approvals.CanDecide(c.UserID, domain.StageLead, user) != nil
+	}), nil
+}
+
+// UpdateState accepts or declines a pending cancellation and notifies the
+// requesting user. Accepting removes the days in its range from the absence,
+// or the whole absence if no days are left, and gives back their vacation.
+func (svc *CancellationService) UpdateState(
+	ctx context.Context,
+	id int64,
+	form domain.UpdateCancellation,
+	editor *domain.User,
+) (*domain.Cancellation, error) {
+	if form.State != "accepted" && form.State != "declined" {
+		return nil, fmt.Errorf("invalid state %q", form.State)
+	}
+
+	c, err := svc.cancellation.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !c.IsPending() {
+		return nil, fmt.Errorf("cancellation %v is not pending", id)
+	}
+
+	err = svc.approval.CanDecide(ctx, c.UserID, domain.StageLead, editor)
+	if err != nil {
+		return nil, err
+	}
+
+	if form.State == "accepted" {
+		c, err = svc.accept(ctx, c, editor.ID)
+	} else {
+		c, err = svc.cancellation.Decline(ctx, id, editor.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	msg := domain.CancellationDecisionMsg(editor.Username, c.State, form.Reason)
+	err = svc.notif.CreateAndNotify(ctx, msg, []domain.User{{ID: c.UserID}})
+	if err != nil {
+		return nil, err
+	}
+
+	svc.log.Info(
+		"Decided cancellation",
+		slog.Int64("id", c.ID),
+		slog.String("state", c.State),
+	)
+
+	return c, nil
+}
+
+func (svc *CancellationService) accept(
+	ctx context.Context,
+	c *domain.Cancellation,
+	editorId int64,
+) (*domain.Cancellation, error) {
+	if c.AbsenceID == nil {
+		return nil, fmt.Errorf("absence of cancellation %v was deleted", c.ID)
+	}
+
+	absence, err := svc.absence.GetById(ctx, *c.AbsenceID)
+	if err != nil {
+		return nil, err
+	}
+	if !absence.IsAccepted() {
+		return nil, fmt.Errorf("absence %v is not accepted", absence.ID)
+	}
+
+	events, err := svc.absence.GetEvents(ctx, absence.ID)
+	if err != nil {
+		return nil, err
+	}
+	removed, remaining := splitEvents(events, c.StartDate, c.EndDate)
+
+	if len(remaining) == 0 {
+		tokens, err := svc.absence.refundTokens(ctx, absence, editorId)
+		if err != nil {
+			return nil, err
+		}
+		return svc.cancellation.Accept(ctx, c, editorId, nil, tokens)
+	}
+
+	tokens, err := svc.refundDays(ctx, absence, removed, editorId)
+	if err != nil {
+		return nil, err
+	}
+
+	left := absence.CancelledRange(remaining)
+	return svc.cancellation.Accept(ctx, c, editorId, &left, tokens)
+}
+
+// refundDays returns the tokens that give back the vacation of the removed
+// days of an accepted absence, into the pots the absence was booked on.
+func (svc *CancellationService) refundDays(
+	ctx context.Context,
+	absence *domain.Absence,
+	removed []domain.Event,
+	editorId int64,
+) ([]domain.CreateVacationToken, error) {
+	typ, err := svc.eventType.Resolve(ctx, absence.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !typ.ConsumesVacation {
+		return nil, nil
+	}
+
+	days := make([]domain.AbsenceDay, len(removed))
+	for i, e := range removed {
+		days[i] = domain.AbsenceDay{Date: e.ScheduledAt, HalfDay: e.HalfDay}
+	}
+	cost := domain.AbsenceCost(days, typ)
+
+	booked, err := svc.token.GetForAbsence(ctx, absence.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []domain.CreateVacationToken
+	if len(booked) > 0 {
+		amount := 0.0
+		for _, c := range cost {
+			amount += c
+		}
+		refunds := domain.RefundVacation(booked, domain.ReasonCancellation)
+		tokens = domain.PartialRefund(refunds, amount)
+	} else {
+		// absences from before the ledger have no linked tokens, refund them
+		// into the regular pot of each year
+		for _, year := range slices.Sorted(maps.Keys(cost)) {
+			t := domain.NewVacationToken(cost[year], year, absence.UserID, domain.ReasonCancellation)
+			tokens = append(tokens, t)
+		}
+	}
+
+	for i := range tokens {
+		tokens[i].CreatedBy = &editorId
+	}
+
+	return tokens, nil
+}
+
+// splitEvents separates the events scheduled in [start, end] from the others.
+func splitEvents(events []domain.Event, start, end time.Time) ([]domain.Event, []domain.Event) {
+	var in, out []domain.Event
+	for _, e := range events {
+		if e.ScheduledAt.Before(start) || e.ScheduledAt.After(end) {
+			out = append(out, e)
+		} else {
+			in = append(in, e)
+		}
+	}
+
+	return in, out
+}
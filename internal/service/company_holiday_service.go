@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"chrono/config"
+	"chrono/internal/domain"
+)
+
+type CompanyHolidayService struct {
+	holiday   domain.CompanyHolidayRepository
+	user      *UserService
+	event     *EventService
+	contract  *UserContractService
+	token     *TokenService
+	eventType *EventTypeService
+	log       *slog.Logger
+}
+
+func NewCompanyHolidayService(
+	h domain.CompanyHolidayRepository,
+	u *UserService,
+	e *EventService,
+	c *UserContractService,
+	t *TokenService,
+	et *EventTypeService,
+	log *slog.Logger,
+) *CompanyHolidayService {
+	return &CompanyHolidayService{
+		holiday:   h,
+		user:      u,
+		event:     e,
+		contract:  c,
+		token:     t,
+		eventType: et,
+		log:       log,
+	}
+}
+
+func (svc *CompanyHolidayService) GetById(
+	ctx context.Context,
+	id int64,
+) (*domain.CompanyHoliday, error) {
+	return svc.holiday.GetById(ctx, id)
+}
+
+func (svc *CompanyHolidayService) GetForYear(
+	ctx context.Context,
+	year int,
+) ([]domain.CompanyHoliday, error) {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+	return svc.holiday.GetInRange(ctx, start, end)
+}
+
+// Create adds the closure and books it from the vacation of every affected
+// user if it consumes vacation.
+func (svc *CompanyHolidayService) Create(
+	ctx context.Context,
+	form domain.CreateCompanyHoliday,
+	editor *domain.User,
+) (*domain.CompanyHoliday, error) {
+	h, err := svc.parse(form)
+	if err != nil {
+		return nil, err
+	}
+	h.CreatedBy = &editor.ID
+
+	bot, err := svc.user.GetByName(ctx, config.GetConfig().BotName)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := svc.book(ctx, h, editor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := svc.holiday.Create(ctx, h, bot, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	svc.log.Info(
+		"Created company holiday",
+		slog.Int64("id", created.ID),
+		slog.String("date", created.Date.Format(time.DateOnly)),
+		slog.Int("tokens", len(tokens)),
+	)
+
+	return created, nil
+}
+
+// Update changes the closure, the vacation it booked is refunded and booked
+// again for the changed closure.
+func (svc *CompanyHolidayService) Update(
+	ctx context.Context,
+	id int64,
+	form domain.CreateCompanyHoliday,
+	editor *domain.User,
+) (*domain.CompanyHoliday, error) {
+	existing, err := svc.holiday.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := svc.parse(form)
+	if err != nil {
+		return nil, err
+	}
+	h.ID = existing.ID
+	h.EventID = existing.EventID
+
+	tokens, err := svc.refund(ctx, existing, editor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	booked, err := svc.book(ctx, h, editor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.holiday.Update(ctx, h, append(tokens, booked...))
+}
+
+// Delete removes the closure and refunds the vacation it booked.
+func (svc *CompanyHolidayService) Delete(
+	ctx context.Context,
+	id int64,
+	editor *domain.User,
+) error {
+	existing, err := svc.holiday.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	tokens, err := svc.refund(ctx, existing, editor.ID)
+	if err != nil {
+		return err
+	}
+
+	return svc.holiday.Delete(ctx, id, tokens)
+}
+
+func (svc *CompanyHolidayService) parse(
+	form domain.CreateCompanyHoliday,
+) (*domain.CompanyHoliday, error) {
+	date, err := time.Parse(time.DateOnly, form.Date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q", form.Date)
+	}
+
+	name := strings.TrimSpace(form.Name)
+	if name == "" {
+		return nil, fmt.Errorf("company holiday name must not be empty")
+	}
+
+	if form.Weight == 0 {
+		form.Weight = 1
+	}
+	if !domain.IsValidEventWeight(form.Weight) {
+		return nil, fmt.Errorf("invalid company holiday weight %v, must be 1.0 or 0.5", form.Weight)
+	}
+
+	var region *string
+	if form.Region != "" {
+		if !domain.IsValidRegion(domain.Region(form.Region)) {
+			return nil, fmt.Errorf("invalid holiday region %q", form.Region)
+		}
+		region = &form.Region
+	}
+
+	return &domain.CompanyHoliday{
+		Date:             date,
+		Name:             name,
+		Weight:           form.Weight,
+		Region:           region,
+		ConsumesVacation: form.ConsumesVacation,
+	}, nil
+}
+
+// book returns the tokens that take a closure that consumes vacation from
+// every enabled user of its region that would have worked on the day. Users
+// that already took vacation on the day are only booked for the rest of it.
+func (svc *CompanyHolidayService) book(
+	ctx context.Context,
+	h *domain.CompanyHoliday,
+	editorId int64,
+) ([]domain.CreateVacationToken, error) {
+	if !h.ConsumesVacation {
+		return nil, nil
+	}
+
+	users, err := svc.user.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := svc.event.GetForDay(ctx, domain.YMDDate{
+		Year:  h.Date.Year(),
+		Month: int(h.Date.Month()),
+		Day:   h.Date.Day(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	types, err := svc.eventType.GetMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	taken := map[int64]float64{}
+	for _, e := range events {
+		typ := types.Get(e.Name)
+		if e.IsAccepted() && typ.ConsumesVacation {
+			taken[e.UserID] += e.Days(typ)
+		}
+	}
+
+	botName := config.GetConfig().BotName
+	tokens := []domain.CreateVacationToken{}
+	for _, u := range users {
+		if !u.IsStaff(botName) || !u.EmployedOn(h.Date) || !h.AppliesTo(holidayRegion(&u)) {
+			continue
+		}
+
+		contracts, err := svc.contract.GetForUser(ctx, u.ID)
+		if err != nil {
+			return nil, err
+		}
+		contract := u.ContractAt(contracts, h.Date)
+		if contract.WeekSchedule().Hours(h.Date) == 0 {
+			continue
+		}
+
+		off := max(0, min(h.Weight, 1-taken[u.ID]))
+		if off == 0 {
+			continue
+		}
+
+		pots, err := svc.token.GetPots(ctx, u.ID, h.Date, h.Date)
+		if err != nil {
+			return nil, err
+		}
+
+		days := []domain.AbsenceDay{{Date: h.Date}}
+		typ := domain.EventType{Weight: off, ConsumesVacation: true}
+		for _, b := range domain.AllocateVacation(days, typ, pots) {
+			tokens = append(tokens, domain.CreateVacationToken{
+				StartDate: b.StartDate,
+				EndDate:   b.EndDate,
+				Value:     -b.Value,
+				UserID:    u.ID,
+				Reason:    domain.ReasonCompanyClosure,
+				EventID:   h.EventID,
+				CreatedBy: &editorId,
+			})
+		}
+	}
+
+	return tokens, nil
+}
+
+// refund returns the tokens that give back the vacation the closure booked.
+func (svc *CompanyHolidayService) refund(
+	ctx context.Context,
+	h *domain.CompanyHoliday,
+	editorId int64,
+) ([]domain.CreateVacationToken, error) {
+	if h.EventID == nil {
+		return nil, nil
+	}
+
+	booked, err := svc.token.GetForEvent(ctx, *h.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := domain.RefundVacation(booked, domain.ReasonCancellation)
+	for i := range tokens {
+		tokens[i].CreatedBy = &editorId
+	}
+
+	return tokens, nil
+}
@@ -13,54 +13,92 @@ import (
 )
 
 type EventService struct {
-	log     *slog.Logger
-	event   domain.EventRepository
-	token   *TokenService
-	request *RequestService
-	user    *UserService
+	log       *slog.Logger
+	event     domain.EventRepository
+	eventType *EventTypeService
+	token     *TokenService
+	absence   *AbsenceService
+	user      *UserService
+	uow       domain.UnitOfWork
 }
 
 func NewEventService(
 	e domain.EventRepository,
-	r *RequestService,
+	et *EventTypeService,
+	a *AbsenceService,
 	u *UserService,
 	t *TokenService,
+	w domain.UnitOfWork,
 	log *slog.Logger,
 ) *EventService {
-	return &EventService{log: log, event: e, request: r, user: u, token: t}
+	return &EventService{
+		log:       log,
+		event:     e,
+		eventType: et,
+		absence:   a,
+		user:      u,
+		token:     t,
+		uow:       w,
+	}
 }
 
+// Create stores an event of the type for the user. Absence types are created
+// through the AbsenceService and return the warnings of its rule checks.
 func (svc *EventService) Create(
 	ctx context.Context,
 	data domain.YMDDate,
 	eventType string,
 	user *domain.User,
-) (*domain.Event, error) {
-	evt := domain.Event{Name: eventType}
-
-	if evt.IsVacation() && user.IsSuperuser {
-		_, err := svc.token.CreateVacationToken(ctx, -1, data.Year, user.ID)
-		if err != nil {
-			return nil, err
-		}
-		return svc.event.Create(ctx, data, eventType, user)
+) (*domain.Event, []domain.RuleViolation, error) {
+	typ, err := svc.eventType.Resolve(ctx, eventType)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if !evt.IsVacation() {
-		return svc.event.Create(ctx, data, eventType, user)
+	if !typ.NeedsApproval && !typ.ConsumesVacation {
+		event, err := svc.event.Create(ctx, data, eventType, "accepted", user)
+		return event, nil, err
 	}
 
-	event, err := svc.event.Create(ctx, data, eventType, user)
+	// types that need approval or consume vacation are always stored as a
+	// single day absence so the vacation tokens are booked and the staffing
+	// rules are checked in one place
+	date := time.Date(data.Year, time.Month(data.Month), data.Day, 0, 0, 0, 0, time.UTC)
+	absence, warnings, err := svc.absence.Create(
+		ctx,
+		domain.CreateAbsence{
+			EventName: eventType,
+			StartDate: date.Format(time.DateOnly),
+			EndDate:   date.Format(time.DateOnly),
+		},
+		user,
+	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	_, err = svc.request.Create(ctx, event.RequestMsg(user.Username), user, event)
+	events, err := svc.absence.GetEvents(ctx, absence.ID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return event, nil
+	return &events[0], warnings, nil
+}
+
+// CreateHoliday stores a holiday of the region for the bot user.
+func (svc *EventService) CreateHoliday(
+	ctx context.Context,
+	holiday domain.Holiday,
+	region domain.Region,
+	bot *domain.User,
+) (*domain.Event, error) {
+	return svc.event.CreateHoliday(ctx, holiday, region, bot)
+}
+
+// TagLegacyHolidays assigns the region to the holidays that were stored before
+// holidays had a region.
+func (svc *EventService) TagLegacyHolidays(ctx context.Context, region domain.Region) error {
+	return svc.event.TagLegacyHolidays(ctx, config.GetConfig().BotName, region)
 }
 
 func (svc *EventService) Update(
@@ -71,6 +109,8 @@ func (svc *EventService) Update(
 	return svc.event.Update(ctx, eventId, state)
 }
 
+// Delete removes the event and refunds the vacation an accepted event booked
+// together with it. Events of an absence delete the whole absence.
 func (svc *EventService) Delete(
 	ctx context.Context,
 	eventId int64,
@@ -85,16 +125,41 @@ func (svc *EventService) Delete(
 		return nil, fmt.Errorf("User: %v has no permission to delete the event.", currUser.Username)
 	}
 
-	err = svc.event.Delete(ctx, eventId)
+	if event.AbsenceID != nil {
+		_, err = svc.absence.Delete(ctx, *event.AbsenceID, currUser)
+		if err != nil {
+			return nil, err
+		}
+		return event, nil
+	}
+
+	typ, err := svc.eventType.Resolve(ctx, event.Name)
 	if err != nil {
 		return nil, err
 	}
 
-	if event.IsVacation() && event.IsAccepted() {
-		_, err := svc.token.CreateVacationToken(ctx, 1.0, event.ScheduledAt.Year(), event.UserID)
+	err = svc.uow.Do(ctx, func(ctx context.Context) error {
+		err := svc.event.Delete(ctx, eventId)
 		if err != nil {
-			return nil, err
+			return err
 		}
+		if !typ.ConsumesVacation || !event.IsAccepted() {
+			return nil
+		}
+
+		token := domain.NewVacationToken(
+			event.Days(typ),
+			event.ScheduledAt.Year(),
+			event.UserID,
+			domain.ReasonCancellation,
+		)
+		token.EventID = &event.ID
+		token.CreatedBy = &currUser.ID
+		_, err = svc.token.CreateVacationToken(ctx, token)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return event, nil
@@ -107,28 +172,35 @@ func (svc *EventService) GetForDay(
 	return svc.event.GetForDay(ctx, data)
 }
 
+// GetForMonth returns the events of the month, holidays are only included for
+// the given region.
 func (svc *EventService) GetForMonth(
 	ctx context.Context,
 	data domain.YMDate,
+	region domain.Region,
 	userFilter *domain.User,
 	eventFilter string,
 ) (domain.Month, error) {
 	cfg := config.GetConfig()
-	return svc.event.GetForMonth(ctx, data, cfg.BotName, userFilter, eventFilter)
+	return svc.event.GetForMonth(ctx, data, cfg.BotName, region, userFilter, eventFilter)
 }
 
 func (svc *EventService) GetForYear(
 	ctx context.Context,
 	year int,
 ) ([]domain.EventUser, error) {
-	return svc.event.GetForYear(ctx, year)
+	return svc.event.GetForYear(ctx, config.GetConfig().BotName, year)
 }
 
+// GetHistogramForYear counts the absences per day of the year, holidays are
+// only marked for the given region.
 func (svc *EventService) GetHistogramForYear(
 	ctx context.Context,
 	year int,
+	region domain.Region,
 ) ([]domain.YearHistogram, error) {
-	events, err := svc.event.GetForYear(ctx, year)
+	botName := config.GetConfig().BotName
+	events, err := svc.event.GetForYear(ctx, botName, year)
 	if err != nil {
 		return nil, nil
 	}
@@ -146,11 +218,15 @@ func (svc *EventService) GetHistogramForYear(
 	}
 
 	for _, event := range events {
+		if event.Event.Region != nil && domain.Region(*event.Event.Region) != region {
+			continue
+		}
+
 		i := event.Event.ScheduledAt.YearDay() - 1
 		date := event.Event.ScheduledAt
 
 		eventList[i].Count += 1
-		eventList[i].IsHoliday = event.User.ID == 1
+		eventList[i].IsHoliday = event.User.Username == botName
 		_, dateWeek := date.ISOWeek()
 		_, currWeek := time.Now().ISOWeek()
 		eventList[i].IsCurrentWeek = dateWeek == currWeek
@@ -203,11 +279,18 @@ func (svc *EventService) GetUserWithVacation(
 		return domain.UserWithVacation{}, err
 	}
 
+	entitlement, err := svc.token.Entitlement(ctx, user, year)
+	if err != nil {
+		return domain.UserWithVacation{}, err
+	}
+
 	return domain.UserWithVacation{
-		VacationRemaining: remaining,
-		VacationUsed:      used,
-		User:              *user,
-		PendingEvents:     pending,
+		VacationEntitlement: entitlement,
+		MonthsEmployed:      user.MonthsEmployed(year),
+		VacationRemaining:   remaining,
+		VacationUsed:        used,
+		User:                *user,
+		PendingEvents:       pending,
 	}, nil
 }
 
@@ -237,15 +320,6 @@ func (svc *EventService) GetAllUsersWithVacation(
 	return allUsersWithVac, nil
 }
 
-func (svc *EventService) UpdateInRange(
-	ctx context.Context,
-	userId int64,
-	state string,
-	start, end time.Time,
-) error {
-	return svc.event.UpdateInRange(ctx, userId, state, start, end)
-}
-
 func (svc *EventService) GetAllByUserId(
 	ctx context.Context,
 	userId int64,
@@ -253,76 +327,44 @@ func (svc *EventService) GetAllByUserId(
 	return svc.event.GetAllByUserId(ctx, userId)
 }
 
-func (svc *EventService) GetNonWeekendCountHolidays(
+// HolidayRegion returns the region whose holidays apply to the user.
+func (svc *EventService) HolidayRegion(user *domain.User) domain.Region {
+	return holidayRegion(user)
+}
+
+// GetHolidays returns the holidays and company closures of the region in
+// [start, end].
+func (svc *EventService) GetHolidays(
 	ctx context.Context,
+	region domain.Region,
 	start, end time.Time,
-) (int, error) {
-	cfg := config.GetConfig()
-
-	// "Bot" user that holds holiday events
-	bot, err := svc.user.GetByName(ctx, cfg.BotName)
-	if err != nil {
-		return 0, err
-	}
-
-	holidays, err := svc.event.GetAllByUserId(ctx, bot.ID)
-	if err != nil {
-		return 0, err
-	}
-
-	nonWeekendHolidays := 0
-
-	for _, h := range holidays {
-		t := h.ScheduledAt
-
-		// only count events within [start, end]
-		if t.Before(start) || t.After(end) {
-			continue
-		}
-
-		wd := t.Weekday()
-		if wd == time.Saturday || wd == time.Sunday {
-			continue
-		}
-
-		nonWeekendHolidays++
-	}
-
-	return nonWeekendHolidays, nil
+) ([]domain.Holiday, error) {
+	return svc.event.GetHolidays(ctx, config.GetConfig().BotName, region, start, end)
 }
 
-func (svc *EventService) GetUsedVacation(
+// GetExpectedWorkHours returns the expected, holiday and vacation hours of the
+// user in [start, end] under the given contracts and the holidays of the
+// user's region.
+func (svc *EventService) GetExpectedWorkHours(
 	ctx context.Context,
-	userId int64,
+	user *domain.User,
+	contracts []domain.UserContract,
 	start, end time.Time,
-) (float64, error) {
-	events, err := svc.event.GetAllByUserId(ctx, userId)
+) (domain.WorkHours, error) {
+	holidays, err := svc.GetHolidays(ctx, holidayRegion(user), start, end)
 	if err != nil {
-		return 0, err
+		return domain.WorkHours{}, err
 	}
 
-	count := 0.0
-
-	for _, e := range events {
-		t := e.ScheduledAt
-
-		// only count events within [start, end]
-		if t.Before(start) || t.After(end) {
-			continue
-		}
-
-		// only accepted vacation
-		if e.State != "accepted" {
-			continue
-		}
+	events, err := svc.event.GetAllByUserId(ctx, user.ID)
+	if err != nil {
+		return domain.WorkHours{}, err
+	}
 
-		switch e.Name {
-		case "urlaub":
-			count += 1.0
-		case "urlaub halbtags":
-			count += 0.5
-		}
+	types, err := svc.eventType.GetMap(ctx)
+	if err != nil {
+		return domain.WorkHours{}, err
 	}
 
-	return count, nil
+	return user.ExpectedWorkHours(contracts, start, end, holidays, events, types), nil
 }
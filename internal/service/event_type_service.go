@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"chrono/internal/domain"
+)
+
+type EventTypeService struct {
+	eventType domain.EventTypeRepository
+	log       *slog.Logger
+}
+
+func NewEventTypeService(r domain.EventTypeRepository, log *slog.Logger) *EventTypeService {
+	return &EventTypeService{eventType: r, log: log}
+}
+
+func (svc *EventTypeService) Create(
+	ctx context.Context,
+	t *domain.EventType,
+) (*domain.EventType, error) {
+	t.Name = strings.ToLower(strings.TrimSpace(t.Name))
+	if t.Name == "" {
+		return nil, fmt.Errorf("event type name must not be empty")
+	}
+	if t.Label == "" {
+		t.Label = t.Name
+	}
+	if err := svc.validate(t); err != nil {
+		return nil, err
+	}
+
+	return svc.eventType.Create(ctx, t)
+}
+
+func (svc *EventTypeService) Update(
+	ctx context.Context,
+	t *domain.EventType,
+) (*domain.EventType, error) {
+	if err := svc.validate(t); err != nil {
+		return nil, err
+	}
+
+	return svc.eventType.Update(ctx, t)
+}
+
+func (svc *EventTypeService) Delete(ctx context.Context, id int64) error {
+	return svc.eventType.Delete(ctx, id)
+}
+
+func (svc *EventTypeService) GetById(ctx context.Context, id int64) (*domain.EventType, error) {
+	return svc.eventType.GetById(ctx, id)
+}
+
+func (svc *EventTypeService) GetAll(ctx context.Context) ([]domain.EventType, error) {
+	return svc.eventType.GetAll(ctx)
+}
+
+// GetMap returns all registered event types keyed by their name.
+func (svc *EventTypeService) GetMap(ctx context.Context) (domain.EventTypes, error) {
+	types, err := svc.eventType.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(domain.EventTypes, len(types))
+	for _, t := range types {
+		m[t.Name] = t
+	}
+
+	return m, nil
+}
+
+// Resolve returns the registered type for an event name, falling back to
+// domain.DefaultEventType for names that are not in the registry.
+func (svc *EventTypeService) Resolve(ctx context.Context, name string) (domain.EventType, error) {
+	types, err := svc.GetMap(ctx)
+	if err != nil {
+		return domain.EventType{}, err
+	}
+
+	return types.Get(name), nil
+}
+
+func (svc *EventTypeService) validate(t *domain.EventType) error {
+	if !domain.IsValidEventWeight(t.Weight) {
+		return fmt.Errorf("invalid event type weight %v, must be 1.0 or 0.5", t.Weight)
+	}
+	if t.ConsumesOvertime && (t.ConsumesVacation || t.CountsAsWorked) {
+		return fmt.Errorf("event types that consume overtime neither consume vacation nor count as worked")
+	}
+	if t.Color == "" {
+		t.Color = domain.Color.RandomHexColor()
+	}
+
+	return nil
+}
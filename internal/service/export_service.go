@@ -51,7 +51,7 @@ func (svc *KrankheitsExport) ExportForUser(ctx context.Context, userId int64) (s
 func (svc *KrankheitsExport) processUser(userName string, events []domain.Event) (string, error) {
 	krank := []domain.Event{}
 	for _, e := range events {
-		if e.Name != "krank" {
+		if e.Name != domain.SickEventName {
 			continue
 		}
 		krank = append(krank, e)
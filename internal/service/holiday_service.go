@@ -2,12 +2,9 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
 	"log/slog"
-	"net/http"
-	"time"
+	"slices"
 
 	"chrono/config"
 	"chrono/internal/domain"
@@ -29,13 +26,18 @@ func NewHolidayService(
 	return &HolidayService{user: user, event: event, api: api, log: log}
 }
 
-func (svc *HolidayService) Update(ctx context.Context, year int) error {
+// Update creates the holidays of the region in the year unless they have been
+// created before.
+func (svc *HolidayService) Update(ctx context.Context, year int, region domain.Region) error {
 	if year < 1900 {
 		return fmt.Errorf("Invalid year %v, must be 1900 and above", year)
 	}
+	if !domain.IsValidRegion(region) {
+		return fmt.Errorf("Invalid holiday region %q", region)
+	}
 
 	cfg := config.GetConfig()
-	if svc.HolidayCacheExists(ctx, year) {
+	if svc.HolidayCacheExists(ctx, year, region) {
 		return nil
 	}
 	bot, err := svc.user.GetByName(ctx, cfg.BotName)
@@ -43,77 +45,86 @@ func (svc *HolidayService) Update(ctx context.Context, year int) error {
 		return err
 	}
 
-	holidays, err := svc.FetchHolidays(year)
-	if err != nil {
-		return err
+	for _, h := range domain.HolidaysFor(year, region) {
+		_, err := svc.event.CreateHoliday(ctx, h, region, bot)
+		if err != nil {
+			return err
+		}
 	}
 
-	holidays = svc.filterHolidays(holidays)
+	return svc.CreateCache(ctx, year, region)
+}
 
-	for name, data := range holidays {
-		date, err := time.Parse(time.DateOnly, data["datum"])
-		if err != nil {
-			svc.log.Error("Failed parsing date", slog.String("error", err.Error()))
-			continue
+// TagLegacyHolidays assigns the holidays that were fetched before holidays had
+// a region to Baden-Württemberg, the only region fetched back then.
+func (svc *HolidayService) TagLegacyHolidays(ctx context.Context) error {
+	return svc.event.TagLegacyHolidays(ctx, domain.RegionBW)
+}
+
+// UpdateUpcoming creates the holidays of the current and the next year for
+// every region a user works in.
+func (svc *HolidayService) UpdateUpcoming(ctx context.Context) error {
+	year := domain.CurrentYear()
+	for _, y := range []int{year, year + 1} {
+		if err := svc.UpdateForUsers(ctx, y); err != nil {
+			return err
 		}
-		svc.event.Create(
-			ctx,
-			domain.YMDDate{Year: date.Year(), Month: int(date.Month()), Day: date.Day()},
-			name,
-			bot,
-		)
 	}
 
-	return svc.CreateCache(ctx, year)
+	return nil
 }
 
-func (svc *HolidayService) FetchHolidays(year int) (domain.Holidays, error) {
-	holidays := domain.Holidays{}
-	resp, err := http.Get(fmt.Sprintf("https://feiertage-api.de/api/?jahr=%v&nur_land=BW", year))
-	if err != nil {
-		svc.log.Error(
-			"Error fetching feiertage-api",
-			slog.Int("year", year),
-			slog.String("error", err.Error()),
-		)
-		return holidays, err
-	}
-	body, err := io.ReadAll(resp.Body)
+// UpdateForUsers creates the holidays of the year for the default region and
+// every region a user works in.
+func (svc *HolidayService) UpdateForUsers(ctx context.Context, year int) error {
+	users, err := svc.user.GetAll(ctx)
 	if err != nil {
-		return holidays, err
-	}
-	err = json.Unmarshal(body, &holidays)
-	if err != nil {
-		return holidays, err
+		return err
 	}
 
-	return holidays, nil
-}
-
-func (svc *HolidayService) filterHolidays(holidays domain.Holidays) domain.Holidays {
-	filter := map[string]bool{"Reformationstag": true}
+	regions := []domain.Region{defaultHolidayRegion()}
+	for _, u := range users {
+		region := holidayRegion(&u)
+		if !slices.Contains(regions, region) {
+			regions = append(regions, region)
+		}
+	}
 
-	for holiday := range holidays {
-		if _, exists := filter[holiday]; exists {
-			delete(holidays, holiday)
+	for _, region := range regions {
+		if err := svc.Update(ctx, year, region); err != nil {
+			return err
 		}
 	}
 
-	return holidays
+	return nil
 }
 
-func (svc *HolidayService) HolidayCacheExists(ctx context.Context, year int) bool {
-	count, err := svc.api.Exists(ctx, int64(year))
+func (svc *HolidayService) HolidayCacheExists(
+	ctx context.Context,
+	year int,
+	region domain.Region,
+) bool {
+	count, err := svc.api.Exists(ctx, int64(year), region)
 	if err != nil {
 		return false
 	}
 	return count > 0
 }
 
-func (svc *HolidayService) CreateCache(ctx context.Context, year int) error {
-	return svc.api.Create(ctx, int64(year))
+func (svc *HolidayService) CreateCache(ctx context.Context, year int, region domain.Region) error {
+	return svc.api.Create(ctx, int64(year), region)
 }
 
 func (svc *HolidayService) GetAPICacheYears(ctx context.Context) ([]int64, error) {
 	return svc.api.GetAll(ctx)
 }
+
+// defaultHolidayRegion is the configured region for users without their own.
+func defaultHolidayRegion() domain.Region {
+	return domain.Region(config.GetConfig().HolidayRegion)
+}
+
+// holidayRegion returns the region whose holidays apply to the user.
+func holidayRegion(u *domain.User) domain.Region {
+	return u.HolidayRegion(defaultHolidayRegion())
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"chrono/config"
+	"chrono/internal/domain"
+)
+
+type OvertimeService struct {
+	overtime   domain.OvertimeRepository
+	timestamps *TimestampsService
+	user       domain.UserRepository
+	log        *slog.Logger
+}
+
+func NewOvertimeService(
+	o domain.OvertimeRepository,
+	t *TimestampsService,
+	u domain.UserRepository,
+	log *slog.Logger,
+) *OvertimeService {
+	return &OvertimeService{overtime: o, timestamps: t, user: u, log: log}
+}
+
+// Balance returns the overtime account of the user at the end of the day. It
+// starts from the snapshot of the last closed month before the day and adds
+// the hours and bookings since.
+func (svc *OvertimeService) Balance(
+	ctx context.Context,
+	user *domain.User,
+	date time.Time,
+) (domain.OvertimeBalance, error) {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	start, err := svc.start(ctx, user)
+	if err != nil {
+		return domain.OvertimeBalance{}, err
+	}
+	if start == nil {
+		return domain.NewOvertimeBalance(user.ID, date, date, 0, domain.WorkHours{}, nil), nil
+	}
+
+	since, carried := *start, 0.0
+	last, err := svc.overtime.GetLastSnapshotBefore(ctx, user.ID, domain.MonthStart(date))
+	if err != nil {
+		return domain.OvertimeBalance{}, err
+	}
+	if last != nil {
+		since, carried = last.Month.AddDate(0, 1, 0), last.Balance
+	}
+
+	return svc.balance(ctx, user, since, date, carried)
+}
+
+// balance adds the hours and bookings of the user from since to the end of
+// date to the carried balance.
+func (svc *OvertimeService) balance(
+	ctx context.Context,
+	user *domain.User,
+	since time.Time,
+	date time.Time,
+	carried float64,
+) (domain.OvertimeBalance, error) {
+	end := date.AddDate(0, 0, 1).Add(-time.Second)
+	if end.Before(since) {
+		return domain.NewOvertimeBalance(user.ID, since, date, carried, domain.WorkHours{}, nil), nil
+	}
+
+	hours, err := svc.timestamps.GetWorkHours(ctx, user, since, end)
+	if err != nil {
+		return domain.OvertimeBalance{}, err
+	}
+
+	bookings, err := svc.overtime.GetBookingsInRange(ctx, user.ID, since, end)
+	if err != nil {
+		return domain.OvertimeBalance{}, err
+	}
+
+	return domain.NewOvertimeBalance(user.ID, since, date, carried, hours, bookings), nil
+}
+
+// start returns the first day of the overtime account of the user, the date
+// of its opening balance, else the entry date of the user, else the day of
+// their first timestamp. It is nil while the account has not started.
+func (svc *OvertimeService) start(
+	ctx context.Context,
+	user *domain.User,
+) (*time.Time, error) {
+	opening, err := svc.overtime.GetOpening(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if opening != nil {
+		return &opening.Date, nil
+	}
+
+	d := user.EntryDate
+	if d == nil {
+		first, err := svc.timestamps.GetFirst(ctx, user.ID)
+		if err != nil || first == nil {
+			return nil, err
+		}
+		d = &first.StartTime
+	}
+
+	day := time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, time.UTC)
+	return &day, nil
+}
+
+// AddBooking books an opening balance, an adjustment, a payout or time off in
+// lieu on the overtime account of the user. Closed months can not be booked
+// on anymore and the opening balance has to precede all other bookings and
+// closed months.
+func (svc *OvertimeService) AddBooking(
+	ctx context.Context,
+	userId int64,
+	form domain.CreateOvertimeBooking,
+	editor *domain.User,
+) (*domain.OvertimeBooking, error) {
+	user, err := svc.user.GetById(ctx, userId)
+	if err != nil {
+		return nil, fmt.Errorf("user %v not found", userId)
+	}
+
+	b, err := form.Booking(user.ID, editor.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	month := domain.MonthStart(b.Date)
+	closed, err := svc.overtime.GetLastSnapshotBefore(ctx, user.ID, month.AddDate(0, 1, 0))
+	if err != nil {
+		return nil, err
+	}
+	if closed != nil && closed.Month.Equal(month) {
+		return nil, fmt.Errorf("%v is already closed", b.Date.Format("2006-01"))
+	}
+
+	if b.Kind == domain.OvertimeOpening {
+		bookings, err := svc.overtime.GetBookings(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		snapshots, err := svc.overtime.GetSnapshots(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if len(bookings) > 0 || len(snapshots) > 0 {
+			return nil, fmt.Errorf("the opening balance has to be the first booking")
+		}
+	} else {
+		start, err := svc.start(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		if start == nil {
+			return nil, fmt.Errorf("the overtime account has not started, book an opening balance")
+		}
+		if b.Date.Before(*start) {
+			return nil, fmt.Errorf(
+				"the overtime account starts on %v",
+				start.Format(time.DateOnly),
+			)
+		}
+	}
+
+	booking, err := svc.overtime.CreateBooking(ctx, &b)
+	if err != nil {
+		return nil, err
+	}
+
+	svc.log.Info(
+		"Booked overtime",
+		slog.Int64("userId", user.ID),
+		slog.String("kind", booking.Kind),
+		slog.Float64("hours", booking.Hours),
+	)
+
+	return booking, nil
+}
+
+func (svc *OvertimeService) GetBookings(
+	ctx context.Context,
+	userId int64,
+) ([]domain.OvertimeBooking, error) {
+	return svc.overtime.GetBookings(ctx, userId)
+}
+
+func (svc *OvertimeService) GetSnapshots(
+	ctx context.Context,
+	userId int64,
+) ([]domain.OvertimeSnapshot, error) {
+	return svc.overtime.GetSnapshots(ctx, userId)
+}
+
+// CloseMonths stores a snapshot of the overtime account of every staff user
+// for each month that ended since the last closed one. Users that fail are
+// logged and closed on the next run.
+func (svc *OvertimeService) CloseMonths(ctx context.Context) error {
+	users, err := svc.user.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	botName := config.GetConfig().BotName
+	now := time.Now().UTC()
+	for _, u := range users {
+		if !u.IsStaff(botName) {
+			continue
+		}
+		if err := svc.closeForUser(ctx, &u, now); err != nil {
+			svc.log.Error(
+				"failed to close overtime months",
+				slog.Int64("user", u.ID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (svc *OvertimeService) closeForUser(
+	ctx context.Context,
+	user *domain.User,
+	now time.Time,
+) error {
+	start, err := svc.start(ctx, user)
+	if err != nil || start == nil {
+		return err
+	}
+
+	since, carried := *start, 0.0
+	last, err := svc.overtime.GetLastSnapshotBefore(ctx, user.ID, domain.MonthStart(now))
+	if err != nil {
+		return err
+	}
+	if last != nil {
+		since, carried = last.Month.AddDate(0, 1, 0), last.Balance
+	}
+
+	for _, month := range domain.MonthsToClose(since, now) {
+		end := month.AddDate(0, 1, -1)
+		if start.After(end) {
+			// the account started after the month
+			continue
+		}
+		from := month
+		if start.After(from) {
+			from = *start
+		}
+
+		b, err := svc.balance(ctx, user, from, end, carried)
+		if err != nil {
+			return err
+		}
+
+		snapshot := b.Snapshot(month)
+		if _, err := svc.overtime.CreateSnapshot(ctx, &snapshot); err != nil {
+			return err
+		}
+		carried = b.Balance
+
+		svc.log.Info(
+			"Closed overtime month",
+			slog.Int64("userId", user.ID),
+			slog.String("month", month.Format("2006-01")),
+			slog.Float64("balance", b.Balance),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"chrono/internal/domain"
+)
+
+// pendingDecision is a request or a timestamp correction that waits for the
+// decision of its approvers.
+type pendingDecision struct {
+	id        int64
+	userId    int64
+	username  string
+	waiting   time.Time
+	approvers []int64
+	remind    bool
+	escalate  bool
+}
+
+// approverReminder reminds the approvers of pending decisions and escalates
+// them to the fallback approvers. A notification and its mark are stored
+// together, decisions that fail are logged and retried on the next run.
+type approverReminder struct {
+	kind          string
+	notif         *NotificationService
+	uow           domain.UnitOfWork
+	log           *slog.Logger
+	markReminded  func(ctx context.Context, id int64, at time.Time) error
+	markEscalated func(ctx context.Context, id int64, at time.Time) error
+	fallback      func(userId int64) ([]int64, error)
+}
+
+func (r approverReminder) run(ctx context.Context, pending []pendingDecision, now time.Time) {
+	reminded, escalated, failed := 0, 0, 0
+	for _, d := range pending {
+		days := int(now.Sub(d.waiting).Hours() / 24)
+
+		if d.escalate {
+			if err := r.escalateOne(ctx, d, days, now); err != nil {
+				r.log.Error(
+					"failed to escalate "+r.kind,
+					slog.Int64("id", d.id),
+					slog.String("error", err.Error()),
+				)
+				failed++
+			} else {
+				escalated++
+			}
+		}
+
+		if d.remind {
+			if err := r.remindOne(ctx, d, days, now); err != nil {
+				r.log.Error(
+					"failed to remind approvers of "+r.kind,
+					slog.Int64("id", d.id),
+					slog.String("error", err.Error()),
+				)
+				failed++
+			} else {
+				reminded++
+			}
+		}
+	}
+
+	if reminded > 0 || escalated > 0 || failed > 0 {
+		r.log.Info(
+			"Reminded approvers of pending "+r.kind+"s",
+			slog.Int("reminded", reminded),
+			slog.Int("escalated", escalated),
+			slog.Int("failed", failed),
+		)
+	}
+}
+
+func (r approverReminder) escalateOne(
+	ctx context.Context,
+	d pendingDecision,
+	days int,
+	now time.Time,
+) error {
+	ids, err := r.fallback(d.userId)
+	if err != nil {
+		return err
+	}
+
+	return r.uow.Do(ctx, func(ctx context.Context) error {
+		err := notifyIds(ctx, r.notif, domain.EscalationMsg(d.username, days), ids)
+		if err != nil {
+			return err
+		}
+
+		return r.markEscalated(ctx, d.id, now)
+	})
+}
+
+func (r approverReminder) remindOne(
+	ctx context.Context,
+	d pendingDecision,
+	days int,
+	now time.Time,
+) error {
+	return r.uow.Do(ctx, func(ctx context.Context) error {
+		err := notifyIds(ctx, r.notif, domain.ReminderMsg(d.username, days), d.approvers)
+		if err != nil {
+			return err
+		}
+
+		return r.markReminded(ctx, d.id, now)
+	})
+}
+
+// notifyIds sends the message to the users with the given ids.
+func notifyIds(ctx context.Context, notif *NotificationService, msg string, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	users := make([]domain.User, len(ids))
+	for i, id := range ids {
+		users[i] = domain.User{ID: id}
+	}
+
+	return notif.CreateAndNotify(ctx, msg, users)
+}
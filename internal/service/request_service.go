@@ -4,148 +4,318 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
+	"chrono/config"
 	"chrono/internal/domain"
 )
 
 type RequestService struct {
-	request domain.RequestRepository
-	notif   *NotificationService
-	user    domain.UserRepository
-	log     *slog.Logger
+	request  domain.RequestRepository
+	comment  domain.RequestCommentRepository
+	event    domain.EventRepository
+	user     domain.UserRepository
+	approval *ApprovalService
+	staffing *StaffingService
+	notif    *NotificationService
+	uow      domain.UnitOfWork
+	log      *slog.Logger
 }
 
 func NewRequestService(
 	r domain.RequestRepository,
+	c domain.RequestCommentRepository,
+	e domain.EventRepository,
 	u domain.UserRepository,
+	a *ApprovalService,
+	s *StaffingService,
 	n *NotificationService,
+	w domain.UnitOfWork,
 	log *slog.Logger,
 ) *RequestService {
-	return &RequestService{request: r, notif: n, log: log, user: u}
+	return &RequestService{
+		request:  r,
+		comment:  c,
+		event:    e,
+		user:     u,
+		approval: a,
+		staffing: s,
+		notif:    n,
+		uow:      w,
+		log:      log,
+	}
 }
 
-func (svc *RequestService) Create(
+// GetPending returns the requests that wait for the decision of the user in
+// their current approval stage, or that were escalated to the user, with the
+// staffing rules and blackout periods they violate.
+func (svc *RequestService) GetPending(
 	ctx context.Context,
-	msg string,
 	user *domain.User,
-	event *domain.Event,
-) (*domain.Request, error) {
-	req, err := svc.request.Create(ctx, msg, user, event)
+) ([]domain.BatchRequest, error) {
+	req, err := svc.request.GetPending(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	admins, err := svc.user.GetAdmins(ctx)
+	approvals, err := svc.approval.Load(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	err = svc.notif.CreateAndNotify(ctx, msg, admins)
+	req = slices.DeleteFunc(req, func(r domain.RequestAbsenceUser) bool {
+		return approvals.CanDecideRequest(r.UserID, r.Stage(), r.EscalatedAt != nil, user) != nil
+	})
+
+	absences := make([]domain.Absence, len(req))
+	for i := range req {
+		absences[i] = domain.Absence{
+			ID:        req[i].ID_3,
+			Name:      req[i].Name,
+			StartDate: req[i].StartDate,
+			EndDate:   req[i].EndDate,
+			UserID:    req[i].UserID,
+		}
+	}
+	violations, err := svc.staffing.CheckAbsences(ctx, absences)
 	if err != nil {
 		return nil, err
 	}
 
-	return req, nil
+	conflicts, err := svc.conflicts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	requestsToShow := make([]domain.BatchRequest, 0, len(req))
+	for i := range req {
+		requestsToShow = append(requestsToShow, domain.BatchRequest{
+			StartDate:  req[i].StartDate,
+			EndDate:    req[i].EndDate,
+			EventCount: int(req[i].EventCount),
+			Request:    &req[i],
+			Conflicts:  &conflicts[i],
+			Violations: violations[i],
+			Reminders:  req[i].ReminderCount,
+			Escalated:  req[i].EscalatedAt != nil,
+		})
+	}
+
+	return requestsToShow, nil
 }
 
-func (svc *RequestService) GetPending(ctx context.Context) ([]domain.BatchRequest, error) {
-	req, err := svc.request.GetPending(ctx)
+// conflicts returns for each request the other users with events in its
+// range, the events of all requests are loaded at once.
+func (svc *RequestService) conflicts(
+	ctx context.Context,
+	req []domain.RequestAbsenceUser,
+) ([][]domain.User, error) {
+	conflicts := make([][]domain.User, len(req))
+	if len(req) == 0 {
+		return conflicts, nil
+	}
+
+	start, end := req[0].StartDate, req[0].EndDate
+	for _, r := range req[1:] {
+		if r.StartDate.Before(start) {
+			start = r.StartDate
+		}
+		if r.EndDate.After(end) {
+			end = r.EndDate
+		}
+	}
+
+	events, err := svc.event.GetAllInRange(ctx, start, end)
 	if err != nil {
 		return nil, err
 	}
 
-	requestsToShow := []domain.BatchRequest{}
+	users, err := svc.user.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	startIndex := 0
-	for startIndex < len(req) {
-		endIndex := startIndex
+	for i := range req {
+		absent := map[int64]bool{}
+		for _, e := range events {
+			if e.UserID != req[i].UserID &&
+				!e.ScheduledAt.Before(req[i].StartDate) &&
+				!e.ScheduledAt.After(req[i].EndDate) {
+				absent[e.UserID] = true
+			}
+		}
 
-		for endIndex+1 < len(req) &&
-			req[endIndex].ScheduledAt.Year() == req[endIndex+1].ScheduledAt.Year() &&
-			req[endIndex].ScheduledAt.YearDay()+1 == req[endIndex+1].ScheduledAt.YearDay() &&
-			req[endIndex].Name == req[endIndex+1].Name &&
-			req[endIndex].UserID == req[endIndex+1].UserID {
-			endIndex++
+		conflicts[i] = []domain.User{}
+		for _, u := range users {
+			if absent[u.ID] {
+				conflicts[i] = append(conflicts[i], u)
+			}
 		}
+	}
 
-		startDate := req[startIndex].ScheduledAt
-		endDate := req[endIndex].ScheduledAt
+	return conflicts, nil
+}
 
-		confilctingUsers, err := svc.user.GetConflicting(
-			ctx,
-			req[startIndex].UserID,
-			startDate,
-			endDate,
-		)
-		if err != nil {
-			return nil, err
-		}
+// Remind sends a reminder to the approvers of every request that has been
+// waiting in its stage for the configured interval, and escalates requests
+// that waited longer than the escalation period to the fallback approvers.
+// Requests that fail are logged and retried on the next run.
+func (svc *RequestService) Remind(ctx context.Context) error {
+	cfg := config.GetConfig()
+	every := durationOfDays(cfg.ReminderDays)
+	after := durationOfDays(cfg.EscalationDays)
+	if every <= 0 && after <= 0 {
+		return nil
+	}
 
-		requestsToShow = append(requestsToShow, domain.BatchRequest{
-			StartDate:  startDate,
-			EndDate:    endDate,
-			EventCount: endIndex - startIndex + 1,
-			Request:    &req[endIndex],
-			Conflicts:  &confilctingUsers,
-		})
+	req, err := svc.request.GetPending(ctx)
+	if err != nil {
+		return err
+	}
+
+	approvals, err := svc.approval.Load(ctx)
+	if err != nil {
+		return err
+	}
 
-		startIndex = endIndex + 1
+	now := time.Now().UTC()
+	pending := make([]pendingDecision, len(req))
+	for i := range req {
+		pending[i] = pendingDecision{
+			id:        req[i].ID,
+			userId:    req[i].UserID,
+			username:  req[i].Username,
+			waiting:   req[i].EditedAt,
+			approvers: approvals.For(req[i].UserID, req[i].Stage()),
+			remind:    req[i].ReminderDue(now, every),
+			escalate:  req[i].EscalationDue(now, after),
+		}
 	}
 
-	return requestsToShow, nil
-}
+	approverReminder{
+		kind:          "request",
+		notif:         svc.notif,
+		uow:           svc.uow,
+		log:           svc.log,
+		markReminded:  svc.request.MarkReminded,
+		markEscalated: svc.request.MarkEscalated,
+		fallback:      approvals.Fallback,
+	}.run(ctx, pending, now)
 
-func (svc *RequestService) GetEventNameFrom(
-	ctx context.Context,
-	req int64,
-) (string, error) {
-	return svc.request.GetEventNameFrom(ctx, req)
+	return nil
 }
 
-func (svc *RequestService) GetInRange(
+// GetComments returns the comments on the request, oldest first.
+func (svc *RequestService) GetComments(
 	ctx context.Context,
-	userId int64,
-	start, end time.Time,
-) ([]domain.Request, error) {
-	return svc.request.GetInRange(ctx, userId, start, end)
+	requestId int64,
+	user *domain.User,
+) ([]domain.RequestComment, error) {
+	req, _, err := svc.participant(ctx, requestId, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.comment.GetForRequest(ctx, req.ID)
 }
 
-func (svc *RequestService) UpdateInRange(
+// AddComment stores a comment of the user on the request and notifies the
+// other participants.
+func (svc *RequestService) AddComment(
 	ctx context.Context,
-	editorId int64,
-	form domain.PatchRequestForm,
-) (int64, error) {
-	startDate := time.Unix(form.StartDate, 0).UTC()
-	endDate := time.Unix(form.EndDate, 0).UTC()
-	reqId, err := svc.request.UpdateInRange(
-		ctx,
-		form.State,
-		editorId,
-		form.UserID,
-		startDate,
-		endDate,
-	)
+	requestId int64,
+	form domain.CreateRequestComment,
+	user *domain.User,
+) (*domain.RequestComment, error) {
+	req, ids, err := svc.participant(ctx, requestId, user)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := domain.CleanMessage(form.Body)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	if body == "" {
+		return nil, fmt.Errorf("comment must not be empty")
 	}
 
-	editor, err := svc.user.GetById(ctx, editorId)
+	comment, err := svc.comment.Create(ctx, &domain.RequestComment{
+		RequestID: req.ID,
+		UserID:    user.ID,
+		Kind:      domain.CommentKindComment,
+		Body:      body,
+		Username:  user.Username,
+	})
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	msg := fmt.Sprintf("%v %v your request.", editor.Username, form.State)
-	if form.Reason != "" {
-		msg = fmt.Sprintf("%v %v your request: %v.", editor.Username, form.State, form.Reason)
+	others := slices.DeleteFunc(ids, func(id int64) bool { return id == user.ID })
+	err = notifyIds(ctx, svc.notif, domain.CommentMsg(user.Username, body), others)
+	if err != nil {
+		return nil, err
 	}
 
-	err = svc.notif.CreateAndNotify(ctx, msg, []domain.User{{ID: form.UserID}})
+	return comment, nil
+}
+
+// participant returns the request and the ids of its participants, the
+// requester, the approvers of both stages and the fallback approvers of an
+// escalated request. Other users but admins can not access it.
+func (svc *RequestService) participant(
+	ctx context.Context,
+	requestId int64,
+	user *domain.User,
+) (*domain.Request, []int64, error) {
+	req, err := svc.request.GetById(ctx, requestId)
 	if err != nil {
-		return 0, err
+		return nil, nil, fmt.Errorf("request %v not found", requestId)
+	}
+
+	ids := []int64{req.UserID}
+	for _, stage := range []int64{domain.StageLead, domain.StageFinal} {
+		approvers, err := svc.approval.Approvers(ctx, req.UserID, stage)
+		if err != nil {
+			return nil, nil, err
+		}
+		ids = append(ids, approvers...)
+	}
+	if req.EscalatedAt != nil {
+		fallback, err := svc.approval.Fallback(ctx, req.UserID)
+		if err != nil {
+			return nil, nil, err
+		}
+		ids = append(ids, fallback...)
+	}
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	if !user.IsAdmin() && !slices.Contains(ids, user.ID) {
+		return nil, nil, fmt.Errorf("request %v not found", requestId)
 	}
 
-	return reqId, nil
+	return req, ids, nil
+}
+
+func durationOfDays(days float64) time.Duration {
+	return time.Duration(days * float64(24*time.Hour))
+}
+
+func (svc *RequestService) GetEventNameFrom(
+	ctx context.Context,
+	req int64,
+) (string, error) {
+	return svc.request.GetEventNameFrom(ctx, req)
+}
+
+func (svc *RequestService) GetInRange(
+	ctx context.Context,
+	userId int64,
+	start, end time.Time,
+) ([]domain.Request, error) {
+	return svc.request.GetInRange(ctx, userId, start, end)
 }
 
 func (svc *RequestService) GetEventName(ctx context.Context, reqId int64) (string, error) {
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"chrono/internal/domain"
+)
+
+// Scheduler runs the registered jobs in the background. A job is due once its
+// interval has passed since its last recorded run, so the schedule survives
+// restarts.
+type Scheduler struct {
+	runs domain.JobRunRepository
+	jobs []domain.Job
+	tick time.Duration
+	log  *slog.Logger
+
+	// mu guards the fields below, Start and Stop are called from different
+	// goroutines during startup and shutdown
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	done    chan struct{}
+	stopped bool
+}
+
+func NewScheduler(r domain.JobRunRepository, tick time.Duration, log *slog.Logger) *Scheduler {
+	return &Scheduler{runs: r, tick: tick, log: log}
+}
+
+// Register adds a job, jobs have to be registered before Start.
+func (s *Scheduler) Register(job domain.Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Start runs the due jobs right away and then checks for due jobs every tick
+// until Stop is called or ctx is done. Start does nothing once Stop was called.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped || s.cancel != nil {
+		return
+	}
+
+	ctx, s.cancel = context.WithCancel(ctx)
+	done := make(chan struct{})
+	s.done = done
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(s.tick)
+		defer ticker.Stop()
+
+		for {
+			s.runDue(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	s.log.Info("Started scheduler.", slog.Int("jobs", len(s.jobs)))
+}
+
+// Stop cancels the scheduler and waits for the running job to return.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	s.stopped = true
+	cancel, done := s.cancel, s.done
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	<-done
+	s.log.Info("Stopped scheduler.")
+}
+
+// Status returns every registered job with its last run.
+func (s *Scheduler) Status(ctx context.Context) ([]domain.JobStatus, error) {
+	runs, err := s.runs.GetLastForAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]domain.JobStatus, len(s.jobs))
+	for i, job := range s.jobs {
+		status[i] = domain.JobStatus{Name: job.Name, Interval: job.Interval.String()}
+		for _, r := range runs {
+			if r.Job == job.Name {
+				status[i].LastRun = &r
+				break
+			}
+		}
+	}
+
+	return status, nil
+}
+
+func (s *Scheduler) runDue(ctx context.Context) {
+	for _, job := range s.jobs {
+		if ctx.Err() != nil {
+			return
+		}
+		if !s.isDue(ctx, job) {
+			continue
+		}
+		s.run(ctx, job)
+	}
+}
+
+func (s *Scheduler) isDue(ctx context.Context, job domain.Job) bool {
+	last, err := s.runs.GetLast(ctx, job.Name)
+	if err != nil {
+		return true
+	}
+
+	return time.Since(last.StartedAt) >= job.Interval
+}
+
+func (s *Scheduler) run(ctx context.Context, job domain.Job) {
+	run, err := s.runs.Start(ctx, job.Name)
+	if err != nil {
+		return
+	}
+
+	err = func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("job panicked: %v", r)
+			}
+		}()
+		return job.Run(ctx)
+	}()
+	if err != nil {
+		s.log.Error(
+			"Job failed",
+			slog.String("job", job.Name),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	// the run is recorded even if ctx was cancelled in the meantime
+	_, _ = s.runs.Finish(context.WithoutCancel(ctx), run.ID, err)
+}
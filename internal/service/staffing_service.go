@@ -0,0 +1,338 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"chrono/config"
+	"chrono/internal/domain"
+)
+
+type StaffingService struct {
+	staffing  domain.StaffingRepository
+	event     domain.EventRepository
+	eventType *EventTypeService
+	user      domain.UserRepository
+	contract  domain.UserContractRepository
+	approval  *ApprovalService
+	log       *slog.Logger
+}
+
+func NewStaffingService(
+	s domain.StaffingRepository,
+	e domain.EventRepository,
+	et *EventTypeService,
+	u domain.UserRepository,
+	uc domain.UserContractRepository,
+	ap *ApprovalService,
+	log *slog.Logger,
+) *StaffingService {
+	return &StaffingService{
+		staffing:  s,
+		event:     e,
+		eventType: et,
+		user:      u,
+		contract:  uc,
+		approval:  ap,
+		log:       log,
+	}
+}
+
+func (svc *StaffingService) CreateRule(
+	ctx context.Context,
+	form domain.CreateStaffingRule,
+) (*domain.StaffingRule, error) {
+	if form.MinPresent <= 0 {
+		return nil, fmt.Errorf("minimum present must be positive, got %v", form.MinPresent)
+	}
+
+	severity := form.Severity
+	if severity == "" {
+		severity = domain.SeverityWarning
+	}
+	if !domain.IsValidSeverity(severity) {
+		return nil, fmt.Errorf("invalid severity %q", severity)
+	}
+
+	teamId, err := svc.team(ctx, form.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.staffing.CreateRule(ctx, &domain.StaffingRule{
+		TeamID:     teamId,
+		MinPresent: form.MinPresent,
+		Severity:   severity,
+	})
+}
+
+func (svc *StaffingService) DeleteRule(ctx context.Context, id int64) error {
+	return svc.staffing.DeleteRule(ctx, id)
+}
+
+func (svc *StaffingService) GetRules(ctx context.Context) ([]domain.StaffingRule, error) {
+	return svc.staffing.GetRules(ctx)
+}
+
+func (svc *StaffingService) CreateBlackout(
+	ctx context.Context,
+	form domain.CreateBlackoutPeriod,
+) (*domain.BlackoutPeriod, error) {
+	if form.Name == "" {
+		return nil, fmt.Errorf("blackout period needs a name")
+	}
+
+	start, err := time.Parse(time.DateOnly, form.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date %q", form.StartDate)
+	}
+	end, err := time.Parse(time.DateOnly, form.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date %q", form.EndDate)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("end date must not be before start date")
+	}
+
+	severity := form.Severity
+	if severity == "" {
+		severity = domain.SeverityError
+	}
+	if !domain.IsValidSeverity(severity) {
+		return nil, fmt.Errorf("invalid severity %q", severity)
+	}
+
+	teamId, err := svc.team(ctx, form.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.staffing.CreateBlackout(ctx, &domain.BlackoutPeriod{
+		Name:      form.Name,
+		StartDate: start,
+		EndDate:   end,
+		TeamID:    teamId,
+		Severity:  severity,
+	})
+}
+
+func (svc *StaffingService) DeleteBlackout(ctx context.Context, id int64) error {
+	return svc.staffing.DeleteBlackout(ctx, id)
+}
+
+func (svc *StaffingService) GetBlackouts(ctx context.Context) ([]domain.BlackoutPeriod, error) {
+	return svc.staffing.GetBlackouts(ctx)
+}
+
+// Check evaluates the staffing rules and blackout periods that apply to the
+// team of the user for an absence on the given days. Blackout periods only
+// restrict types that consume vacation.
+func (svc *StaffingService) Check(
+	ctx context.Context,
+	userId int64,
+	typ domain.EventType,
+	days []domain.AbsenceDay,
+) ([]domain.RuleViolation, error) {
+	if len(days) == 0 {
+		return []domain.RuleViolation{}, nil
+	}
+
+	data, err := svc.load(ctx, days[0].Date, days[len(days)-1].Date)
+	if err != nil {
+		return nil, err
+	}
+
+	return data.check(userId, typ, days), nil
+}
+
+// CheckAbsences evaluates the rules for the days of absences that are not
+// decided yet. The rules and staff are loaded once for all absences, the
+// violations are returned in the order of the absences.
+func (svc *StaffingService) CheckAbsences(
+	ctx context.Context,
+	absences []domain.Absence,
+) ([][]domain.RuleViolation, error) {
+	violations := make([][]domain.RuleViolation, len(absences))
+	if len(absences) == 0 {
+		return violations, nil
+	}
+
+	start, end := absences[0].StartDate, absences[0].EndDate
+	for _, a := range absences[1:] {
+		if a.StartDate.Before(start) {
+			start = a.StartDate
+		}
+		if a.EndDate.After(end) {
+			end = a.EndDate
+		}
+	}
+
+	data, err := svc.load(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, a := range absences {
+		days := []domain.AbsenceDay{}
+		for _, e := range data.absent {
+			if e.AbsenceID != nil && *e.AbsenceID == a.ID {
+				days = append(days, domain.AbsenceDay{Date: e.ScheduledAt, HalfDay: e.HalfDay})
+			}
+		}
+		violations[i] = data.check(a.UserID, data.types.Get(a.Name), days)
+	}
+
+	return violations, nil
+}
+
+// CheckAbsence evaluates the rules for the days of an absence that is not
+// decided yet.
+func (svc *StaffingService) CheckAbsence(
+	ctx context.Context,
+	absence *domain.Absence,
+) ([]domain.RuleViolation, error) {
+	violations, err := svc.CheckAbsences(ctx, []domain.Absence{*absence})
+	if err != nil {
+		return nil, err
+	}
+
+	return violations[0], nil
+}
+
+// staffingData is what the rules are evaluated against, the absence events
+// and blackout periods cover [start, end] of the load.
+type staffingData struct {
+	types     domain.EventTypes
+	rules     []domain.StaffingRule
+	blackouts []domain.BlackoutPeriod
+	users     []domain.User
+	contracts map[int64][]domain.UserContract
+	members   []domain.TeamMember
+	absent    []domain.Event
+}
+
+func (svc *StaffingService) load(
+	ctx context.Context,
+	start, end time.Time,
+) (*staffingData, error) {
+	types, err := svc.eventType.GetMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := svc.staffing.GetRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blackouts, err := svc.staffing.GetBlackoutsInRange(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := svc.user.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts, err := svc.contract.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := svc.approval.GetTeamMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	absent, err := svc.event.GetAbsencesInRange(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return &staffingData{
+		types:     types,
+		rules:     rules,
+		blackouts: blackouts,
+		users:     users,
+		contracts: contracts,
+		members:   members,
+		absent:    absent,
+	}, nil
+}
+
+func (d *staffingData) check(
+	userId int64,
+	typ domain.EventType,
+	days []domain.AbsenceDay,
+) []domain.RuleViolation {
+	violations := []domain.RuleViolation{}
+	if len(days) == 0 {
+		return violations
+	}
+
+	teamId := domain.TeamOf(d.members, userId)
+
+	if typ.ConsumesVacation {
+		for _, b := range d.blackouts {
+			if !b.AppliesTo(teamId) {
+				continue
+			}
+			if v := b.Violation(days); v != nil {
+				violations = append(violations, *v)
+			}
+		}
+	}
+
+	for _, r := range d.rules {
+		if !r.AppliesTo(teamId) {
+			continue
+		}
+
+		staff := staffOf(d.users, d.members, r.TeamID)
+		violations = append(
+			violations,
+			r.Violations(userId, days, staff, d.contracts, d.absent)...,
+		)
+	}
+
+	return violations
+}
+
+// team returns the id of the team or nil for id 0 and fails for unknown
+// teams.
+func (svc *StaffingService) team(ctx context.Context, id int64) (*int64, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	if _, err := svc.approval.GetTeamById(ctx, id); err != nil {
+		return nil, fmt.Errorf("team %v not found", id)
+	}
+
+	return &id, nil
+}
+
+// staffOf returns the enabled users of the team, or of the company for a nil
+// team, without the bot user.
+func staffOf(users []domain.User, members []domain.TeamMember, teamId *int64) []domain.User {
+	team := map[int64]int64{}
+	for _, m := range members {
+		team[m.UserID] = m.TeamID
+	}
+
+	botName := config.GetConfig().BotName
+	staff := []domain.User{}
+	for _, u := range users {
+		if !u.IsStaff(botName) {
+			continue
+		}
+		if teamId != nil && team[u.ID] != *teamId {
+			continue
+		}
+		staff = append(staff, u)
+	}
+
+	return staff
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"chrono/config"
+	"chrono/internal/domain"
+)
+
+type TimestampCorrectionService struct {
+	correction domain.TimestampCorrectionRepository
+	timestamps *TimestampsService
+	breaks     domain.TimestampBreakRepository
+	user       domain.UserRepository
+	notif      *NotificationService
+	uow        domain.UnitOfWork
+	log        *slog.Logger
+}
+
+func NewTimestampCorrectionService(
+	c domain.TimestampCorrectionRepository,
+	t *TimestampsService,
+	b domain.TimestampBreakRepository,
+	u domain.UserRepository,
+	n *NotificationService,
+	w domain.UnitOfWork,
+	log *slog.Logger,
+) *TimestampCorrectionService {
+	return &TimestampCorrectionService{
+		correction: c,
+		timestamps: t,
+		breaks:     b,
+		user:       u,
+		notif:      n,
+		uow:        w,
+		log:        log,
+	}
+}
+
+// Create asks to change, add or delete a timestamp of the user and notifies
+// the admins.
+func (svc *TimestampCorrectionService) Create(
+	ctx context.Context,
+	form domain.CreateTimestampCorrection,
+	user *domain.User,
+) (*domain.TimestampCorrection, error) {
+	c := &domain.TimestampCorrection{UserID: user.ID, Kind: form.Kind}
+	if form.Kind != domain.CorrectionAdd {
+		c.TimestampID = form.TimestampID
+	}
+	if !form.StartTime.IsZero() {
+		start := form.StartTime.UTC()
+		c.StartTime = &start
+	}
+	if !form.EndTime.IsZero() {
+		end := form.EndTime.UTC()
+		c.EndTime = &end
+	}
+	if form.Reason != "" {
+		c.Reason = &form.Reason
+	}
+
+	t, err := svc.validate(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	if t != nil && t.UserID != user.ID {
+		return nil, fmt.Errorf("timestamp %v not found", t.ID)
+	}
+
+	if c.TimestampID != nil {
+		existing, err := svc.correction.GetForUser(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range existing {
+			if e.IsPending() && e.TimestampID != nil && *e.TimestampID == *c.TimestampID {
+				return nil, fmt.Errorf("a correction of this timestamp is already pending")
+			}
+		}
+	}
+
+	c, err = svc.correction.Create(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	admins, err := svc.user.GetAdmins(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recipients := []domain.User{}
+	for _, a := range admins {
+		if a.ID != user.ID {
+			recipients = append(recipients, a)
+		}
+	}
+	err = svc.notif.CreateAndNotify(ctx, c.RequestMsg(user.Username, t), recipients)
+	if err != nil {
+		return nil, err
+	}
+
+	svc.log.Info(
+		"Created timestamp correction",
+		slog.Int64("id", c.ID),
+		slog.String("kind", c.Kind),
+	)
+
+	return c, nil
+}
+
+// validate checks the correction against the current state of its
+// timestamp, which it returns, nil for an added timestamp.
+func (svc *TimestampCorrectionService) validate(
+	ctx context.Context,
+	c *domain.TimestampCorrection,
+) (*domain.Timestamp, error) {
+	var t *domain.Timestamp
+	var breaks []domain.TimestampBreak
+	if c.Kind != domain.CorrectionAdd && c.TimestampID != nil {
+		ts, err := svc.timestamps.GetById(ctx, *c.TimestampID)
+		if err != nil {
+			return nil, fmt.Errorf("timestamp %v not found", *c.TimestampID)
+		}
+		t = &ts
+
+		breaks, err = svc.breaks.GetForTimestamp(ctx, ts.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.Validate(t, breaks, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (svc *TimestampCorrectionService) GetForUser(
+	ctx context.Context,
+	userId int64,
+) ([]domain.TimestampCorrection, error) {
+	return svc.correction.GetForUser(ctx, userId)
+}
+
+func (svc *TimestampCorrectionService) GetPending(
+	ctx context.Context,
+) ([]domain.TimestampCorrection, error) {
+	return svc.correction.GetPending(ctx)
+}
+
+// Remind sends a reminder to the admins of every correction that has been
+// pending for the configured interval. Corrections are decided by the admins
+// only, so they are never escalated.
+func (svc *TimestampCorrectionService) Remind(ctx context.Context) error {
+	every := durationOfDays(config.GetConfig().ReminderDays)
+	if every <= 0 {
+		return nil
+	}
+
+	corrections, err := svc.correction.GetPending(ctx)
+	if err != nil {
+		return err
+	}
+
+	admins, err := svc.user.GetAdmins(ctx)
+	if err != nil {
+		return err
+	}
+
+	users, err := svc.user.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	usernames := make(map[int64]string, len(users))
+	for _, u := range users {
+		usernames[u.ID] = u.Username
+	}
+
+	now := time.Now().UTC()
+	pending := make([]pendingDecision, 0, len(corrections))
+	for i := range corrections {
+		if !corrections[i].ReminderDue(now, every) {
+			continue
+		}
+
+		approvers := []int64{}
+		for _, a := range admins {
+			if a.ID != corrections[i].UserID {
+				approvers = append(approvers, a.ID)
+			}
+		}
+
+		pending = append(pending, pendingDecision{
+			id:        corrections[i].ID,
+			userId:    corrections[i].UserID,
+			username:  usernames[corrections[i].UserID],
+			waiting:   corrections[i].CreatedAt,
+			approvers: approvers,
+			remind:    true,
+		})
+	}
+
+	approverReminder{
+		kind:         "timestamp correction",
+		notif:        svc.notif,
+		uow:          svc.uow,
+		log:          svc.log,
+		markReminded: svc.correction.MarkReminded,
+	}.run(ctx, pending, now)
+
+	return nil
+}
+
+// UpdateState accepts or declines a pending correction and notifies the
+// requesting user. Accepting applies the correction to the timestamps and
+// keeps the original values in the history.
+func (svc *TimestampCorrectionService) UpdateState(
+	ctx context.Context,
+	id int64,
+	form domain.UpdateTimestampCorrection,
+	editor *domain.User,
+) (*domain.TimestampCorrection, error) {
+	if form.State != "accepted" && form.State != "declined" {
+		return nil, fmt.Errorf("invalid state %q", form.State)
+	}
+	if !editor.IsAdmin() {
+		return nil, fmt.Errorf("only admins decide timestamp corrections")
+	}
+
+	c, err := svc.correction.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !c.IsPending() {
+		return nil, fmt.Errorf("correction %v is not pending", id)
+	}
+	if c.UserID == editor.ID {
+		return nil, fmt.Errorf("users can not approve their own requests")
+	}
+
+	c.State = form.State
+	err = svc.uow.Do(ctx, func(ctx context.Context) error {
+		if c.State == "accepted" {
+			if _, err := svc.validate(ctx, c); err != nil {
+				return err
+			}
+			t, err := svc.timestamps.Apply(ctx, c, editor.ID)
+			if err != nil {
+				return err
+			}
+			// the timestamp of a delete is gone, the history keeps its id
+			c.TimestampID = &t.ID
+			if c.Kind == domain.CorrectionDelete {
+				c.TimestampID = nil
+			}
+		}
+
+		c, err = svc.correction.Decide(ctx, c, editor.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	msg := domain.CorrectionDecisionMsg(editor.Username, c.State, form.Reason)
+	err = svc.notif.CreateAndNotify(ctx, msg, []domain.User{{ID: c.UserID}})
+	if err != nil {
+		return nil, err
+	}
+
+	svc.log.Info(
+		"Decided timestamp correction",
+		slog.Int64("id", c.ID),
+		slog.String("state", c.State),
+	)
+
+	return c, nil
+}
@@ -2,45 +2,481 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
+	"chrono/config"
 	"chrono/internal/domain"
 )
 
 type TimestampsService struct {
 	timestamps domain.TimestampsRepository
+	breaks     domain.TimestampBreakRepository
+	history    domain.TimestampHistoryRepository
+	keys       domain.IdempotencyKeyRepository
 	event      *EventService
+	contract   *UserContractService
+	user       domain.UserRepository
+	notif      *NotificationService
+	uow        domain.UnitOfWork
 	log        *slog.Logger
 }
 
 func NewTimestampsService(
 	r domain.TimestampsRepository,
+	b domain.TimestampBreakRepository,
+	h domain.TimestampHistoryRepository,
+	k domain.IdempotencyKeyRepository,
 	e *EventService,
+	c *UserContractService,
+	u domain.UserRepository,
+	n *NotificationService,
+	w domain.UnitOfWork,
 	log *slog.Logger,
 ) *TimestampsService {
-	return &TimestampsService{timestamps: r, log: log, event: e}
+	return &TimestampsService{
+		timestamps: r,
+		breaks:     b,
+		history:    h,
+		keys:       k,
+		log:        log,
+		event:      e,
+		contract:   c,
+		user:       u,
+		notif:      n,
+		uow:        w,
+	}
 }
 
 func (r *TimestampsService) GetById(ctx context.Context, id int64) (domain.Timestamp, error) {
 	return r.timestamps.GetById(ctx, id)
 }
 
-func (r *TimestampsService) Start(ctx context.Context, userId int64) (domain.Timestamp, error) {
-	return r.timestamps.Start(ctx, userId)
+// Start clocks the user in. A user has at most one running timer, which is
+// returned if it is already running. A retry with the key of an earlier
+// request returns the timestamp that request started.
+func (r *TimestampsService) Start(
+	ctx context.Context,
+	userId int64,
+	key string,
+) (domain.Timestamp, error) {
+	t, ok, err := r.replay(ctx, userId, key, domain.IdempotencyStart, nil)
+	if err != nil || ok {
+		return t, err
+	}
+
+	err = r.uow.Do(ctx, func(ctx context.Context) error {
+		t, err = r.timestamps.Start(ctx, userId)
+		if err != nil {
+			return err
+		}
+		return r.remember(ctx, userId, key, domain.IdempotencyStart, t.ID)
+	})
+	if err != nil {
+		return domain.Timestamp{}, err
+	}
+
+	return t, nil
 }
 
-func (r *TimestampsService) Stop(ctx context.Context, id int64) (domain.Timestamp, error) {
-	t, err := r.GetById(ctx, id)
+// Stop clocks the user out of the timestamp, stopping a stopped one is a
+// no-op.
+func (r *TimestampsService) Stop(
+	ctx context.Context,
+	id int64,
+	user *domain.User,
+	key string,
+) (domain.Timestamp, error) {
+	t, err := r.owned(ctx, id, user)
 	if err != nil {
 		return domain.Timestamp{}, err
 	}
 
+	replayed, ok, err := r.replay(ctx, t.UserID, key, domain.IdempotencyStop, &id)
+	if err != nil || ok {
+		return replayed, err
+	}
+
 	if t.EndTime != nil {
 		return t, nil
 	}
 
-	return r.timestamps.Stop(ctx, id)
+	// a paused timer ends with its break
+	err = r.uow.Do(ctx, func(ctx context.Context) error {
+		t, err = r.timestamps.Stop(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := r.breaks.EndOpen(ctx, id, *t.EndTime); err != nil {
+			return err
+		}
+		return r.remember(ctx, t.UserID, key, domain.IdempotencyStop, id)
+	})
+	if err != nil {
+		return domain.Timestamp{}, err
+	}
+
+	return t, nil
+}
+
+// replay returns the timestamp of an earlier request of the user with the
+// key, ok is false if there is none. Reusing a key for another request is an
+// error.
+func (r *TimestampsService) replay(
+	ctx context.Context,
+	userId int64,
+	key string,
+	action string,
+	timestampId *int64,
+) (t domain.Timestamp, ok bool, err error) {
+	if key == "" {
+		return t, false, nil
+	}
+
+	k, err := r.keys.Get(ctx, userId, key, time.Now().UTC().Add(-domain.IdempotencyKeyTTL))
+	if err != nil || k == nil {
+		return t, false, err
+	}
+	if !k.Matches(action, timestampId) {
+		return t, false, fmt.Errorf("idempotency key %q was used for another request", key)
+	}
+	if k.TimestampID == nil {
+		return t, false, fmt.Errorf("timestamp of idempotency key %q was deleted", key)
+	}
+
+	t, err = r.timestamps.GetById(ctx, *k.TimestampID)
+	return t, err == nil, err
+}
+
+// remember stores the timestamp a request with the key was applied to.
+func (r *TimestampsService) remember(
+	ctx context.Context,
+	userId int64,
+	key string,
+	action string,
+	timestampId int64,
+) error {
+	if key == "" {
+		return nil
+	}
+
+	_, err := r.keys.Save(ctx, &domain.IdempotencyKey{
+		UserID:      userId,
+		Key:         key,
+		Action:      action,
+		TimestampID: &timestampId,
+	})
+	return err
+}
+
+// Pause starts a break on the running timestamp of the user.
+func (r *TimestampsService) Pause(
+	ctx context.Context,
+	id int64,
+	user *domain.User,
+) (domain.TimestampBreak, error) {
+	t, err := r.owned(ctx, id, user)
+	if err != nil {
+		return domain.TimestampBreak{}, err
+	}
+	if t.EndTime != nil {
+		return domain.TimestampBreak{}, fmt.Errorf("timestamp %v is not running", id)
+	}
+
+	open, err := r.openBreak(ctx, id)
+	if err != nil {
+		return domain.TimestampBreak{}, err
+	}
+	if open != nil {
+		return domain.TimestampBreak{}, fmt.Errorf("timestamp %v is already paused", id)
+	}
+
+	return r.breaks.Start(ctx, id)
+}
+
+// Resume ends the break of the paused timestamp of the user.
+func (r *TimestampsService) Resume(
+	ctx context.Context,
+	id int64,
+	user *domain.User,
+) (domain.TimestampBreak, error) {
+	if _, err := r.owned(ctx, id, user); err != nil {
+		return domain.TimestampBreak{}, err
+	}
+
+	open, err := r.openBreak(ctx, id)
+	if err != nil {
+		return domain.TimestampBreak{}, err
+	}
+	if open == nil {
+		return domain.TimestampBreak{}, fmt.Errorf("timestamp %v is not paused", id)
+	}
+
+	return r.breaks.Stop(ctx, id)
+}
+
+func (r *TimestampsService) GetBreaks(
+	ctx context.Context,
+	id int64,
+	user *domain.User,
+) ([]domain.TimestampBreak, error) {
+	if _, err := r.owned(ctx, id, user); err != nil {
+		return nil, err
+	}
+
+	return r.breaks.GetForTimestamp(ctx, id)
+}
+
+// AddBreak records a finished break within the timestamp of the user.
+func (r *TimestampsService) AddBreak(
+	ctx context.Context,
+	id int64,
+	form domain.CreateTimestampBreak,
+	user *domain.User,
+) (domain.TimestampBreak, error) {
+	t, err := r.owned(ctx, id, user)
+	if err != nil {
+		return domain.TimestampBreak{}, err
+	}
+
+	existing, err := r.breaks.GetForTimestamp(ctx, id)
+	if err != nil {
+		return domain.TimestampBreak{}, err
+	}
+
+	start, end := form.StartTime.UTC(), form.EndTime.UTC()
+	form.StartTime, form.EndTime = start, end
+	if err := t.ValidBreak(form, existing, time.Now().UTC()); err != nil {
+		return domain.TimestampBreak{}, err
+	}
+
+	return r.breaks.Create(ctx, &domain.TimestampBreak{
+		TimestampID: id,
+		StartTime:   start,
+		EndTime:     &end,
+	})
+}
+
+func (r *TimestampsService) DeleteBreak(
+	ctx context.Context,
+	id int64,
+	breakId int64,
+	user *domain.User,
+) error {
+	if _, err := r.owned(ctx, id, user); err != nil {
+		return err
+	}
+
+	b, err := r.breaks.GetById(ctx, breakId)
+	if err != nil {
+		return err
+	}
+	if b.TimestampID != id {
+		return fmt.Errorf("break %v does not belong to timestamp %v", breakId, id)
+	}
+
+	return r.breaks.Delete(ctx, breakId)
+}
+
+// owned returns the timestamp if it belongs to the user or the user is an
+// admin.
+func (r *TimestampsService) owned(
+	ctx context.Context,
+	id int64,
+	user *domain.User,
+) (domain.Timestamp, error) {
+	t, err := r.GetById(ctx, id)
+	if err != nil {
+		return domain.Timestamp{}, err
+	}
+
+	if t.UserID != user.ID && !user.IsAdmin() {
+		return domain.Timestamp{}, fmt.Errorf(
+			"User: %v has no permission to change the timestamp.",
+			user.Username,
+		)
+	}
+
+	return t, nil
+}
+
+// openBreak returns the running break of the timestamp, nil if it is not
+// paused.
+func (r *TimestampsService) openBreak(
+	ctx context.Context,
+	id int64,
+) (*domain.TimestampBreak, error) {
+	breaks, err := r.breaks.GetForTimestamp(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range breaks {
+		if b.EndTime == nil {
+			return &b, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetCompliance checks the working days of the user from the first to the
+// last day against the working time act. userId nil checks all users.
+func (r *TimestampsService) GetCompliance(
+	ctx context.Context,
+	userId *int64,
+	from time.Time,
+	to time.Time,
+) ([]domain.ComplianceDay, error) {
+	// the day before is needed for the rest period of the first day
+	start := from.AddDate(0, 0, -1)
+	stop := to.AddDate(0, 0, 1)
+
+	var timestamps []domain.Timestamp
+	var breaks []domain.TimestampBreak
+	var err error
+	if userId == nil {
+		timestamps, err = r.timestamps.GetAllInRange(ctx, start, stop)
+		if err != nil {
+			return nil, err
+		}
+		breaks, err = r.breaks.GetAllInRange(ctx, start, stop)
+	} else {
+		timestamps, err = r.timestamps.GetInRange(ctx, *userId, start, stop)
+		if err != nil {
+			return nil, err
+		}
+		breaks, err = r.breaks.GetInRange(ctx, *userId, start, stop)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	days := domain.CheckCompliance(
+		timestamps,
+		breaks,
+		config.GetConfig().AutoBreakDeduction,
+		time.Local,
+	)
+
+	return slices.DeleteFunc(days, func(d domain.ComplianceDay) bool {
+		return d.Date.Before(from) || d.Date.After(to)
+	}), nil
+}
+
+// CloseForgotten stops the timers that were left running once the configured
+// close policy ends them, together with their break if they were paused.
+// Breaks that started after the timer is stopped are removed. The timestamps
+// are flagged as auto closed and their users asked to correct them. Timers
+// that fail are logged and retried on the next run.
+func (r *TimestampsService) CloseForgotten(ctx context.Context) error {
+	cfg := config.GetConfig()
+	policy, err := domain.ParseTimerClosePolicy(
+		cfg.TimerClosePolicy,
+		cfg.TimerCloseAt,
+		cfg.TimerMaxHours,
+	)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	open, err := r.timestamps.GetOpenBefore(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range open {
+		if err := r.closeForgotten(ctx, t, policy, now); err != nil {
+			r.log.Error(
+				"failed to close forgotten timer",
+				slog.Int64("id", t.ID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	return nil
+}
+
+// closeForgotten stops the timer once the policy ends it.
+func (r *TimestampsService) closeForgotten(
+	ctx context.Context,
+	t domain.Timestamp,
+	policy domain.TimerClosePolicy,
+	now time.Time,
+) error {
+	scheduled := 0.0
+	if policy.Mode == domain.TimerCloseSchedule {
+		var err error
+		scheduled, err = r.scheduledHours(ctx, t.UserID, t.StartTime.In(time.Local))
+		if err != nil {
+			return err
+		}
+	}
+
+	end := policy.CloseAt(t.StartTime, scheduled, time.Local)
+	if end.After(now) {
+		return nil
+	}
+
+	var closed *domain.Timestamp
+	err := r.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		closed, err = r.timestamps.AutoClose(ctx, t.ID, end)
+		if err != nil || closed == nil {
+			return err
+		}
+		return r.breaks.EndOpen(ctx, t.ID, end)
+	})
+	if err != nil {
+		return err
+	}
+	if closed == nil {
+		// stopped by the user in the meantime
+		return nil
+	}
+
+	err = r.notif.CreateAndNotify(
+		ctx,
+		domain.TimerAutoClosedMsg(closed.StartTime, *closed.EndTime),
+		[]domain.User{{ID: closed.UserID}},
+	)
+	if err != nil {
+		return err
+	}
+
+	r.log.Info(
+		"Closed forgotten timer",
+		slog.Int64("id", t.ID),
+		slog.Int64("userId", t.UserID),
+		slog.String("policy", policy.Mode),
+	)
+
+	return nil
+}
+
+// scheduledHours returns the hours the contract of the user schedules on the
+// day.
+func (r *TimestampsService) scheduledHours(
+	ctx context.Context,
+	userId int64,
+	day time.Time,
+) (float64, error) {
+	user, err := r.user.GetById(ctx, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	contracts, err := r.contract.GetForUser(ctx, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	contract := user.ContractAt(contracts, day)
+	return contract.WeekSchedule().Hours(day), nil
 }
 
 func (r *TimestampsService) Delete(ctx context.Context, id int64) error {
@@ -113,11 +549,138 @@ func (r *TimestampsService) GetLatest(
 	return r.timestamps.GetLatest(ctx, userId)
 }
 
+// GetFirst returns the earliest timestamp of the user, nil if there is none.
+func (r *TimestampsService) GetFirst(
+	ctx context.Context,
+	userId int64,
+) (*domain.Timestamp, error) {
+	return r.timestamps.GetFirst(ctx, userId)
+}
+
+// Update overwrites the start and end of the timestamp as the editor and keeps
+// the previous values in the history.
 func (r *TimestampsService) Update(
 	ctx context.Context,
 	ts *domain.Timestamp,
+	editorId int64,
 ) (domain.Timestamp, error) {
-	return r.timestamps.Update(ctx, ts)
+	var t domain.Timestamp
+	err := r.uow.Do(ctx, func(ctx context.Context) error {
+		old, err := r.timestamps.GetById(ctx, ts.ID)
+		if err != nil {
+			return err
+		}
+		t, err = r.timestamps.Update(ctx, ts)
+		if err != nil {
+			return err
+		}
+		return r.record(ctx, domain.CorrectionChange, &old, &t, nil, editorId)
+	})
+	if err != nil {
+		return domain.Timestamp{}, err
+	}
+
+	return t, nil
+}
+
+// Apply changes, adds or deletes the timestamp of an accepted correction and
+// keeps the previous values in the history. It returns the timestamp after
+// the correction, or the removed one of a delete.
+func (r *TimestampsService) Apply(
+	ctx context.Context,
+	c *domain.TimestampCorrection,
+	editorId int64,
+) (domain.Timestamp, error) {
+	var t domain.Timestamp
+	err := r.uow.Do(ctx, func(ctx context.Context) error {
+		if c.Kind == domain.CorrectionAdd {
+			var err error
+			t, err = r.timestamps.Create(ctx, &domain.Timestamp{
+				UserID:    c.UserID,
+				StartTime: *c.StartTime,
+				EndTime:   c.EndTime,
+			})
+			if err != nil {
+				return err
+			}
+			return r.record(ctx, c.Kind, nil, &t, &c.ID, editorId)
+		}
+
+		old, err := r.timestamps.GetById(ctx, *c.TimestampID)
+		if err != nil {
+			return err
+		}
+
+		if c.Kind == domain.CorrectionDelete {
+			t = old
+			if err := r.timestamps.Delete(ctx, old.ID); err != nil {
+				return err
+			}
+			return r.record(ctx, c.Kind, &old, nil, &c.ID, editorId)
+		}
+
+		corrected := c.Corrected(old)
+		t, err = r.timestamps.Update(ctx, &corrected)
+		if err != nil {
+			return err
+		}
+		return r.record(ctx, c.Kind, &old, &t, &c.ID, editorId)
+	})
+	if err != nil {
+		return domain.Timestamp{}, err
+	}
+
+	return t, nil
+}
+
+// record adds the values of the timestamp before and after a correction to
+// the history, old is nil for an added timestamp and updated for a deleted one.
+func (r *TimestampsService) record(
+	ctx context.Context,
+	kind string,
+	old *domain.Timestamp,
+	updated *domain.Timestamp,
+	correctionId *int64,
+	editorId int64,
+) error {
+	h := domain.TimestampHistory{
+		CorrectionID: correctionId,
+		Kind:         kind,
+		ChangedBy:    &editorId,
+	}
+	if old != nil {
+		h.TimestampID, h.UserID = old.ID, old.UserID
+		h.OldStartTime, h.OldEndTime = &old.StartTime, old.EndTime
+	}
+	if updated != nil {
+		h.TimestampID, h.UserID = updated.ID, updated.UserID
+		h.NewStartTime, h.NewEndTime = &updated.StartTime, updated.EndTime
+	}
+
+	_, err := r.history.Create(ctx, &h)
+	return err
+}
+
+// GetHistory returns the corrections of the timestamp, which may already be
+// deleted, to its owner or an admin.
+func (r *TimestampsService) GetHistory(
+	ctx context.Context,
+	id int64,
+	user *domain.User,
+) ([]domain.TimestampHistory, error) {
+	history, err := r.history.GetForTimestamp(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(history) > 0 && history[0].UserID != user.ID && !user.IsAdmin() {
+		return nil, fmt.Errorf(
+			"User: %v has no permission to view the timestamp.",
+			user.Username,
+		)
+	}
+
+	return history, nil
 }
 
 func (r *TimestampsService) GetAllForUser(
@@ -135,7 +698,7 @@ func (r *TimestampsService) GetWorkHoursForYearForAllUsers(
 	workHours := map[int64]domain.WorkHours{}
 
 	for _, user := range users {
-		result, err := r.GetWorkHoursForYear(ctx, user.ID, year, user.WorkdayHours)
+		result, err := r.GetWorkHoursForYear(ctx, &user, year)
 		if err != nil {
 			continue
 		}
@@ -145,11 +708,12 @@ func (r *TimestampsService) GetWorkHoursForYearForAllUsers(
 	return workHours
 }
 
+// GetWorkHoursForYear returns the work hours of the user in the year up to
+// yesterday.
 func (r *TimestampsService) GetWorkHoursForYear(
 	ctx context.Context,
-	userId int64,
+	user *domain.User,
 	year int,
-	workDayHours float64,
 ) (domain.WorkHours, error) {
 	now := time.Now()
 	loc := now.Location()
@@ -191,61 +755,55 @@ func (r *TimestampsService) GetWorkHoursForYear(
 	if periodEnd.Before(yearStart) {
 		return domain.WorkHours{}, nil
 	}
-	// ---- HOLIDAYS / VACATION / SICKNESS ----
-	// NOTE: these must use the same period (yearStart..periodEnd) to be fully consistent.
-	holidays, err := r.event.GetNonWeekendCountHolidays(ctx, yearStart, periodEnd)
-	if err != nil {
-		return domain.WorkHours{}, err
-	}
 
-	vacation, err := r.event.GetUsedVacation(ctx, userId, yearStart, periodEnd)
+	return r.GetWorkHours(ctx, user, yearStart, periodEnd)
+}
+
+// GetWorkHours compares the worked hours of the user in [start, end] with the
+// hours expected by the contract in effect on each day. Breaks do not count
+// as worked, neither does the break deducted automatically.
+func (r *TimestampsService) GetWorkHours(
+	ctx context.Context,
+	user *domain.User,
+	start time.Time,
+	end time.Time,
+) (domain.WorkHours, error) {
+	// ---- EXPECTED HOURS / HOLIDAYS / VACATION / SICKNESS ----
+
+	contracts, err := r.contract.GetForUser(ctx, user.ID)
 	if err != nil {
 		return domain.WorkHours{}, err
 	}
 
-	sickDays := 0
-	allEvents, err := r.event.GetAllByUserId(ctx, userId)
+	hours, err := r.event.GetExpectedWorkHours(ctx, user, contracts, start, end)
 	if err != nil {
 		return domain.WorkHours{}, err
 	}
 
-	for _, e := range allEvents {
-		if e.Name != "krank" {
-			continue
-		}
-		// only count sick days in [yearStart, periodEnd]
-		if !e.ScheduledAt.Before(yearStart) && !e.ScheduledAt.After(periodEnd) {
-			sickDays++
-		}
-	}
-
 	// ---- WORKED HOURS ----
 
-	worked, err := r.timestamps.GetTotalSecondsInRange(ctx, userId, yearStart, periodEnd)
+	worked, err := r.timestamps.GetTotalSecondsInRange(ctx, user.ID, start, end)
 	if err != nil {
 		return domain.WorkHours{}, err
 	}
 
-	workedHours := worked / 60 / 60
-
-	// ---- EXPECTED HOURS (weekdays between yearStart and periodEnd) ----
+	breaks, err := r.breaks.GetTotalSecondsInRange(ctx, user.ID, start, end)
+	if err != nil {
+		return domain.WorkHours{}, err
+	}
+	worked -= breaks
 
-	expectedDays := 0
-	for d := yearStart; !d.After(periodEnd); d = d.AddDate(0, 0, 1) {
-		wd := d.Weekday()
-		if wd != time.Saturday && wd != time.Sunday {
-			expectedDays++
+	if config.GetConfig().AutoBreakDeduction {
+		days, err := r.GetCompliance(ctx, &user.ID, start, end)
+		if err != nil {
+			return domain.WorkHours{}, err
+		}
+		for _, d := range days {
+			worked -= float64(d.Deducted * 60)
 		}
 	}
 
-	expectedHours := (float64(expectedDays-holidays) - vacation - float64(sickDays)) * workDayHours
-	holidayHours := float64(holidays) * workDayHours
-	vacationHours := vacation * workDayHours
+	hours.Worked = worked / 60 / 60
 
-	return domain.WorkHours{
-		Worked:   workedHours,
-		Expected: expectedHours,
-		Holidays: holidayHours,
-		Vacation: vacationHours,
-	}, nil
+	return hours, nil
 }
@@ -5,59 +5,118 @@ import (
 	"log/slog"
 	"time"
 
+	"chrono/config"
 	"chrono/internal/domain"
 )
 
 type TokenService struct {
-	refresh domain.RefreshTokenRepository
-	vac     domain.VacationTokenRepository
-	log     *slog.Logger
+	refresh  domain.RefreshTokenRepository
+	vac      domain.VacationTokenRepository
+	contract domain.UserContractRepository
+	uow      domain.UnitOfWork
+	log      *slog.Logger
 }
 
 func NewTokenService(
 	r domain.RefreshTokenRepository,
 	v domain.VacationTokenRepository,
+	c domain.UserContractRepository,
+	w domain.UnitOfWork,
 	log *slog.Logger,
 ) *TokenService {
-	return &TokenService{refresh: r, vac: v, log: log}
+	return &TokenService{refresh: r, vac: v, contract: c, uow: w, log: log}
 }
 
+// InitYearlyTokens grants the vacation entitlement of the user once per year.
+// The refresh token marking the grant and the grant are stored together.
 func (svc *TokenService) InitYearlyTokens(ctx context.Context, user *domain.User, year int) error {
-	exists, err := svc.CreateRefreshTokenIfNotExists(ctx, user.ID, year)
-	if err != nil {
-		svc.log.Error("failed to get refresh token")
-		return err
-	}
+	return svc.uow.Do(ctx, func(ctx context.Context) error {
+		exists, err := svc.CreateRefreshTokenIfNotExists(ctx, user.ID, year)
+		if err != nil {
+			svc.log.Error("failed to get refresh token")
+			return err
+		}
+
+		if exists {
+			return nil
+		}
+
+		entitlement, err := svc.Entitlement(ctx, user, year)
+		if err != nil {
+			return err
+		}
+		if entitlement <= 0 {
+			return nil
+		}
+
+		_, err = svc.CreateVacationToken(
+			ctx,
+			domain.NewVacationToken(entitlement, year, user.ID, domain.ReasonYearlyGrant),
+		)
+		if err != nil {
+			svc.log.Error("failed to create vac tokens")
+			return err
+		}
 
-	if exists || user.VacationDays <= 0 {
 		return nil
-	}
-
-	_, err = svc.CreateVacationToken(ctx, float64(user.VacationDays), year, user.ID)
-	if err != nil {
-		svc.log.Error("failed to create vac tokens")
-		return err
-	}
-
-	return nil
+	})
 }
 
+// UpdateYearlyTokens adjusts the yearly grant of a user to their current
+// entitlement, editorId is recorded as the acting user.
 func (svc *TokenService) UpdateYearlyTokens(
 	ctx context.Context,
-	userId int64,
-	vacation, year int,
+	user *domain.User,
+	year int,
+	editorId int64,
 ) error {
-	_, err := svc.CreateRefreshTokenIfNotExists(ctx, userId, year)
-	if err != nil {
-		return err
-	}
+	return svc.uow.Do(ctx, func(ctx context.Context) error {
+		_, err := svc.CreateRefreshTokenIfNotExists(ctx, user.ID, year)
+		if err != nil {
+			return err
+		}
+
+		tokens, err := svc.vac.GetForUser(ctx, user.ID, year)
+		if err != nil {
+			return err
+		}
+
+		granted := domain.YearlyGranted(tokens)
+
+		entitlement, err := svc.Entitlement(ctx, user, year)
+		if err != nil {
+			return err
+		}
+
+		delta := entitlement - granted
+		if delta == 0 {
+			return nil
+		}
+
+		token := domain.NewVacationToken(delta, year, user.ID, domain.ReasonYearlyGrant)
+		token.CreatedBy = &editorId
+		_, err = svc.CreateVacationToken(ctx, token)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
 
-	_, err = svc.CreateVacationToken(ctx, float64(vacation), year, userId)
+// Entitlement returns the vacation days the user is entitled to in the year
+// under the contracts in effect.
+func (svc *TokenService) Entitlement(
+	ctx context.Context,
+	user *domain.User,
+	year int,
+) (float64, error) {
+	contracts, err := svc.contract.GetForUser(ctx, user.ID)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	return nil
+	return user.VacationEntitlement(year, config.GetConfig().StandardWorkdaysWeek, contracts), nil
 }
 
 func (svc *TokenService) DeleteAll(ctx context.Context) error {
@@ -117,23 +176,10 @@ func (svc *TokenService) CreateRefreshTokenIfNotExists(
 }
 
 func (svc *TokenService) CreateVacationToken(
-	ctx context.Context, value float64, year int, userId int64,
+	ctx context.Context,
+	t domain.CreateVacationToken,
 ) (*domain.VacationToken, error) {
-	start := time.Date(
-		year,
-		time.January,
-		1,
-		0,
-		0,
-		0,
-		0,
-		time.UTC,
-	)
-	end := start.AddDate(1, 2, 0)
-	return svc.vac.Create(
-		ctx,
-		domain.CreateVacationToken{StartDate: start, EndDate: end, UserID: userId, Value: value},
-	)
+	return svc.vac.Create(ctx, t)
 }
 
 func (svc *TokenService) DeleteVacationToken(ctx context.Context, id int64) error {
@@ -152,3 +198,40 @@ func (svc *TokenService) GetRemainingVacationForUser(
 ) (float64, error) {
 	return svc.vac.GetRemainingVacationForUser(ctx, userId, start, end)
 }
+
+// GetLedger returns the vacation tokens of a user for the given year together
+// with the running balance.
+func (svc *TokenService) GetLedger(
+	ctx context.Context,
+	userId int64,
+	year int,
+) (domain.VacationLedger, error) {
+	tokens, err := svc.vac.GetForUser(ctx, userId, year)
+	if err != nil {
+		return domain.VacationLedger{}, err
+	}
+
+	return domain.NewVacationLedger(userId, year, tokens), nil
+}
+
+func (svc *TokenService) GetPots(
+	ctx context.Context,
+	userId int64,
+	start, end time.Time,
+) ([]domain.VacationPot, error) {
+	return svc.vac.GetPots(ctx, userId, start, end)
+}
+
+func (svc *TokenService) GetForAbsence(
+	ctx context.Context,
+	absenceId int64,
+) ([]domain.VacationToken, error) {
+	return svc.vac.GetForAbsence(ctx, absenceId)
+}
+
+func (svc *TokenService) GetForEvent(
+	ctx context.Context,
+	eventId int64,
+) ([]domain.VacationToken, error) {
+	return svc.vac.GetForEvent(ctx, eventId)
+}
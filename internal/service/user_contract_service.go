@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"chrono/internal/domain"
+)
+
+// contractEpoch is the start of the contract that is seeded from the terms
+// stored on the user before the first change is recorded.
+var contractEpoch = time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+type UserContractService struct {
+	contract domain.UserContractRepository
+	user     domain.UserRepository
+	token    *TokenService
+	uow      domain.UnitOfWork
+	log      *slog.Logger
+}
+
+func NewUserContractService(
+	c domain.UserContractRepository,
+	u domain.UserRepository,
+	t *TokenService,
+	w domain.UnitOfWork,
+	log *slog.Logger,
+) *UserContractService {
+	return &UserContractService{contract: c, user: u, token: t, uow: w, log: log}
+}
+
+func (svc *UserContractService) GetForUser(
+	ctx context.Context,
+	userId int64,
+) ([]domain.UserContract, error) {
+	return svc.contract.GetForUser(ctx, userId)
+}
+
+// Create parses the form and adds the contract period for the user.
+func (svc *UserContractService) Create(
+	ctx context.Context,
+	userId int64,
+	form domain.CreateUserContract,
+	editor *domain.User,
+) (*domain.UserContract, error) {
+	validFrom, err := time.Parse(time.DateOnly, form.ValidFrom)
+	if err != nil {
+		return nil, fmt.Errorf("invalid valid from date %q", form.ValidFrom)
+	}
+
+	var validTo *time.Time
+	if form.ValidTo != "" {
+		t, err := time.Parse(time.DateOnly, form.ValidTo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid valid to date %q", form.ValidTo)
+		}
+		validTo = &t
+	}
+
+	var schedule *string
+	if form.Schedule != "" {
+		schedule = &form.Schedule
+	}
+
+	return svc.Add(ctx, domain.UserContract{
+		ValidFrom:    validFrom,
+		ValidTo:      validTo,
+		WorkdayHours: form.WorkdayHours,
+		WorkdaysWeek: form.WorkdaysWeek,
+		VacationDays: form.VacationDays,
+		UserID:       userId,
+		Schedule:     schedule,
+	}, editor)
+}
+
+// Add records a new contract period. The contract in effect on its first day
+// ends the day before, a contract starting on the same day is replaced and
+// periods after it can not be overlapped. The terms on the user and the
+// vacation of the current year follow the contract in effect today, they are
+// stored together with the contract. A schedule replaces the hours per day
+// and the workdays per week.
+func (svc *UserContractService) Add(
+	ctx context.Context,
+	c domain.UserContract,
+	editor *domain.User,
+) (*domain.UserContract, error) {
+	if c.Schedule != nil {
+		w, err := domain.ParseWeekSchedule(*c.Schedule)
+		if err != nil {
+			return nil, err
+		}
+		if w.Workdays() == 0 {
+			return nil, fmt.Errorf("schedule does not contain any workdays")
+		}
+
+		normalized := w.String()
+		c.Schedule = &normalized
+		c.WorkdaysWeek = w.Workdays()
+		c.WorkdayHours = w.Total() / w.Workdays()
+	}
+
+	if c.WorkdayHours <= 0 || c.WorkdayHours > 24 {
+		return nil, fmt.Errorf("invalid workday hours %v", c.WorkdayHours)
+	}
+	if c.WorkdaysWeek <= 0 || c.WorkdaysWeek > 7 {
+		return nil, fmt.Errorf("invalid workdays per week %v", c.WorkdaysWeek)
+	}
+	if c.VacationDays < 0 {
+		return nil, fmt.Errorf("negative vacation value is not supported %v", c.VacationDays)
+	}
+	if c.ValidTo != nil && c.ValidTo.Before(c.ValidFrom) {
+		return nil, fmt.Errorf("contract must not end before it starts")
+	}
+
+	c.CreatedBy = &editor.ID
+
+	user, err := svc.user.GetById(ctx, c.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts, err := svc.contract.GetForUser(ctx, c.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	dayBefore := c.ValidFrom.AddDate(0, 0, -1)
+	save := []domain.UserContract{}
+	if len(contracts) == 0 && c.ValidFrom.After(contractEpoch) {
+		seed := user.ContractAt(nil, contractEpoch)
+		seed.ValidTo = &dayBefore
+		seed.CreatedBy = c.CreatedBy
+		save = append(save, seed)
+	}
+
+	for _, existing := range contracts {
+		switch {
+		case existing.ValidFrom.Equal(c.ValidFrom):
+			c.ID = existing.ID
+		case existing.ValidFrom.After(c.ValidFrom):
+			return nil, fmt.Errorf(
+				"contract from %v overlaps the contract from %v",
+				c.ValidFrom.Format(time.DateOnly),
+				existing.ValidFrom.Format(time.DateOnly),
+			)
+		case existing.Contains(c.ValidFrom):
+			existing.ValidTo = &dayBefore
+			save = append(save, existing)
+		}
+	}
+	save = append(save, c)
+
+	var contract domain.UserContract
+	err = svc.uow.Do(ctx, func(ctx context.Context) error {
+		saved, err := svc.contract.Save(ctx, save)
+		if err != nil {
+			return err
+		}
+		contract = saved[len(saved)-1]
+
+		now := time.Now().UTC()
+		if contract.Contains(now) {
+			user.WorkdayHours = contract.WorkdayHours
+			user.WorkdaysWeek = contract.WorkdaysWeek
+			user.VacationDays = contract.VacationDays
+			user.Schedule = contract.Schedule
+			user, err = svc.user.Update(ctx, user)
+			if err != nil {
+				return err
+			}
+		}
+
+		if contract.ValidFrom.Year() <= now.Year() {
+			return svc.token.UpdateYearlyTokens(ctx, user, now.Year(), editor.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	svc.log.Info(
+		"Added user contract",
+		slog.Int64("userId", contract.UserID),
+		slog.String("validFrom", contract.ValidFrom.Format(time.DateOnly)),
+	)
+
+	return &contract, nil
+}
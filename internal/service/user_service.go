@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"time"
 
+	"chrono/config"
 	"chrono/internal/domain"
 )
 
@@ -53,6 +54,28 @@ func (svc *UserService) GetAll(ctx context.Context) ([]domain.User, error) {
 	return svc.user.GetAll(ctx)
 }
 
+// InitYearlyTokens grants the vacation entitlement of the year to every user
+// that did not get it yet. The bot, disabled users and users that are not
+// employed in the year are skipped.
+func (svc *UserService) InitYearlyTokens(ctx context.Context, year int) error {
+	users, err := svc.user.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	botName := config.GetConfig().BotName
+	for _, u := range users {
+		if !u.IsStaff(botName) || !u.EmployedInYear(year) {
+			continue
+		}
+		if err := svc.token.InitYearlyTokens(ctx, &u, year); err != nil {
+			return fmt.Errorf("init yearly tokens of user %v: %w", u.ID, err)
+		}
+	}
+
+	return nil
+}
+
 func (svc *UserService) GetUsersWithVacation(
 	ctx context.Context,
 ) ([]*domain.UserWithVacation, error) {
@@ -92,26 +115,6 @@ func (svc *UserService) SetUserRole(
 	return updatedUser, nil
 }
 
-func (svc *UserService) SetVacation(ctx context.Context, userId int64, vacation, year int) error {
-	user, err := svc.GetById(ctx, userId)
-	if err != nil {
-		return err
-	}
-	oldVacation := int(user.VacationDays)
-
-	if vacation < 0 {
-		svc.log.Error("negative vacation value is not supported", "value", vacation)
-		return fmt.Errorf("negative vacation value is not supported %v", vacation)
-	}
-	user.VacationDays = int64(vacation)
-	_, err = svc.Update(ctx, user)
-	if err != nil {
-		return err
-	}
-
-	return svc.token.UpdateYearlyTokens(ctx, userId, vacation-oldVacation, year)
-}
-
 func (svc *UserService) GetConflicting(
 	ctx context.Context,
 	userId int64,
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"chrono/config"
+	"chrono/internal/domain"
+)
+
+type YearCloseService struct {
+	closing domain.YearClosingRepository
+	vac     domain.VacationTokenRepository
+	user    domain.UserRepository
+	log     *slog.Logger
+}
+
+func NewYearCloseService(
+	c domain.YearClosingRepository,
+	v domain.VacationTokenRepository,
+	u domain.UserRepository,
+	log *slog.Logger,
+) *YearCloseService {
+	return &YearCloseService{closing: c, vac: v, user: u, log: log}
+}
+
+// Close expires the vacation that is left in the given year and carries the
+// remaining balance of every user over into the next year. Every year can
+// only be closed once.
+func (svc *YearCloseService) Close(
+	ctx context.Context,
+	year int,
+	closedBy *int64,
+) (*domain.YearClosing, error) {
+	if year >= domain.CurrentYear() {
+		return nil, fmt.Errorf("year %v has not ended yet", year)
+	}
+
+	closed, err := svc.IsClosed(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	if closed {
+		return nil, fmt.Errorf("year %v is already closed", year)
+	}
+
+	cfg := config.GetConfig()
+	expiry, err := domain.CarryOverExpiry(year+1, cfg.CarryOverExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("invalid carry over expiry %q", cfg.CarryOverExpiry)
+	}
+
+	users, err := svc.user.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := []domain.CreateVacationToken{}
+	for _, u := range users {
+		if !u.IsStaff(cfg.BotName) {
+			continue
+		}
+		t, err := svc.closeForUser(ctx, u.ID, year, expiry, cfg.CarryOverCap, closedBy)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t...)
+	}
+
+	closing, err := svc.closing.Close(ctx, year, closedBy, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	svc.log.Info(
+		"Closed vacation year",
+		slog.Int("year", year),
+		slog.Int("tokens", len(tokens)),
+	)
+
+	return closing, nil
+}
+
+func (svc *YearCloseService) closeForUser(
+	ctx context.Context,
+	userId int64,
+	year int,
+	expiry time.Time,
+	limit float64,
+	closedBy *int64,
+) ([]domain.CreateVacationToken, error) {
+	start, end := domain.VacationYear(year)
+
+	remaining, err := svc.vac.GetRemainingVacationForUser(ctx, userId, end, end)
+	if err != nil {
+		return nil, err
+	}
+
+	pots, err := svc.vac.GetPots(ctx, userId, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := []domain.CreateVacationToken{}
+	for _, p := range pots {
+		if p.Value <= 0 {
+			continue
+		}
+		tokens = append(tokens, domain.CreateVacationToken{
+			StartDate: p.StartDate,
+			EndDate:   p.EndDate,
+			Value:     -p.Value,
+			UserID:    userId,
+			Reason:    domain.ReasonExpiry,
+			CreatedBy: closedBy,
+		})
+	}
+
+	carry := domain.CarryOver(remaining, limit)
+	if carry > 0 {
+		nextStart, _ := domain.VacationYear(year + 1)
+		tokens = append(tokens, domain.CreateVacationToken{
+			StartDate: nextStart,
+			EndDate:   expiry,
+			Value:     carry,
+			UserID:    userId,
+			Reason:    domain.ReasonCarryOver,
+			CreatedBy: closedBy,
+		})
+	}
+
+	return tokens, nil
+}
+
+func (svc *YearCloseService) IsClosed(ctx context.Context, year int) (bool, error) {
+	closing, err := svc.closing.GetByYear(ctx, year)
+	if err != nil {
+		return false, err
+	}
+
+	return closing != nil, nil
+}
+
+// CloseLastYear closes the previous year once it is over unless it was closed
+// before.
+func (svc *YearCloseService) CloseLastYear(ctx context.Context) error {
+	year := domain.CurrentYear() - 1
+	closed, err := svc.IsClosed(ctx, year)
+	if err != nil {
+		return err
+	}
+	if closed {
+		return nil
+	}
+
+	_, err = svc.Close(ctx, year, nil)
+	return err
+}